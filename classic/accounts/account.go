@@ -5,16 +5,44 @@ package accounts
 
 import (
 	"context"
+	"fmt"
 	"net/http"
 
 	"github.com/trustero/jamf-api-client-go/classic/client"
+	"github.com/trustero/jamf-api-client-go/pager"
 
 	"github.com/pkg/errors"
 )
 
 // Accounts returns all system accounts - users and groups
 func (j *Service) List() (accounts *JamfAccountsId, response *http.Response, err error) {
-	req, err := http.NewRequestWithContext(context.Background(), "GET", j.client.Endpoint, nil)
+	return j.list(context.Background())
+}
+
+// IterateUsers returns an iterator over all system user accounts
+func (j *Service) IterateUsers() *pager.Pager[JamfUserId] {
+	return pager.Single(func(ctx context.Context) ([]JamfUserId, error) {
+		accounts, _, err := j.list(ctx)
+		if err != nil {
+			return nil, err
+		}
+		return accounts.UsersIds, nil
+	})
+}
+
+// IterateGroups returns an iterator over all system group accounts
+func (j *Service) IterateGroups() *pager.Pager[JamfGroupId] {
+	return pager.Single(func(ctx context.Context) ([]JamfGroupId, error) {
+		accounts, _, err := j.list(ctx)
+		if err != nil {
+			return nil, err
+		}
+		return accounts.GroupsIds, nil
+	})
+}
+
+func (j *Service) list(ctx context.Context) (accounts *JamfAccountsId, response *http.Response, err error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", j.client.Endpoint, nil)
 	if err != nil {
 		return nil, nil, errors.Wrap(err, "error building JAMF computer query request")
 	}
@@ -30,8 +58,12 @@ func (j *Service) List() (accounts *JamfAccountsId, response *http.Response, err
 
 // GetByUserId returns the name, id for a specific user given its Id
 func (j *Service) GetByUserId(identifier int) (user *JamfUser, response *http.Response, err error) {
+	return j.getUser(context.Background(), identifier)
+}
+
+func (j *Service) getUser(ctx context.Context, identifier int) (user *JamfUser, response *http.Response, err error) {
 	ep := j.client.UserEndpoint(identifier)
-	req, err := http.NewRequestWithContext(context.Background(), "GET", ep, nil)
+	req, err := http.NewRequestWithContext(ctx, "GET", ep, nil)
 	if err != nil {
 		err = errors.Wrapf(err, "error building JAMF account user request for computer: %v (%s)", identifier, ep)
 		return
@@ -48,8 +80,12 @@ func (j *Service) GetByUserId(identifier int) (user *JamfUser, response *http.Re
 
 // GetByGroupId returns the name, id for a specific group given its Id
 func (j *Service) GetByGroupId(identifier int) (group *JamfGroup, response *http.Response, err error) {
+	return j.getGroup(context.Background(), identifier)
+}
+
+func (j *Service) getGroup(ctx context.Context, identifier int) (group *JamfGroup, response *http.Response, err error) {
 	ep := j.client.GroupEndpoint(identifier)
-	req, err := http.NewRequestWithContext(context.Background(), "GET", ep, nil)
+	req, err := http.NewRequestWithContext(ctx, "GET", ep, nil)
 	if err != nil {
 		err = errors.Wrapf(err, "error building JAMF account group request for groupid : %v (%s)", identifier, ep)
 		return
@@ -63,3 +99,205 @@ func (j *Service) GetByGroupId(identifier int) (group *JamfGroup, response *http
 	group = &res.Group
 	return
 }
+
+// GetByUserName returns a specific user given its name
+func (j *Service) GetByUserName(name string) (user *JamfUser, response *http.Response, err error) {
+	ep := j.client.UserNameEndpoint(name)
+	req, err := http.NewRequestWithContext(context.Background(), "GET", ep, nil)
+	if err != nil {
+		err = errors.Wrapf(err, "error building JAMF account user request for username: %v (%s)", name, ep)
+		return
+	}
+
+	res := &JamfUserResp{}
+	if response, err = client.MakeAPIrequest(j.client, req, &res); err != nil {
+		err = errors.Wrapf(err, "unable to query user for username: %v (%s)", name, ep)
+		return
+	}
+	user = &res.User
+	return
+}
+
+// GetByGroupName returns a specific group given its name
+func (j *Service) GetByGroupName(name string) (group *JamfGroup, response *http.Response, err error) {
+	ep := j.client.GroupNameEndpoint(name)
+	req, err := http.NewRequestWithContext(context.Background(), "GET", ep, nil)
+	if err != nil {
+		err = errors.Wrapf(err, "error building JAMF account group request for groupname: %v (%s)", name, ep)
+		return
+	}
+
+	res := &JamfGroupResp{}
+	if response, err = client.MakeAPIrequest(j.client, req, &res); err != nil {
+		err = errors.Wrapf(err, "unable to query account group for groupname: %v (%s)", name, ep)
+		return
+	}
+	group = &res.Group
+	return
+}
+
+// CreateUser will create a user account in Jamf. Privileges are only applied when the
+// privilege set is Custom
+func (j *Service) CreateUser(user *JamfUser) (result *JamfUser, response *http.Response, err error) {
+	// 0 denotes the next available Id
+	ep := j.client.UserEndpoint(0)
+
+	if user == nil {
+		err = errors.Wrapf(fmt.Errorf("Empty payload"), "unable to process JAMF creation request for account user: (%s)", ep)
+		return
+	}
+
+	if user.Name == "" {
+		err = errors.Wrapf(fmt.Errorf("Name required for new account user"), "unable to process JAMF creation request for account user: (%s)", ep)
+		return
+	}
+
+	if err = ValidateUser(user); err != nil {
+		err = errors.Wrapf(err, "account user validation failed: %v", user.Name)
+		return
+	}
+
+	result = &JamfUser{}
+	response, err = client.SendXML(j.client, "POST", ep, user, result)
+	if err != nil {
+		err = errors.Wrapf(err, "unable to process JAMF creation request for account user: %v (%s)", user.Name, ep)
+	}
+	return
+}
+
+// UpdateUser will update a user account in Jamf by either Id or Name
+func (j *Service) UpdateUser(identifier interface{}, user *JamfUser) (result *JamfUser, response *http.Response, err error) {
+	ep, err := j.userEndpoint(identifier)
+	if err != nil {
+		err = errors.Wrapf(err, "error building JAMF query request for account user: %v", identifier)
+		return
+	}
+
+	if user == nil {
+		err = errors.Wrapf(fmt.Errorf("Empty payload"), "unable to process JAMF update request for account user: %v (%s)", identifier, ep)
+		return
+	}
+
+	if err = ValidateUser(user); err != nil {
+		err = errors.Wrapf(err, "account user validation failed: %v", identifier)
+		return
+	}
+
+	result = &JamfUser{}
+	response, err = client.SendXML(j.client, "PUT", ep, user, result)
+	if err != nil {
+		err = errors.Wrapf(err, "unable to process JAMF update request for account user: %v (%s)", identifier, ep)
+	}
+	return
+}
+
+// DeleteUser will delete a user account by either Id or Name
+func (j *Service) DeleteUser(identifier interface{}) (result *JamfUser, response *http.Response, err error) {
+	ep, err := j.userEndpoint(identifier)
+	if err != nil {
+		err = errors.Wrapf(err, "error building JAMF query request for account user: %v", identifier)
+		return
+	}
+
+	result = &JamfUser{}
+	response, err = client.SendXML(j.client, "DELETE", ep, nil, result)
+	if err != nil {
+		err = errors.Wrapf(err, "unable to process JAMF deletion request for account user: %v (%s)", identifier, ep)
+	}
+	return
+}
+
+// CreateGroup will create a group account in Jamf. Privileges are only applied when the
+// privilege set is Custom
+func (j *Service) CreateGroup(group *JamfGroup) (result *JamfGroup, response *http.Response, err error) {
+	// 0 denotes the next available Id
+	ep := j.client.GroupEndpoint(0)
+
+	if group == nil {
+		err = errors.Wrapf(fmt.Errorf("Empty payload"), "unable to process JAMF creation request for account group: (%s)", ep)
+		return
+	}
+
+	if group.Name == "" {
+		err = errors.Wrapf(fmt.Errorf("Name required for new account group"), "unable to process JAMF creation request for account group: (%s)", ep)
+		return
+	}
+
+	if err = ValidateGroup(group); err != nil {
+		err = errors.Wrapf(err, "account group validation failed: %v", group.Name)
+		return
+	}
+
+	result = &JamfGroup{}
+	response, err = client.SendXML(j.client, "POST", ep, group, result)
+	if err != nil {
+		err = errors.Wrapf(err, "unable to process JAMF creation request for account group: %v (%s)", group.Name, ep)
+	}
+	return
+}
+
+// UpdateGroup will update a group account in Jamf by either Id or Name
+func (j *Service) UpdateGroup(identifier interface{}, group *JamfGroup) (result *JamfGroup, response *http.Response, err error) {
+	ep, err := j.groupEndpoint(identifier)
+	if err != nil {
+		err = errors.Wrapf(err, "error building JAMF query request for account group: %v", identifier)
+		return
+	}
+
+	if group == nil {
+		err = errors.Wrapf(fmt.Errorf("Empty payload"), "unable to process JAMF update request for account group: %v (%s)", identifier, ep)
+		return
+	}
+
+	if err = ValidateGroup(group); err != nil {
+		err = errors.Wrapf(err, "account group validation failed: %v", identifier)
+		return
+	}
+
+	result = &JamfGroup{}
+	response, err = client.SendXML(j.client, "PUT", ep, group, result)
+	if err != nil {
+		err = errors.Wrapf(err, "unable to process JAMF update request for account group: %v (%s)", identifier, ep)
+	}
+	return
+}
+
+// DeleteGroup will delete a group account by either Id or Name
+func (j *Service) DeleteGroup(identifier interface{}) (result *JamfGroup, response *http.Response, err error) {
+	ep, err := j.groupEndpoint(identifier)
+	if err != nil {
+		err = errors.Wrapf(err, "error building JAMF query request for account group: %v", identifier)
+		return
+	}
+
+	result = &JamfGroup{}
+	response, err = client.SendXML(j.client, "DELETE", ep, nil, result)
+	if err != nil {
+		err = errors.Wrapf(err, "unable to process JAMF deletion request for account group: %v (%s)", identifier, ep)
+	}
+	return
+}
+
+// userEndpoint returns the endpoint for a user given its Id (int) or name (string)
+func (j *Service) userEndpoint(identifier interface{}) (string, error) {
+	switch id := identifier.(type) {
+	case string:
+		return j.client.UserNameEndpoint(id), nil
+	case int:
+		return j.client.UserEndpoint(id), nil
+	default:
+		return "", fmt.Errorf("invalid identifier of type (%T) passed for %s please use name (string) or id (int)", identifier, j.client.Endpoint)
+	}
+}
+
+// groupEndpoint returns the endpoint for a group given its Id (int) or name (string)
+func (j *Service) groupEndpoint(identifier interface{}) (string, error) {
+	switch id := identifier.(type) {
+	case string:
+		return j.client.GroupNameEndpoint(id), nil
+	case int:
+		return j.client.GroupEndpoint(id), nil
+	default:
+		return "", fmt.Errorf("invalid identifier of type (%T) passed for %s please use name (string) or id (int)", identifier, j.client.Endpoint)
+	}
+}
@@ -0,0 +1,243 @@
+// Unless explicitly stated otherwise all files in this repository are licensed under the Apache-2.0
+// This product includes software developed at Datadog (https://www.datadoghq.com/). Copyright 2020 Datadog, Inc.
+
+package accounts
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// Findings flagged by an audit
+const (
+	// FindingAdministrator is raised when a user has the Administrator privilege set directly or through a group
+	FindingAdministrator = "administrator"
+	// FindingDisabledPrivileged is raised when a disabled user still holds privileges
+	FindingDisabledPrivileged = "disabled_privileged"
+	// FindingNoForcePasswordChange is raised when a local user is not forced to change their password
+	FindingNoForcePasswordChange = "no_force_password_change"
+)
+
+// auditCSVHeader lists the columns written by AuditReport.WriteCSV
+var auditCSVHeader = []string{
+	"id", "name", "full_name", "email", "enabled", "directory_user", "access_level", "privilege_set",
+	"groups", "administrator", "jss_objects", "jss_settings", "jss_actions", "recon", "casper_admin",
+	"casper_remote", "casper_imaging", "findings",
+}
+
+// AccountAudit holds the effective privileges of a single user
+type AccountAudit struct {
+	ID            int           `json:"id"`
+	Name          string        `json:"name"`
+	FullName      string        `json:"full_name,omitempty"`
+	Email         string        `json:"email,omitempty"`
+	Enabled       AccountStatus `json:"enabled"`
+	DirectoryUser bool          `json:"directory_user"`
+	AccessLevel   AccessLevel   `json:"access_level"`
+	PrivilegeSet  PrivilegeSet  `json:"privilege_set"`
+	// Groups holds the names of the groups the user is a member of
+	Groups []string `json:"groups,omitempty"`
+	// Administrator is set when the user or one of its groups has the Administrator privilege set
+	Administrator bool `json:"administrator"`
+	// Privileges is the union of the privileges granted by the privilege sets of the user and its groups
+	Privileges Privileges `json:"privileges"`
+	Findings   []string   `json:"findings,omitempty"`
+}
+
+// HasFinding returns whether the audit raised a given finding for the user
+func (a *AccountAudit) HasFinding(finding string) bool {
+	for _, f := range a.Findings {
+		if f == finding {
+			return true
+		}
+	}
+	return false
+}
+
+// String returns a one line summary of the audit of a user
+func (a *AccountAudit) String() string {
+	if len(a.Findings) == 0 {
+		return fmt.Sprintf("%s: ok", a.Name)
+	}
+	return fmt.Sprintf("%s: %s", a.Name, strings.Join(a.Findings, ", "))
+}
+
+// AuditReport holds the effective privileges of every user
+type AuditReport struct {
+	GeneratedAt time.Time       `json:"generated_at"`
+	Accounts    []*AccountAudit `json:"accounts"`
+}
+
+// Flagged returns the users with at least one finding
+func (r *AuditReport) Flagged() []*AccountAudit {
+	var flagged []*AccountAudit
+	for _, a := range r.Accounts {
+		if len(a.Findings) > 0 {
+			flagged = append(flagged, a)
+		}
+	}
+	return flagged
+}
+
+// WriteJSON writes the report as indented JSON
+func (r *AuditReport) WriteJSON(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(r)
+}
+
+// WriteCSV writes the report with one row per user. Lists such as groups, privileges and
+// findings are joined with "; "
+func (r *AuditReport) WriteCSV(w io.Writer) error {
+	out := csv.NewWriter(w)
+	if err := out.Write(auditCSVHeader); err != nil {
+		return err
+	}
+
+	for _, a := range r.Accounts {
+		p := a.Privileges
+		row := []string{
+			strconv.Itoa(a.ID), a.Name, a.FullName, a.Email, string(a.Enabled), strconv.FormatBool(a.DirectoryUser),
+			string(a.AccessLevel), string(a.PrivilegeSet), joinList(a.Groups), strconv.FormatBool(a.Administrator),
+		}
+		for _, c := range PrivilegeCategories {
+			row = append(row, joinList(p.Category(c)))
+		}
+		row = append(row, joinList(a.Findings))
+		if err := out.Write(row); err != nil {
+			return err
+		}
+	}
+
+	out.Flush()
+	return out.Error()
+}
+
+// Audit fetches every user and group and expands them into the effective privileges of each
+// user, see BuildAuditReport
+func (j *Service) Audit(ctx context.Context) (*AuditReport, error) {
+	accounts, _, err := j.list(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to audit accounts")
+	}
+
+	users := make([]*JamfUser, 0, len(accounts.UsersIds))
+	for _, u := range accounts.UsersIds {
+		user, _, err := j.getUser(ctx, u.Id)
+		if err != nil {
+			return nil, errors.Wrapf(err, "unable to audit account user: %s", u.Name)
+		}
+		users = append(users, user)
+	}
+
+	groups := make([]*JamfGroup, 0, len(accounts.GroupsIds))
+	for _, g := range accounts.GroupsIds {
+		group, _, err := j.getGroup(ctx, g.Id)
+		if err != nil {
+			return nil, errors.Wrapf(err, "unable to audit account group: %s", g.Name)
+		}
+		groups = append(groups, group)
+	}
+
+	report := BuildAuditReport(users, groups)
+	report.GeneratedAt = time.Now().UTC()
+	return report, nil
+}
+
+// BuildAuditReport expands users and groups into the effective privileges of each user, the
+// privilege sets of the user and its groups are expanded to the privileges they grant before
+// they are merged. A user belongs to every group listing it in Members. Directory groups do not list their members in
+// Jamf so their privileges are not attributed to any user.
+// Users are flagged when:
+//   - the user or one of its groups has the Administrator privilege set
+//   - the user is disabled but still holds privileges
+//   - the user is a local user which is not forced to change their password
+func BuildAuditReport(users []*JamfUser, groups []*JamfGroup) *AuditReport {
+	memberOf := map[int][]*JamfGroup{}
+	for _, g := range groups {
+		for _, m := range g.Members {
+			memberOf[m.Id] = append(memberOf[m.Id], g)
+		}
+	}
+
+	report := &AuditReport{}
+	for _, u := range users {
+		a := &AccountAudit{
+			ID:            u.Id,
+			Name:          u.Name,
+			FullName:      u.FullName,
+			Email:         u.Email,
+			Enabled:       u.Enabled,
+			DirectoryUser: u.DirectoryUser != nil && *u.DirectoryUser,
+			AccessLevel:   u.AccessLevel,
+			PrivilegeSet:  u.PrivilegeSet,
+			Administrator: u.PrivilegeSet.Is(Administrator),
+		}
+
+		sets := []Privileges{u.PrivilegeSet.Expand(u.Privileges)}
+		for _, g := range memberOf[u.Id] {
+			a.Groups = append(a.Groups, g.Name)
+			a.Administrator = a.Administrator || g.PrivilegeSet.Is(Administrator)
+			sets = append(sets, g.PrivilegeSet.Expand(g.Privileges))
+		}
+		sort.Strings(a.Groups)
+		a.Privileges = unionPrivileges(sets...)
+
+		if a.Administrator {
+			a.Findings = append(a.Findings, FindingAdministrator)
+		}
+		if !u.Enabled.IsEnabled() && (a.Administrator || !a.Privileges.isEmpty()) {
+			a.Findings = append(a.Findings, FindingDisabledPrivileged)
+		}
+		if !a.DirectoryUser && (u.ForcePasswordChange == nil || !*u.ForcePasswordChange) {
+			a.Findings = append(a.Findings, FindingNoForcePasswordChange)
+		}
+		report.Accounts = append(report.Accounts, a)
+	}
+
+	sort.Slice(report.Accounts, func(a, b int) bool { return report.Accounts[a].Name < report.Accounts[b].Name })
+	return report
+}
+
+// unionPrivileges merges privilege lists into sorted lists without duplicates
+func unionPrivileges(sets ...Privileges) Privileges {
+	union := Privileges{}
+	for _, p := range sets {
+		for _, c := range PrivilegeCategories {
+			for _, privilege := range p.Category(c) {
+				union.Add(c, privilege)
+			}
+		}
+	}
+	for _, c := range PrivilegeCategories {
+		list := union.Category(c)
+		sort.Slice(list, func(a, b int) bool { return list[a] < list[b] })
+	}
+	return union
+}
+
+func (p Privileges) isEmpty() bool {
+	for _, c := range PrivilegeCategories {
+		if len(p.Category(c)) > 0 {
+			return false
+		}
+	}
+	return true
+}
+
+func joinList[T ~string](values []T) string {
+	list := make([]string, len(values))
+	for i, v := range values {
+		list[i] = string(v)
+	}
+	return strings.Join(list, "; ")
+}
@@ -0,0 +1,146 @@
+// Unless explicitly stated otherwise all files in this repository are licensed under the Apache-2.0
+// This product includes software developed at Datadog (https://www.datadoghq.com/). Copyright 2020 Datadog, Inc.
+
+package accounts_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	jamf "github.com/trustero/jamf-api-client-go/classic/accounts"
+	"github.com/trustero/jamf-api-client-go/classic/client"
+)
+
+func auditFixtures() ([]*jamf.JamfUser, []*jamf.JamfGroup) {
+	users := []*jamf.JamfUser{
+		{Id: 1, Name: "root", Enabled: "Enabled", ForcePasswordChange: client.Bool(true), AccessLevel: "Full Access", PrivilegeSet: "Administrator"},
+		{Id: 2, Name: "helpdesk", Enabled: "Enabled", ForcePasswordChange: client.Bool(true), AccessLevel: "Full Access", PrivilegeSet: "Custom",
+			Privileges: jamf.Privileges{JssObjects: []jamf.Privilege{"Read Computers"}}},
+		{Id: 3, Name: "former", Enabled: "Disabled", ForcePasswordChange: client.Bool(true), AccessLevel: "Full Access", PrivilegeSet: "Custom"},
+		{Id: 4, Name: "jdoe", Enabled: "Enabled", DirectoryUser: client.Bool(true), AccessLevel: "Full Access", PrivilegeSet: "Custom"},
+	}
+	groups := []*jamf.JamfGroup{
+		{Id: 10, Name: "Desktop", PrivilegeSet: "Custom", Members: []jamf.JamfUserId{{Id: 2}, {Id: 3}},
+			Privileges: jamf.Privileges{
+				JssObjects: []jamf.Privilege{"Update Computers", "Read Computers"},
+				JssActions: []jamf.Privilege{"Send Computer Remote Lock Command"},
+			}},
+		{Id: 11, Name: "Admins", PrivilegeSet: "Administrator", Members: []jamf.JamfUserId{{Id: 4}}},
+	}
+	return users, groups
+}
+
+func TestBuildAuditReport(t *testing.T) {
+	report := jamf.BuildAuditReport(auditFixtures())
+	assert.Equal(t, 4, len(report.Accounts))
+
+	byName := map[string]*jamf.AccountAudit{}
+	for _, a := range report.Accounts {
+		byName[a.Name] = a
+	}
+
+	root := byName["root"]
+	assert.True(t, root.Administrator)
+	assert.Equal(t, []string{jamf.FindingAdministrator}, root.Findings)
+	assert.Contains(t, root.Privileges.JssObjects, jamf.Privilege("Delete Computers"))
+	assert.Equal(t, []jamf.Privilege{"Use Casper Imaging"}, root.Privileges.CasperImaging)
+
+	helpdesk := byName["helpdesk"]
+	assert.False(t, helpdesk.Administrator)
+	assert.Empty(t, helpdesk.Findings)
+	assert.Equal(t, []string{"Desktop"}, helpdesk.Groups)
+	assert.Equal(t, []jamf.Privilege{"Read Computers", "Update Computers"}, helpdesk.Privileges.JssObjects)
+	assert.Equal(t, []jamf.Privilege{"Send Computer Remote Lock Command"}, helpdesk.Privileges.JssActions)
+	assert.Equal(t, "helpdesk: ok", helpdesk.String())
+
+	// privileges inherited from a group still count when the user is disabled
+	former := byName["former"]
+	assert.Equal(t, []string{jamf.FindingDisabledPrivileged}, former.Findings)
+
+	// directory users are never asked to change their password but administrator groups apply
+	jdoe := byName["jdoe"]
+	assert.True(t, jdoe.HasFinding(jamf.FindingAdministrator))
+	assert.False(t, jdoe.HasFinding(jamf.FindingNoForcePasswordChange))
+
+	assert.Equal(t, 3, len(report.Flagged()))
+}
+
+func TestBuildAuditReportPrivilegeSets(t *testing.T) {
+	report := jamf.BuildAuditReport([]*jamf.JamfUser{
+		{Id: 1, Name: "auditor", PrivilegeSet: "Auditor", Privileges: jamf.Privileges{JssObjects: []jamf.Privilege{"Update Computers"}}},
+		{Id: 2, Name: "enroller", PrivilegeSet: "Enrollment Only"},
+	}, nil)
+
+	auditor := report.Accounts[0]
+	assert.Contains(t, auditor.Privileges.JssObjects, jamf.Privilege("Read Computers"))
+	assert.NotContains(t, auditor.Privileges.JssObjects, jamf.Privilege("Update Computers"))
+	assert.Empty(t, auditor.Privileges.JssActions)
+
+	enroller := report.Accounts[1]
+	assert.Equal(t, []jamf.Privilege{"Allow User to Enroll", "Enroll Computers and Mobile Devices"}, enroller.Privileges.JssActions)
+	assert.Empty(t, enroller.Privileges.JssObjects)
+}
+
+func TestBuildAuditReportAdministratorCase(t *testing.T) {
+	report := jamf.BuildAuditReport(
+		[]*jamf.JamfUser{
+			{Id: 1, Name: "admin", PrivilegeSet: "administrator"},
+			{Id: 2, Name: "member", PrivilegeSet: "Custom"},
+		},
+		[]*jamf.JamfGroup{{Id: 3, Name: "Admins", PrivilegeSet: "ADMINISTRATOR", Members: []jamf.JamfUserId{{Id: 2}}}},
+	)
+	assert.True(t, report.Accounts[0].Administrator)
+	assert.True(t, report.Accounts[1].Administrator)
+	assert.True(t, jamf.PrivilegeSet("administrator").Is(jamf.Administrator))
+	assert.False(t, jamf.Auditor.Is(jamf.Administrator))
+}
+
+func TestBuildAuditReportPasswordChange(t *testing.T) {
+	report := jamf.BuildAuditReport([]*jamf.JamfUser{{Id: 1, Name: "local", Enabled: "Enabled"}}, nil)
+	assert.Equal(t, "local: no_force_password_change", report.Accounts[0].String())
+}
+
+func TestAuditReportExport(t *testing.T) {
+	report := jamf.BuildAuditReport(auditFixtures())
+
+	buf := &bytes.Buffer{}
+	assert.Nil(t, report.WriteCSV(buf))
+	rows, err := csv.NewReader(buf).ReadAll()
+	assert.Nil(t, err)
+	assert.Equal(t, 5, len(rows))
+	assert.Equal(t, "id", rows[0][0])
+	assert.Equal(t, "findings", rows[0][len(rows[0])-1])
+	// accounts are sorted by name
+	assert.Equal(t, []string{"former", "helpdesk", "jdoe", "root"}, []string{rows[1][1], rows[2][1], rows[3][1], rows[4][1]})
+	assert.Equal(t, "Read Computers; Update Computers", rows[2][10])
+
+	buf.Reset()
+	assert.Nil(t, report.WriteJSON(buf))
+	decoded := &jamf.AuditReport{}
+	assert.Nil(t, json.Unmarshal(buf.Bytes(), decoded))
+	assert.Equal(t, report.Accounts, decoded.Accounts)
+}
+
+func TestAudit(t *testing.T) {
+	testServer := accountsResponseMocks(t)
+	defer testServer.Close()
+	j, err := jamf.NewService(testServer.URL, "fake-username", "mock-password-cool", nil)
+	assert.Nil(t, err)
+
+	report, err := j.Audit(context.Background())
+	assert.Nil(t, err)
+	assert.False(t, report.GeneratedAt.IsZero())
+	assert.Equal(t, 2, len(report.Accounts))
+
+	asmith, jdoe := report.Accounts[0], report.Accounts[1]
+	assert.Equal(t, "asmith: no_force_password_change", asmith.String())
+	assert.Equal(t, []string{"IT Admins"}, jdoe.Groups)
+	assert.Equal(t, []string{jamf.FindingAdministrator}, jdoe.Findings)
+	// the Administrator privilege set of the group grants every privilege
+	assert.Contains(t, jdoe.Privileges.JssObjects, jamf.Privilege("Update Computers"))
+	assert.Contains(t, jdoe.Privileges.JssObjects, jamf.Privilege("Delete Computers"))
+}
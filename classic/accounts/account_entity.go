@@ -3,6 +3,8 @@
 
 package accounts
 
+import "encoding/xml"
+
 // Account represents an account set up in Jamf
 type JamfAccountsResp struct {
 	AccountsId JamfAccountsId `json:"accounts,omitempty"`
@@ -13,12 +15,12 @@ type JamfAccountsId struct {
 }
 
 type JamfUserId struct {
-	Id   int    `json:"id,omitempty"`
-	Name string `json:"name,omitempty"`
+	Id   int    `json:"id,omitempty" xml:"id,omitempty"`
+	Name string `json:"name,omitempty" xml:"name,omitempty"`
 }
 type JamfGroupId struct {
-	Id   int    `json:"id,omitempty"`
-	Name string `json:"name,omitempty"`
+	Id   int    `json:"id,omitempty" xml:"id,omitempty"`
+	Name string `json:"name,omitempty" xml:"name,omitempty"`
 }
 
 type JamfUserResp struct {
@@ -26,24 +28,23 @@ type JamfUserResp struct {
 }
 
 type JamfUser struct {
-	Id                  int    `json:"id,omitempty"`
-	Name                string `json:"name,omitempty"`
-	DirectoryUser       bool   `json:"directory_user,omitempty"`
-	FullName            string `json:"full_name,omitempty"`
-	Email               string `json:"email,omitempty"`
-	EmailAddress        string `json:"email_address,omitempty"`
-	PasswordSha256      string `json:"password_sha256,omitempty"`
-	Enabled             string `json:"enabled,omitempty"`
-	ForcePasswordChange bool   `json:"force_password_change,omitempty"`
-	AccessLevel         string `json:"access_level,omitempty"`
-	PrivilegeSet        string `json:"privilege_set,omitempty"`
-	Privileges          struct {
-		JssObjects  []string `json:"jss_objects,omitempty"`
-		JssSettings []string `json:"jss_settings,omitempty"`
-		JssActions  []string `json:"jss_actions,omitempty"`
-		Recon       []string `json:"recon,omitempty"`
-		CasperAdmin []string `json:"casper_admin,omitempty"`
-	} `json:"privileges,omitempty"`
+	XMLName       xml.Name    `json:"-" xml:"account,omitempty"`
+	Id            int         `json:"id,omitempty" xml:"id,omitempty"`
+	Name          string      `json:"name,omitempty" xml:"name,omitempty"`
+	DirectoryUser *bool       `json:"directory_user,omitempty" xml:"directory_user,omitempty"`
+	FullName      string      `json:"full_name,omitempty" xml:"full_name,omitempty"`
+	Email         string      `json:"email,omitempty" xml:"email,omitempty"`
+	EmailAddress  string      `json:"email_address,omitempty" xml:"email_address,omitempty"`
+	LdapServer    *LdapServer `json:"ldap_server,omitempty" xml:"ldap_server,omitempty"`
+	// Password is only sent to Jamf when creating or updating a user, it is never returned
+	Password            string        `json:"-" xml:"password,omitempty"`
+	PasswordSha256      string        `json:"password_sha256,omitempty" xml:"password_sha256,omitempty"`
+	Enabled             AccountStatus `json:"enabled,omitempty" xml:"enabled,omitempty"`
+	ForcePasswordChange *bool         `json:"force_password_change,omitempty" xml:"force_password_change,omitempty"`
+	AccessLevel         AccessLevel   `json:"access_level,omitempty" xml:"access_level,omitempty"`
+	PrivilegeSet        PrivilegeSet  `json:"privilege_set,omitempty" xml:"privilege_set,omitempty"`
+	Site                *Site         `json:"site,omitempty" xml:"site,omitempty"`
+	Privileges          Privileges    `json:"privileges,omitempty" xml:"privileges"`
 }
 
 type JamfGroupResp struct {
@@ -51,18 +52,67 @@ type JamfGroupResp struct {
 }
 
 type JamfGroup struct {
-	Id           int    `json:"id,omitempty"`
-	Name         string `json:"name,omitempty"`
-	AccessLevel  string `json:"access_level,omitempty"`
-	PrivilegeSet string `json:"privilege_set,omitempty"`
-	Privileges   struct {
-		JssObjects    []string `json:"jss_objects,omitempty"`
-		JssSettings   []string `json:"jss_settings,omitempty"`
-		JssActions    []string `json:"jss_actions,omitempty"`
-		Recon         []string `json:"recon,omitempty"`
-		CasperAdmin   []string `json:"casper_admin,omitempty"`
-		CasperRemote  []string `json:"casper_remote,omitempty"`
-		CasperImaging []string `json:"casper_imaging,omitempty"`
-	} `json:"privileges,omitempty"`
-	Members []JamfUserId `json:"members,omitempty"`
+	XMLName      xml.Name     `json:"-" xml:"group,omitempty"`
+	Id           int          `json:"id,omitempty" xml:"id,omitempty"`
+	Name         string       `json:"name,omitempty" xml:"name,omitempty"`
+	AccessLevel  AccessLevel  `json:"access_level,omitempty" xml:"access_level,omitempty"`
+	PrivilegeSet PrivilegeSet `json:"privilege_set,omitempty" xml:"privilege_set,omitempty"`
+	LdapServer   *LdapServer  `json:"ldap_server,omitempty" xml:"ldap_server,omitempty"`
+	Site         *Site        `json:"site,omitempty" xml:"site,omitempty"`
+	Privileges   Privileges   `json:"privileges,omitempty" xml:"privileges"`
+	Members      []JamfUserId `json:"members,omitempty" xml:"members>user,omitempty"`
+}
+
+// Privileges holds the privileges granted to a user or group when its privilege set is Custom.
+// Jamf lists each privilege by name, ex. Read Computers
+type Privileges struct {
+	JssObjects    []Privilege `json:"jss_objects,omitempty" xml:"jss_objects>privilege"`
+	JssSettings   []Privilege `json:"jss_settings,omitempty" xml:"jss_settings>privilege"`
+	JssActions    []Privilege `json:"jss_actions,omitempty" xml:"jss_actions>privilege"`
+	Recon         []Privilege `json:"recon,omitempty" xml:"recon>privilege"`
+	CasperAdmin   []Privilege `json:"casper_admin,omitempty" xml:"casper_admin>privilege"`
+	CasperRemote  []Privilege `json:"casper_remote,omitempty" xml:"casper_remote>privilege"`
+	CasperImaging []Privilege `json:"casper_imaging,omitempty" xml:"casper_imaging>privilege"`
+}
+
+// MarshalXML writes only the privilege categories which hold privileges and omits the
+// privileges element entirely when there are none
+func (p Privileges) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	var tokens []xml.Token
+	for _, c := range PrivilegeCategories {
+		privileges := p.Category(c)
+		if len(privileges) == 0 {
+			continue
+		}
+		category := xml.StartElement{Name: xml.Name{Local: string(c)}}
+		tokens = append(tokens, category)
+		for _, privilege := range privileges {
+			element := xml.StartElement{Name: xml.Name{Local: "privilege"}}
+			tokens = append(tokens, element, xml.CharData(privilege), element.End())
+		}
+		tokens = append(tokens, category.End())
+	}
+	if len(tokens) == 0 {
+		return nil
+	}
+
+	tokens = append([]xml.Token{start}, append(tokens, start.End())...)
+	for _, t := range tokens {
+		if err := e.EncodeToken(t); err != nil {
+			return err
+		}
+	}
+	return e.Flush()
+}
+
+// LdapServer is the directory service a directory user or group is looked up in
+type LdapServer struct {
+	ID   int    `json:"id,omitempty" xml:"id,omitempty"`
+	Name string `json:"name,omitempty" xml:"name,omitempty"`
+}
+
+// Site limits the access of a user or group with Site Access
+type Site struct {
+	ID   int    `json:"id" xml:"id,omitempty"`
+	Name string `json:"name,omitempty" xml:"name,omitempty"`
 }
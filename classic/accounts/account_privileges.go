@@ -0,0 +1,326 @@
+// Unless explicitly stated otherwise all files in this repository are licensed under the Apache-2.0
+// This product includes software developed at Datadog (https://www.datadoghq.com/). Copyright 2020 Datadog, Inc.
+
+package accounts
+
+import (
+	"sort"
+	"strings"
+	"sync"
+)
+
+// AccessLevel limits which parts of Jamf a user or group can reach
+type AccessLevel string
+
+// Access levels supported by Jamf
+const (
+	FullAccess  AccessLevel = "Full Access"
+	SiteAccess  AccessLevel = "Site Access"
+	GroupAccess AccessLevel = "Group Access"
+)
+
+// PrivilegeSet is the set of privileges granted to a user or group. Only Custom uses the
+// privileges listed on the account
+type PrivilegeSet string
+
+// Privilege sets supported by Jamf
+const (
+	Administrator  PrivilegeSet = "Administrator"
+	Auditor        PrivilegeSet = "Auditor"
+	EnrollmentOnly PrivilegeSet = "Enrollment Only"
+	Custom         PrivilegeSet = "Custom"
+)
+
+// AccountStatus is whether a user is able to log in
+type AccountStatus string
+
+// Account statuses reported by Jamf
+const (
+	AccountEnabled  AccountStatus = "Enabled"
+	AccountDisabled AccountStatus = "Disabled"
+)
+
+// IsEnabled returns whether the status allows the user to log in. Jamf treats a missing status as enabled
+func (s AccountStatus) IsEnabled() bool {
+	return !strings.EqualFold(string(s), string(AccountDisabled))
+}
+
+// Privilege is a single Jamf privilege such as Read Computers
+type Privilege string
+
+// PrivilegeCategory groups privileges the way Jamf lists them on an account
+type PrivilegeCategory string
+
+// Privilege categories of an account
+const (
+	JssObjects    PrivilegeCategory = "jss_objects"
+	JssSettings   PrivilegeCategory = "jss_settings"
+	JssActions    PrivilegeCategory = "jss_actions"
+	Recon         PrivilegeCategory = "recon"
+	CasperAdmin   PrivilegeCategory = "casper_admin"
+	CasperRemote  PrivilegeCategory = "casper_remote"
+	CasperImaging PrivilegeCategory = "casper_imaging"
+)
+
+// PrivilegeCategories lists every category in the order Jamf returns them
+var PrivilegeCategories = []PrivilegeCategory{JssObjects, JssSettings, JssActions, Recon, CasperAdmin, CasperRemote, CasperImaging}
+
+// jssObjects are the Jamf objects which each have a Create, Read, Update and Delete privilege
+var jssObjects = []string{
+	"Advanced Computer Searches", "Advanced Mobile Device Searches", "Advanced User Content Searches",
+	"Advanced User Searches", "AirPlay Permissions", "Allowed File Extension", "Attachment Assignments",
+	"Buildings", "Categories", "Classes", "Computer Enrollment Invitations", "Computer Extension Attributes",
+	"Computer PreStage Enrollments", "Computers", "Departments", "Device Enrollment Program Instances",
+	"Directory Bindings", "Disk Encryption Configurations", "Dock Items", "eBooks", "Enrollment Customizations",
+	"Enrollment Profiles", "File Attachments", "iBeacon", "Inventory Preload Records", "Keystores",
+	"LDAP Servers", "Licensed Software", "Mac Applications", "macOS Configuration Profiles", "Maintenance Pages",
+	"Mobile Device Applications", "Mobile Device Configuration Profiles", "Mobile Device Enrollment Invitations",
+	"Mobile Device Extension Attributes", "Mobile Device PreStage Enrollments", "Mobile Devices",
+	"Network Integration", "Network Segments", "Packages", "Patch Management Software Titles", "Patch Policies",
+	"Peripheral Types", "Personal Device Configurations", "Personal Device Profiles", "Policies", "Printers",
+	"Provisioning Profiles", "Push Certificates", "Remote Administration", "Removable MAC Address",
+	"Restricted Software", "Scripts", "Self Service Bookmarks", "Self Service Branding Configuration", "Sites",
+	"Smart Computer Groups", "Smart Mobile Device Groups", "Smart User Groups", "Software Update Servers",
+	"Static Computer Groups", "Static Mobile Device Groups", "Static User Groups", "User Extension Attributes",
+	"Users", "VPP Assignment", "VPP Invitations", "Volume Purchasing Administrator Accounts", "Webhooks",
+}
+
+// jssCRUDSettings are the Jamf settings which each have a Create, Read, Update and Delete privilege
+var jssCRUDSettings = []string{
+	"Accounts", "API Integrations", "API Roles", "Jamf Pro Server Actions",
+}
+
+// jssSettings are the Jamf settings which each have a Read and Update privilege
+var jssSettings = []string{
+	"Activation Code", "Apple Configurator Enrollment", "Automatic Mac App Updates", "Automatically Renew MDM Profile Settings",
+	"Cache", "Change Management", "Check-In", "Classic API", "Clustering", "Computer Check-In Setting",
+	"Computer Inventory Collection", "Computer Inventory Collection Settings", "Conditional Access",
+	"Customer Experience Metrics", "Device Compliance Information", "Education Settings", "Enrollment Customization",
+	"GSX Connection", "Health Check", "Inventory Display", "Jamf Connect Settings", "Jamf Protect Settings",
+	"Jamf Pro Server Settings", "Limited Access Settings", "Login Disclaimer", "Mobile Device App Maintenance Settings",
+	"Mobile Device Inventory Collection", "Password Policy", "Patch Management Settings", "PKI", "Re-enrollment",
+	"Retention Policy", "Security", "Self Service", "SMTP Server", "SSO Settings", "User-Initiated Enrollment",
+}
+
+// jssActions are the actions a user can take on managed devices
+var jssActions = []string{
+	"Allow User to Enroll", "Assign Users to Computers", "Assign Users to Mobile Devices", "Change Password",
+	"Dismiss Notifications", "Enroll Computers and Mobile Devices", "Flush MDM Commands", "Flush Policy Logs",
+	"Jamf Packages Action", "Remove Jamf Pro Management Framework", "Remove restrictions set by Jamf Parent",
+	"Send Application Attributes Command", "Send Blank Pushes to Mobile Devices", "Send Command to Renew MDM Profile",
+	"Send Computer Bluetooth Command", "Send Computer Delete User Account Command",
+	"Send Computer Remote Command to Download and Install OS X Update", "Send Computer Remote Command to Install Package",
+	"Send Computer Remote Desktop Command", "Send Computer Remote Lock Command", "Send Computer Remote Wipe Command",
+	"Send Computer Set Activation Lock Command", "Send Computer Unlock User Account Command", "Send Computer Unmanage Command",
+	"Send Disable Bootstrap Token Command", "Send Email to End Users via JSS", "Send Enable Bootstrap Token Command",
+	"Send Inventory Requests to Mobile Devices", "Send Messages to Self Service Mobile", "Send Mobile Device Bluetooth Command",
+	"Send Mobile Device Diagnostics and Usage Reporting and App Analytics Commands",
+	"Send Mobile Device Disable Data Roaming Command", "Send Mobile Device Disable Voice Roaming Command",
+	"Send Mobile Device Enable Data Roaming Command", "Send Mobile Device Enable Voice Roaming Command",
+	"Send Mobile Device Lost Mode Command", "Send Mobile Device Managed Settings Command", "Send Mobile Device Mirroring Command",
+	"Send Mobile Device Personal Hotspot Command", "Send Mobile Device Remote Command to Download and Install iOS Update",
+	"Send Mobile Device Remote Lock Command", "Send Mobile Device Remote Wipe Command", "Send Mobile Device Remove Passcode Command",
+	"Send Mobile Device Restart Device Command",
+	"Send Mobile Device Set Activation Lock Command", "Send Mobile Device Set Device Name Command",
+	"Send Mobile Device Set Wallpaper Command", "Send Mobile Device Shared Device Configuration Commands",
+	"Send Mobile Device Shut Down Command", "Send Mobile Device Software Update Recommendation Command",
+	"Send Set Timezone Command", "Send Update Passcode Lock Grace Period Command", "Start Remote Desktop Session",
+	"Unmanage Mobile Devices", "Update Local Admin Password Settings", "View Activation Lock Bypass Code",
+	"View Disk Encryption Recovery Key", "View Event Logs", "View Jamf Pro Audit Log", "View Local Admin Password",
+	"View Local Admin Password Audit History", "View MDM command information in Jamf Pro API",
+	"View Mobile Device Lost Mode Location", "View Recovery Lock",
+}
+
+// enrollmentOnlyPrivileges are granted by the Enrollment Only privilege set
+var enrollmentOnlyPrivileges = []Privilege{"Enroll Computers and Mobile Devices", "Allow User to Enroll"}
+
+// registry holds every privilege Jamf offers keyed by category, see RegisterPrivilege
+var (
+	registryMu sync.RWMutex
+	registry   = buildRegistry()
+)
+
+func buildRegistry() map[PrivilegeCategory]map[Privilege]bool {
+	r := map[PrivilegeCategory]map[Privilege]bool{}
+	for _, c := range PrivilegeCategories {
+		r[c] = map[Privilege]bool{}
+	}
+
+	for _, object := range jssObjects {
+		for _, verb := range []string{"Create", "Read", "Update", "Delete"} {
+			r[JssObjects][Privilege(verb+" "+object)] = true
+		}
+	}
+	for _, setting := range jssCRUDSettings {
+		for _, verb := range []string{"Create", "Read", "Update", "Delete"} {
+			r[JssSettings][Privilege(verb+" "+setting)] = true
+		}
+	}
+	for _, setting := range jssSettings {
+		for _, verb := range []string{"Read", "Update"} {
+			r[JssSettings][Privilege(verb+" "+setting)] = true
+		}
+	}
+	for _, action := range jssActions {
+		r[JssActions][Privilege(action)] = true
+	}
+	for _, p := range []Privilege{"Add Computers Remotely", "Create QuickAdd Packages"} {
+		r[Recon][p] = true
+	}
+	for _, p := range []Privilege{"Use Casper Admin", "Save With Casper Admin"} {
+		r[CasperAdmin][p] = true
+	}
+	for _, p := range []Privilege{"Use Casper Remote", "Install/Uninstall Packages", "Run Scripts", "Screen Share with Remote Computers", "Screen Share Remote Computers Silently"} {
+		r[CasperRemote][p] = true
+	}
+	r[CasperImaging]["Use Casper Imaging"] = true
+	return r
+}
+
+// RegisterPrivilege adds a privilege to the registry used by validation. It allows privileges
+// introduced by newer Jamf versions to be used before they are known to this package
+func RegisterPrivilege(category PrivilegeCategory, privilege Privilege) {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	if registry[category] == nil {
+		registry[category] = map[Privilege]bool{}
+	}
+	registry[category][privilege] = true
+}
+
+// IsKnownPrivilege returns whether a privilege is registered under a category
+func IsKnownPrivilege(category PrivilegeCategory, privilege Privilege) bool {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+	return registry[category][privilege]
+}
+
+// KnownPrivileges returns every registered privilege of a category sorted by name
+func KnownPrivileges(category PrivilegeCategory) []Privilege {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+	privileges := make([]Privilege, 0, len(registry[category]))
+	for p := range registry[category] {
+		privileges = append(privileges, p)
+	}
+	sort.Slice(privileges, func(a, b int) bool { return privileges[a] < privileges[b] })
+	return privileges
+}
+
+// Category returns the privileges of a category
+func (p Privileges) Category(category PrivilegeCategory) []Privilege {
+	switch category {
+	case JssObjects:
+		return p.JssObjects
+	case JssSettings:
+		return p.JssSettings
+	case JssActions:
+		return p.JssActions
+	case Recon:
+		return p.Recon
+	case CasperAdmin:
+		return p.CasperAdmin
+	case CasperRemote:
+		return p.CasperRemote
+	case CasperImaging:
+		return p.CasperImaging
+	default:
+		return nil
+	}
+}
+
+// Add grants a privilege under a category unless it is already granted
+func (p *Privileges) Add(category PrivilegeCategory, privilege Privilege) {
+	var list *[]Privilege
+	switch category {
+	case JssObjects:
+		list = &p.JssObjects
+	case JssSettings:
+		list = &p.JssSettings
+	case JssActions:
+		list = &p.JssActions
+	case Recon:
+		list = &p.Recon
+	case CasperAdmin:
+		list = &p.CasperAdmin
+	case CasperRemote:
+		list = &p.CasperRemote
+	case CasperImaging:
+		list = &p.CasperImaging
+	default:
+		return
+	}
+
+	for _, existing := range *list {
+		if existing == privilege {
+			return
+		}
+	}
+	*list = append(*list, privilege)
+}
+
+// HasPrivilege returns whether a privilege is listed in any category
+func (p Privileges) HasPrivilege(privilege Privilege) bool {
+	for _, c := range PrivilegeCategories {
+		for _, granted := range p.Category(c) {
+			if granted == privilege {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// Is returns whether the privilege set is other, Jamf privilege sets are compared ignoring case
+func (s PrivilegeSet) Is(other PrivilegeSet) bool {
+	return strings.EqualFold(string(s), string(other))
+}
+
+// Grants returns whether a privilege set grants a privilege. Administrator grants every
+// privilege, Auditor every Read privilege and Enrollment Only the enrollment privileges.
+// Custom only grants the privileges listed with it
+func (s PrivilegeSet) Grants(privilege Privilege, listed Privileges) bool {
+	switch PrivilegeSet(strings.ToLower(string(s))) {
+	case "administrator":
+		return true
+	case "auditor":
+		return strings.HasPrefix(string(privilege), "Read ")
+	case "enrollment only":
+		for _, p := range enrollmentOnlyPrivileges {
+			if p == privilege {
+				return true
+			}
+		}
+		return false
+	default:
+		return listed.HasPrivilege(privilege)
+	}
+}
+
+// Expand returns every privilege granted by the privilege set, listed holds the privileges
+// of the account which Custom grants. Administrator and Auditor are expanded to the known
+// privileges they grant along with any other listed privilege they grant
+func (s PrivilegeSet) Expand(listed Privileges) Privileges {
+	expanded := Privileges{}
+	for _, c := range PrivilegeCategories {
+		for _, list := range [][]Privilege{KnownPrivileges(c), listed.Category(c)} {
+			for _, p := range list {
+				if s.Grants(p, listed) {
+					expanded.Add(c, p)
+				}
+			}
+		}
+	}
+	return expanded
+}
+
+// HasPrivilege returns whether the user is granted a privilege by its own privilege set. Groups
+// the user is a member of are not taken into account, see BuildAuditReport
+func (u *JamfUser) HasPrivilege(privilege Privilege) bool {
+	return u.PrivilegeSet.Grants(privilege, u.Privileges)
+}
+
+// HasPrivilege returns whether the group is granted a privilege by its privilege set
+func (g *JamfGroup) HasPrivilege(privilege Privilege) bool {
+	return g.PrivilegeSet.Grants(privilege, g.Privileges)
+}
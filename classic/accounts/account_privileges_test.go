@@ -0,0 +1,54 @@
+// Unless explicitly stated otherwise all files in this repository are licensed under the Apache-2.0
+// This product includes software developed at Datadog (https://www.datadoghq.com/). Copyright 2020 Datadog, Inc.
+
+package accounts_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	jamf "github.com/trustero/jamf-api-client-go/classic/accounts"
+)
+
+func TestKnownPrivileges(t *testing.T) {
+	assert.True(t, jamf.IsKnownPrivilege(jamf.JssObjects, "Read Computers"))
+	assert.True(t, jamf.IsKnownPrivilege(jamf.JssObjects, "Delete macOS Configuration Profiles"))
+	assert.True(t, jamf.IsKnownPrivilege(jamf.JssSettings, "Update SMTP Server"))
+	assert.True(t, jamf.IsKnownPrivilege(jamf.JssActions, "Flush MDM Commands"))
+	assert.False(t, jamf.IsKnownPrivilege(jamf.JssSettings, "Read Computers"))
+	assert.False(t, jamf.IsKnownPrivilege(jamf.JssObjects, "Read Time Machines"))
+	assert.Contains(t, jamf.KnownPrivileges(jamf.JssObjects), jamf.Privilege("Read Computers"))
+
+	jamf.RegisterPrivilege(jamf.JssObjects, "Read Time Machines")
+	assert.True(t, jamf.IsKnownPrivilege(jamf.JssObjects, "Read Time Machines"))
+	assert.Contains(t, jamf.KnownPrivileges(jamf.JssObjects), jamf.Privilege("Read Time Machines"))
+	assert.Equal(t, []jamf.Privilege{"Use Casper Imaging"}, jamf.KnownPrivileges(jamf.CasperImaging))
+}
+
+func TestHasPrivilege(t *testing.T) {
+	custom := &jamf.JamfUser{PrivilegeSet: jamf.Custom}
+	custom.Privileges.Add(jamf.JssObjects, "Read Computers")
+	custom.Privileges.Add(jamf.JssObjects, "Read Computers")
+	custom.Privileges.Add(jamf.JssActions, "Flush MDM Commands")
+	assert.Equal(t, []jamf.Privilege{"Read Computers"}, custom.Privileges.JssObjects)
+	assert.True(t, custom.HasPrivilege("Read Computers"))
+	assert.True(t, custom.HasPrivilege("Flush MDM Commands"))
+	assert.False(t, custom.HasPrivilege("Update Computers"))
+
+	admin := &jamf.JamfUser{PrivilegeSet: jamf.Administrator}
+	assert.True(t, admin.HasPrivilege("Delete Computers"))
+
+	auditor := &jamf.JamfGroup{PrivilegeSet: jamf.Auditor}
+	assert.True(t, auditor.HasPrivilege("Read Policies"))
+	assert.False(t, auditor.HasPrivilege("Update Policies"))
+
+	enrollment := &jamf.JamfUser{PrivilegeSet: jamf.EnrollmentOnly}
+	assert.True(t, enrollment.HasPrivilege("Enroll Computers and Mobile Devices"))
+	assert.False(t, enrollment.HasPrivilege("Read Computers"))
+}
+
+func TestAccountStatus(t *testing.T) {
+	assert.True(t, jamf.AccountEnabled.IsEnabled())
+	assert.True(t, jamf.AccountStatus("").IsEnabled())
+	assert.False(t, jamf.AccountDisabled.IsEnabled())
+}
@@ -0,0 +1,249 @@
+// Unless explicitly stated otherwise all files in this repository are licensed under the Apache-2.0
+// This product includes software developed at Datadog (https://www.datadoghq.com/). Copyright 2020 Datadog, Inc.
+
+package accounts_test
+
+import (
+	"context"
+	"encoding/xml"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	jamf "github.com/trustero/jamf-api-client-go/classic/accounts"
+	"github.com/trustero/jamf-api-client-go/classic/client"
+)
+
+var ACCOUNTS_API_BASE_ENDPOINT = "/JSSResource/accounts"
+
+const accountUserResponse = `{
+	"account": {
+		"id": 3,
+		"name": "jdoe",
+		"directory_user": false,
+		"full_name": "Jane Doe",
+		"email": "jdoe@example.com",
+		"email_address": "jdoe@example.com",
+		"enabled": "Enabled",
+		"force_password_change": true,
+		"access_level": "Full Access",
+		"privilege_set": "Custom",
+		"site": {"id": -1, "name": "None"},
+		"privileges": {
+			"jss_objects": ["Read Computers", "Update Computers"],
+			"jss_settings": ["Read SMTP Server"],
+			"jss_actions": ["Send Computer Remote Lock Command"],
+			"recon": [],
+			"casper_admin": ["Use Casper Admin"]
+		}
+	}
+}`
+
+const accountGroupResponse = `{
+	"group": {
+		"id": 7,
+		"name": "IT Admins",
+		"access_level": "Full Access",
+		"privilege_set": "Administrator",
+		"site": {"id": -1, "name": "None"},
+		"privileges": {
+			"jss_objects": ["Read Computers"]
+		},
+		"members": [{"id": 3, "name": "jdoe"}]
+	}
+}`
+
+func accountsResponseMocks(t *testing.T) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "PUT" || r.Method == "POST" {
+			data, err := ioutil.ReadAll(r.Body)
+			assert.Nil(t, err)
+			w.Header().Set("Content-Type", "application/xml")
+			switch r.RequestURI {
+			case fmt.Sprintf("%s/userid/0", ACCOUNTS_API_BASE_ENDPOINT), fmt.Sprintf("%s/userid/3", ACCOUNTS_API_BASE_ENDPOINT), fmt.Sprintf("%s/username/jdoe", ACCOUNTS_API_BASE_ENDPOINT):
+				user := &jamf.JamfUser{}
+				assert.Nil(t, xml.Unmarshal(data, user))
+				fmt.Fprintf(w, `<account><id>3</id><name>%s</name></account>`, user.Name)
+			case fmt.Sprintf("%s/groupid/0", ACCOUNTS_API_BASE_ENDPOINT), fmt.Sprintf("%s/groupname/IT%%20Admins", ACCOUNTS_API_BASE_ENDPOINT):
+				group := &jamf.JamfGroup{}
+				assert.Nil(t, xml.Unmarshal(data, group))
+				fmt.Fprintf(w, `<group><id>7</id><name>%s</name></group>`, group.Name)
+			default:
+				w.WriteHeader(http.StatusNotFound)
+			}
+			return
+		}
+
+		switch r.RequestURI {
+		case ACCOUNTS_API_BASE_ENDPOINT:
+			fmt.Fprint(w, `{
+				"accounts": {
+					"users": [{"id": 3, "name": "jdoe"}, {"id": 4, "name": "asmith"}],
+					"groups": [{"id": 7, "name": "IT Admins"}]
+				}
+			}`)
+		case fmt.Sprintf("%s/userid/3", ACCOUNTS_API_BASE_ENDPOINT), fmt.Sprintf("%s/username/jdoe", ACCOUNTS_API_BASE_ENDPOINT):
+			if r.Method == "DELETE" {
+				w.Header().Set("Content-Type", "application/xml")
+				fmt.Fprint(w, `<account><id>3</id></account>`)
+				return
+			}
+			fmt.Fprint(w, accountUserResponse)
+		case fmt.Sprintf("%s/userid/4", ACCOUNTS_API_BASE_ENDPOINT):
+			fmt.Fprint(w, `{"account": {"id": 4, "name": "asmith", "enabled": "Disabled", "access_level": "Full Access", "privilege_set": "Custom"}}`)
+		case fmt.Sprintf("%s/groupid/7", ACCOUNTS_API_BASE_ENDPOINT), fmt.Sprintf("%s/groupname/IT%%20Admins", ACCOUNTS_API_BASE_ENDPOINT):
+			if r.Method == "DELETE" {
+				w.Header().Set("Content-Type", "application/xml")
+				fmt.Fprint(w, `<group><id>7</id></group>`)
+				return
+			}
+			fmt.Fprint(w, accountGroupResponse)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+}
+
+func TestQueryAccounts(t *testing.T) {
+	testServer := accountsResponseMocks(t)
+	defer testServer.Close()
+	j, err := jamf.NewService(testServer.URL, "fake-username", "mock-password-cool", nil)
+	assert.Nil(t, err)
+
+	accounts, _, err := j.List()
+	assert.Nil(t, err)
+	assert.Equal(t, 2, len(accounts.UsersIds))
+	assert.Equal(t, "IT Admins", accounts.GroupsIds[0].Name)
+
+	it := j.IterateUsers()
+	var names []string
+	for it.Next(context.Background()) {
+		names = append(names, it.Value().Name)
+	}
+	assert.Nil(t, it.Err())
+	assert.Equal(t, []string{"jdoe", "asmith"}, names)
+}
+
+func TestQueryAccountUser(t *testing.T) {
+	testServer := accountsResponseMocks(t)
+	defer testServer.Close()
+	j, err := jamf.NewService(testServer.URL, "fake-username", "mock-password-cool", nil)
+	assert.Nil(t, err)
+
+	user, _, err := j.GetByUserId(3)
+	assert.Nil(t, err)
+	assert.Equal(t, "jdoe", user.Name)
+	assert.Equal(t, jamf.Custom, user.PrivilegeSet)
+	assert.Equal(t, []jamf.Privilege{"Read Computers", "Update Computers"}, user.Privileges.JssObjects)
+	assert.Equal(t, -1, user.Site.ID)
+
+	user, _, err = j.GetByUserName("jdoe")
+	assert.Nil(t, err)
+	assert.Equal(t, 3, user.Id)
+
+	_, _, err = j.GetByUserName("nobody")
+	assert.NotNil(t, err)
+}
+
+func TestQueryAccountGroup(t *testing.T) {
+	testServer := accountsResponseMocks(t)
+	defer testServer.Close()
+	j, err := jamf.NewService(testServer.URL, "fake-username", "mock-password-cool", nil)
+	assert.Nil(t, err)
+
+	group, _, err := j.GetByGroupId(7)
+	assert.Nil(t, err)
+	assert.Equal(t, "IT Admins", group.Name)
+	assert.Equal(t, []jamf.JamfUserId{{Id: 3, Name: "jdoe"}}, group.Members)
+
+	group, _, err = j.GetByGroupName("IT Admins")
+	assert.Nil(t, err)
+	assert.Equal(t, 7, group.Id)
+}
+
+func TestAccountUserXML(t *testing.T) {
+	user := &jamf.JamfUser{
+		Name:                "jdoe",
+		Password:            "hunter2",
+		Enabled:             "Enabled",
+		ForcePasswordChange: client.Bool(false),
+		AccessLevel:         "Full Access",
+		PrivilegeSet:        "Custom",
+		Privileges: jamf.Privileges{
+			JssObjects: []jamf.Privilege{"Read Computers", "Update Computers"},
+			JssActions: []jamf.Privilege{"Flush MDM Commands"},
+		},
+	}
+
+	data, err := xml.Marshal(user)
+	assert.Nil(t, err)
+	assert.Equal(t, "<account><name>jdoe</name><password>hunter2</password>"+
+		"<enabled>Enabled</enabled><force_password_change>false</force_password_change><access_level>Full Access</access_level>"+
+		"<privilege_set>Custom</privilege_set><privileges><jss_objects><privilege>Read Computers</privilege>"+
+		"<privilege>Update Computers</privilege></jss_objects><jss_actions><privilege>Flush MDM Commands</privilege>"+
+		"</jss_actions></privileges></account>", string(data))
+
+	decoded := &jamf.JamfUser{}
+	assert.Nil(t, xml.Unmarshal(data, decoded))
+	assert.Equal(t, user.Privileges, decoded.Privileges)
+
+	group := &jamf.JamfGroup{Name: "IT Admins", PrivilegeSet: "Auditor", Members: []jamf.JamfUserId{{Id: 3}}}
+	data, err = xml.Marshal(group)
+	assert.Nil(t, err)
+	assert.Equal(t, "<group><name>IT Admins</name><privilege_set>Auditor</privilege_set>"+
+		"<members><user><id>3</id></user></members></group>", string(data))
+}
+
+func TestCreateUpdateDeleteAccountUser(t *testing.T) {
+	testServer := accountsResponseMocks(t)
+	defer testServer.Close()
+	j, err := jamf.NewService(testServer.URL, "fake-username", "mock-password-cool", nil)
+	assert.Nil(t, err)
+
+	created, _, err := j.CreateUser(&jamf.JamfUser{Name: "jdoe", Password: "hunter2", PrivilegeSet: "Auditor"})
+	assert.Nil(t, err)
+	assert.Equal(t, 3, created.Id)
+
+	_, _, err = j.CreateUser(&jamf.JamfUser{})
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "Name required for new account user")
+
+	updated, _, err := j.UpdateUser("jdoe", &jamf.JamfUser{Name: "jdoe", Enabled: "Disabled"})
+	assert.Nil(t, err)
+	assert.Equal(t, "jdoe", updated.Name)
+
+	_, _, err = j.UpdateUser(3, nil)
+	assert.NotNil(t, err)
+
+	_, _, err = j.UpdateUser(1.5, &jamf.JamfUser{})
+	assert.NotNil(t, err)
+
+	deleted, _, err := j.DeleteUser(3)
+	assert.Nil(t, err)
+	assert.Equal(t, 3, deleted.Id)
+}
+
+func TestCreateUpdateDeleteAccountGroup(t *testing.T) {
+	testServer := accountsResponseMocks(t)
+	defer testServer.Close()
+	j, err := jamf.NewService(testServer.URL, "fake-username", "mock-password-cool", nil)
+	assert.Nil(t, err)
+
+	created, _, err := j.CreateGroup(&jamf.JamfGroup{Name: "IT Admins", PrivilegeSet: "Administrator"})
+	assert.Nil(t, err)
+	assert.Equal(t, 7, created.Id)
+
+	_, _, err = j.CreateGroup(nil)
+	assert.NotNil(t, err)
+
+	updated, _, err := j.UpdateGroup("IT Admins", &jamf.JamfGroup{Name: "IT Admins"})
+	assert.Nil(t, err)
+	assert.Equal(t, "IT Admins", updated.Name)
+
+	deleted, _, err := j.DeleteGroup(7)
+	assert.Nil(t, err)
+	assert.Equal(t, 7, deleted.Id)
+}
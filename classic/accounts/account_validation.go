@@ -0,0 +1,103 @@
+// Unless explicitly stated otherwise all files in this repository are licensed under the Apache-2.0
+// This product includes software developed at Datadog (https://www.datadoghq.com/). Copyright 2020 Datadog, Inc.
+
+package accounts
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ValidateUser orchestrates user account validation
+func ValidateUser(u *JamfUser) error {
+	if err := u.AccessLevel.Validate(); err != nil {
+		return err
+	}
+
+	if err := u.PrivilegeSet.Validate(); err != nil {
+		return err
+	}
+
+	if err := u.Enabled.Validate(); err != nil {
+		return err
+	}
+
+	if err := u.Privileges.Validate(); err != nil {
+		return err
+	}
+
+	return validateSite(u.AccessLevel, u.Site)
+}
+
+// ValidateGroup orchestrates group account validation
+func ValidateGroup(g *JamfGroup) error {
+	if err := g.AccessLevel.Validate(); err != nil {
+		return err
+	}
+
+	if err := g.PrivilegeSet.Validate(); err != nil {
+		return err
+	}
+
+	if err := g.Privileges.Validate(); err != nil {
+		return err
+	}
+
+	return validateSite(g.AccessLevel, g.Site)
+}
+
+// Validate will validate that an access level is supported. An empty access level is left to Jamf
+func (l AccessLevel) Validate() error {
+	switch strings.ToLower(string(l)) {
+	case "", "full access", "site access", "group access":
+		return nil
+	default:
+		return fmt.Errorf("%s is not a valid access level must be of type [ Full Access, Site Access, Group Access ]", l)
+	}
+}
+
+// Validate will validate that a privilege set is supported. An empty privilege set is left to Jamf
+func (s PrivilegeSet) Validate() error {
+	switch strings.ToLower(string(s)) {
+	case "", "administrator", "auditor", "enrollment only", "custom":
+		return nil
+	default:
+		return fmt.Errorf("%s is not a valid privilege set must be of type [ Administrator, Auditor, Enrollment Only, Custom ]", s)
+	}
+}
+
+// Validate will validate that an account status is supported. An empty status is left to Jamf
+func (s AccountStatus) Validate() error {
+	switch strings.ToLower(string(s)) {
+	case "", "enabled", "disabled":
+		return nil
+	default:
+		return fmt.Errorf("%s is not a valid account status must be of type [ Enabled, Disabled ]", s)
+	}
+}
+
+// Validate will validate that every privilege is registered under its category. Privileges
+// added by newer Jamf versions must be registered with RegisterPrivilege before use
+func (p Privileges) Validate() error {
+	var unknown []string
+	for _, c := range PrivilegeCategories {
+		for _, privilege := range p.Category(c) {
+			if !IsKnownPrivilege(c, privilege) {
+				unknown = append(unknown, fmt.Sprintf("%s (%s)", privilege, c))
+			}
+		}
+	}
+
+	if len(unknown) > 0 {
+		return fmt.Errorf("unknown privilege(s): %s", strings.Join(unknown, ", "))
+	}
+	return nil
+}
+
+// validateSite makes sure accounts with Site Access are limited to a site
+func validateSite(level AccessLevel, site *Site) error {
+	if strings.EqualFold(string(level), string(SiteAccess)) && (site == nil || (site.ID <= 0 && site.Name == "")) {
+		return fmt.Errorf("a site is required for accounts with %s", SiteAccess)
+	}
+	return nil
+}
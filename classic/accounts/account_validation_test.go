@@ -0,0 +1,97 @@
+// Unless explicitly stated otherwise all files in this repository are licensed under the Apache-2.0
+// This product includes software developed at Datadog (https://www.datadoghq.com/). Copyright 2020 Datadog, Inc.
+
+package accounts_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	jamf "github.com/trustero/jamf-api-client-go/classic/accounts"
+)
+
+func TestValidateUserPass(t *testing.T) {
+	user := &jamf.JamfUser{
+		Name:         "jdoe",
+		Enabled:      jamf.AccountEnabled,
+		AccessLevel:  jamf.FullAccess,
+		PrivilegeSet: jamf.Custom,
+		Privileges:   jamf.Privileges{JssObjects: []jamf.Privilege{"Read Computers"}},
+	}
+	assert.Nil(t, jamf.ValidateUser(user))
+
+	// values left empty are left to Jamf
+	assert.Nil(t, jamf.ValidateUser(&jamf.JamfUser{Name: "jdoe"}))
+
+	user.AccessLevel = jamf.SiteAccess
+	user.Site = &jamf.Site{ID: 2}
+	assert.Nil(t, jamf.ValidateUser(user))
+}
+
+func TestValidateUserFail(t *testing.T) {
+	err := jamf.ValidateUser(&jamf.JamfUser{AccessLevel: "Everything"})
+	assert.NotNil(t, err)
+	assert.Equal(t, "Everything is not a valid access level must be of type [ Full Access, Site Access, Group Access ]", err.Error())
+
+	err = jamf.ValidateUser(&jamf.JamfUser{PrivilegeSet: "Superuser"})
+	assert.NotNil(t, err)
+	assert.Equal(t, "Superuser is not a valid privilege set must be of type [ Administrator, Auditor, Enrollment Only, Custom ]", err.Error())
+
+	err = jamf.ValidateUser(&jamf.JamfUser{Enabled: "Locked"})
+	assert.NotNil(t, err)
+	assert.Equal(t, "Locked is not a valid account status must be of type [ Enabled, Disabled ]", err.Error())
+
+	err = jamf.ValidateUser(&jamf.JamfUser{AccessLevel: jamf.SiteAccess})
+	assert.NotNil(t, err)
+	assert.Equal(t, "a site is required for accounts with Site Access", err.Error())
+
+	err = jamf.Privileges{
+		JssObjects:  []jamf.Privilege{"Read Computers", "Read Everything"},
+		JssSettings: []jamf.Privilege{"Read Computers"},
+	}.Validate()
+	assert.NotNil(t, err)
+	assert.Equal(t, "unknown privilege(s): Read Everything (jss_objects), Read Computers (jss_settings)", err.Error())
+}
+
+func TestValidateGroup(t *testing.T) {
+	assert.Nil(t, jamf.ValidateGroup(&jamf.JamfGroup{Name: "IT", AccessLevel: jamf.GroupAccess, PrivilegeSet: jamf.Auditor}))
+
+	err := jamf.ValidateGroup(&jamf.JamfGroup{Name: "IT", PrivilegeSet: "Root"})
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "Root is not a valid privilege set")
+}
+
+func TestCreateUserValidation(t *testing.T) {
+	testServer := accountsResponseMocks(t)
+	defer testServer.Close()
+	j, err := jamf.NewService(testServer.URL, "fake-username", "mock-password-cool", nil)
+	assert.Nil(t, err)
+
+	_, _, err = j.CreateUser(&jamf.JamfUser{Name: "jdoe", PrivilegeSet: "Superuser"})
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "account user validation failed: jdoe")
+
+	_, _, err = j.UpdateGroup(7, &jamf.JamfGroup{AccessLevel: "Everything"})
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "account group validation failed: 7")
+
+	// unknown privileges are rejected on both create and update
+	user := &jamf.JamfUser{Name: "jdoe", Privileges: jamf.Privileges{JssObjects: []jamf.Privilege{"Read Vaults"}}}
+	_, _, err = j.CreateUser(user)
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "unknown privilege(s): Read Vaults (jss_objects)")
+
+	_, _, err = j.UpdateUser(3, user)
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "account user validation failed: 3: unknown privilege(s): Read Vaults (jss_objects)")
+
+	group := &jamf.JamfGroup{Name: "IT", Privileges: jamf.Privileges{JssSettings: []jamf.Privilege{"Read Vaults"}}}
+	_, _, err = j.UpdateGroup(7, group)
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "unknown privilege(s): Read Vaults (jss_settings)")
+
+	// once registered a privilege added by a newer Jamf version is accepted
+	jamf.RegisterPrivilege(jamf.JssObjects, "Read Vaults")
+	_, _, err = j.UpdateUser(3, user)
+	assert.Nil(t, err)
+}
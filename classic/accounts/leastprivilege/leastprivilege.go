@@ -0,0 +1,240 @@
+// Unless explicitly stated otherwise all files in this repository are licensed under the Apache-2.0
+// This product includes software developed at Datadog (https://www.datadoghq.com/). Copyright 2020 Datadog, Inc.
+
+// Package leastprivilege works out the minimal Jamf privileges an API account needs from the
+// requests a program makes, see Recorder and Calculate
+package leastprivilege
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/trustero/jamf-api-client-go/classic/accounts"
+)
+
+// Requirement is a privilege needed to make a call
+type Requirement struct {
+	Category  accounts.PrivilegeCategory
+	Privilege accounts.Privilege
+}
+
+// domainRule maps the requests to an API domain to the Jamf objects whose privileges they need
+type domainRule struct {
+	category accounts.PrivilegeCategory
+	objects  []string
+	// commands maps the command found in the request path to the action it needs
+	commands map[string]accounts.Privilege
+}
+
+// methodVerbs maps an HTTP method to the verb used by Jamf privileges
+var methodVerbs = map[string]string{
+	"GET":    "Read",
+	"POST":   "Create",
+	"PUT":    "Update",
+	"DELETE": "Delete",
+}
+
+var (
+	rulesMu sync.RWMutex
+	rules   = map[string]domainRule{
+		"accounts":                    {category: accounts.JssSettings, objects: []string{"Accounts"}},
+		"buildings":                   {category: accounts.JssObjects, objects: []string{"Buildings"}},
+		"categories":                  {category: accounts.JssObjects, objects: []string{"Categories"}},
+		"computerextensionattributes": {category: accounts.JssObjects, objects: []string{"Computer Extension Attributes"}},
+		"computergroups":              {category: accounts.JssObjects, objects: []string{"Smart Computer Groups", "Static Computer Groups"}},
+		"computers":                   {category: accounts.JssObjects, objects: []string{"Computers"}},
+		"computers-inventory":         {category: accounts.JssObjects, objects: []string{"Computers"}},
+		"departments":                 {category: accounts.JssObjects, objects: []string{"Departments"}},
+		"mobiledevicegroups":          {category: accounts.JssObjects, objects: []string{"Smart Mobile Device Groups", "Static Mobile Device Groups"}},
+		"mobiledevices":               {category: accounts.JssObjects, objects: []string{"Mobile Devices"}},
+		"osxconfigurationprofiles":    {category: accounts.JssObjects, objects: []string{"macOS Configuration Profiles"}},
+		"packages":                    {category: accounts.JssObjects, objects: []string{"Packages"}},
+		"policies":                    {category: accounts.JssObjects, objects: []string{"Policies"}},
+		"scripts":                     {category: accounts.JssObjects, objects: []string{"Scripts"}},
+		"sites":                       {category: accounts.JssObjects, objects: []string{"Sites"}},
+		// any account can read the Jamf Pro version
+		"jamf-pro-version": {},
+		// every Jamf Pro API client requests, keeps alive and invalidates its own bearer token
+		"auth": {},
+		"computercommands": {
+			category: accounts.JssActions,
+			commands: map[string]accounts.Privilege{
+				"DeviceLock":     "Send Computer Remote Lock Command",
+				"EraseDevice":    "Send Computer Remote Wipe Command",
+				"UnmanageDevice": "Send Computer Unmanage Command",
+			},
+		},
+		"mobiledevicecommands": {
+			category: accounts.JssActions,
+			commands: map[string]accounts.Privilege{
+				"BlankPush":                           "Send Blank Pushes to Mobile Devices",
+				"ClearPasscode":                       "Send Mobile Device Remove Passcode Command",
+				"DeviceLock":                          "Send Mobile Device Remote Lock Command",
+				"DeviceName":                          "Send Mobile Device Set Device Name Command",
+				"EnableLostMode":                      "Send Mobile Device Lost Mode Command",
+				"DisableLostMode":                     "Send Mobile Device Lost Mode Command",
+				"EraseDevice":                         "Send Mobile Device Remote Wipe Command",
+				"RestartDevice":                       "Send Mobile Device Restart Device Command",
+				"ShutDownDevice":                      "Send Mobile Device Shut Down Command",
+				"UnmanageDevice":                      "Unmanage Mobile Devices",
+				"UpdateInventory":                     "Send Inventory Requests to Mobile Devices",
+				"ScheduleOSUpdate":                    "Send Mobile Device Remote Command to Download and Install iOS Update",
+				"PlayLostModeSound":                   "Send Mobile Device Lost Mode Command",
+				"Wallpaper":                           "Send Mobile Device Set Wallpaper Command",
+				"SettingsEnableBluetooth":             "Send Mobile Device Bluetooth Command",
+				"SettingsDisableBluetooth":            "Send Mobile Device Bluetooth Command",
+				"SettingsEnableDataRoaming":           "Send Mobile Device Enable Data Roaming Command",
+				"SettingsDisableDataRoaming":          "Send Mobile Device Disable Data Roaming Command",
+				"SettingsEnableVoiceRoaming":          "Send Mobile Device Enable Voice Roaming Command",
+				"SettingsDisableVoiceRoaming":         "Send Mobile Device Disable Voice Roaming Command",
+				"SettingsEnablePersonalHotspot":       "Send Mobile Device Personal Hotspot Command",
+				"SettingsDisablePersonalHotspot":      "Send Mobile Device Personal Hotspot Command",
+				"SettingsEnableAppAnalytics":          "Send Mobile Device Diagnostics and Usage Reporting and App Analytics Commands",
+				"SettingsDisableAppAnalytics":         "Send Mobile Device Diagnostics and Usage Reporting and App Analytics Commands",
+				"SettingsEnableDiagnosticSubmission":  "Send Mobile Device Diagnostics and Usage Reporting and App Analytics Commands",
+				"SettingsDisableDiagnosticSubmission": "Send Mobile Device Diagnostics and Usage Reporting and App Analytics Commands",
+			},
+		},
+	}
+)
+
+// RegisterDomain maps the requests to an API domain to the privileges of Jamf objects, ex.
+// RegisterDomain("printers", accounts.JssObjects, "Printers") makes GET printers need Read Printers.
+// A domain registered without objects needs no privileges
+func RegisterDomain(domain string, category accounts.PrivilegeCategory, objects ...string) {
+	rulesMu.Lock()
+	defer rulesMu.Unlock()
+	rules[domain] = domainRule{category: category, objects: objects}
+}
+
+// RequirementsFor returns the privileges needed to make a call. It returns false when the
+// domain or command is unknown. Group requests need the privileges of both smart and static
+// groups since the group type is not known from the request
+func RequirementsFor(call Call) ([]Requirement, bool) {
+	rulesMu.RLock()
+	rule, ok := rules[call.Domain]
+	rulesMu.RUnlock()
+	if !ok {
+		return nil, false
+	}
+
+	if rule.commands != nil {
+		// commands are only sent with POST, reading them back needs the device privilege
+		if call.Method != "POST" {
+			return []Requirement{{Category: accounts.JssObjects, Privilege: readDevices(call.Domain)}}, true
+		}
+		privilege, ok := rule.commands[commandName(call.Path)]
+		if !ok {
+			return nil, false
+		}
+		return []Requirement{{Category: rule.category, Privilege: privilege}}, true
+	}
+
+	verb, ok := methodVerbs[call.Method]
+	if !ok {
+		return nil, false
+	}
+
+	requirements := make([]Requirement, 0, len(rule.objects))
+	for _, object := range rule.objects {
+		requirements = append(requirements, Requirement{Category: rule.category, Privilege: accounts.Privilege(verb + " " + object)})
+	}
+	return requirements, true
+}
+
+// Result holds the minimal privileges needed for a set of calls
+type Result struct {
+	Privileges accounts.Privileges
+	// Unmapped holds the distinct calls whose privileges are unknown, see RegisterDomain
+	Unmapped []Call
+}
+
+// Calculate returns the minimal privileges needed to make every call. Each privilege is listed
+// once and sorted by name
+func Calculate(calls []Call) *Result {
+	res := &Result{}
+	unmapped := map[string]bool{}
+	for _, call := range calls {
+		requirements, ok := RequirementsFor(call)
+		if !ok {
+			key := call.String() + call.Path
+			if !unmapped[key] {
+				unmapped[key] = true
+				res.Unmapped = append(res.Unmapped, call)
+			}
+			continue
+		}
+		for _, r := range requirements {
+			res.Privileges.Add(r.Category, r.Privilege)
+		}
+	}
+
+	for _, c := range accounts.PrivilegeCategories {
+		list := res.Privileges.Category(c)
+		sort.Slice(list, func(a, b int) bool { return list[a] < list[b] })
+	}
+	return res
+}
+
+// Err returns an error listing the unmapped calls if any
+func (r *Result) Err() error {
+	if len(r.Unmapped) == 0 {
+		return nil
+	}
+
+	calls := make([]string, len(r.Unmapped))
+	for i, c := range r.Unmapped {
+		calls[i] = c.String() + c.Path
+	}
+	return fmt.Errorf("unable to map %d call(s) to privileges: %s", len(calls), strings.Join(calls, ", "))
+}
+
+// User returns an accounts create payload for a Custom user holding only the needed privileges
+func (r *Result) User(name string) *accounts.JamfUser {
+	return &accounts.JamfUser{
+		Name:         name,
+		Enabled:      accounts.AccountEnabled,
+		AccessLevel:  accounts.FullAccess,
+		PrivilegeSet: accounts.Custom,
+		Privileges:   r.Privileges,
+	}
+}
+
+// APIRole is a Jamf Pro API role definition as sent to /api/v1/api-roles
+type APIRole struct {
+	DisplayName string   `json:"displayName"`
+	Privileges  []string `json:"privileges"`
+}
+
+// APIRole returns an API role holding only the needed privileges
+func (r *Result) APIRole(name string) *APIRole {
+	role := &APIRole{DisplayName: name, Privileges: []string{}}
+	for _, c := range accounts.PrivilegeCategories {
+		for _, p := range r.Privileges.Category(c) {
+			role.Privileges = append(role.Privileges, string(p))
+		}
+	}
+	sort.Strings(role.Privileges)
+	return role
+}
+
+// commandName returns the command of a command request path, ex. DeviceLock for
+// /command/DeviceLock/id/1
+func commandName(path string) string {
+	parts := strings.Split(strings.Trim(path, "/"), "/")
+	for i, part := range parts {
+		if part == "command" && i+1 < len(parts) {
+			return parts[i+1]
+		}
+	}
+	return ""
+}
+
+func readDevices(domain string) accounts.Privilege {
+	if domain == "computercommands" {
+		return "Read Computers"
+	}
+	return "Read Mobile Devices"
+}
@@ -0,0 +1,156 @@
+// Unless explicitly stated otherwise all files in this repository are licensed under the Apache-2.0
+// This product includes software developed at Datadog (https://www.datadoghq.com/). Copyright 2020 Datadog, Inc.
+
+package leastprivilege_test
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/trustero/jamf-api-client-go/classic/accounts"
+	"github.com/trustero/jamf-api-client-go/classic/accounts/leastprivilege"
+	"github.com/trustero/jamf-api-client-go/classic/computers"
+	"github.com/trustero/jamf-api-client-go/classic/scripts"
+	"github.com/trustero/jamf-api-client-go/pro/v1/computersinventory"
+)
+
+func TestParseCall(t *testing.T) {
+	call, ok := leastprivilege.ParseCall("get", "/JSSResource/computers/id/1")
+	assert.True(t, ok)
+	assert.Equal(t, leastprivilege.Call{Method: "GET", Domain: "computers", Path: "/id/1"}, call)
+
+	call, ok = leastprivilege.ParseCall("GET", "/api/v1/computers-inventory")
+	assert.True(t, ok)
+	assert.Equal(t, leastprivilege.Call{Method: "GET", Domain: "computers-inventory", Pro: true}, call)
+
+	_, ok = leastprivilege.ParseCall("GET", "/healthCheck.html")
+	assert.False(t, ok)
+}
+
+func TestRequirementsFor(t *testing.T) {
+	r, ok := leastprivilege.RequirementsFor(leastprivilege.Call{Method: "PUT", Domain: "scripts"})
+	assert.True(t, ok)
+	assert.Equal(t, []leastprivilege.Requirement{{Category: accounts.JssObjects, Privilege: "Update Scripts"}}, r)
+
+	r, ok = leastprivilege.RequirementsFor(leastprivilege.Call{Method: "GET", Domain: "accounts"})
+	assert.True(t, ok)
+	assert.Equal(t, []leastprivilege.Requirement{{Category: accounts.JssSettings, Privilege: "Read Accounts"}}, r)
+
+	r, ok = leastprivilege.RequirementsFor(leastprivilege.Call{Method: "POST", Domain: "mobiledevicecommands", Path: "/command/DeviceLock/id/7"})
+	assert.True(t, ok)
+	assert.Equal(t, []leastprivilege.Requirement{{Category: accounts.JssActions, Privilege: "Send Mobile Device Remote Lock Command"}}, r)
+
+	// commands sent with an XML payload only name the command in the path
+	r, ok = leastprivilege.RequirementsFor(leastprivilege.Call{Method: "POST", Domain: "mobiledevicecommands", Path: "/command/SettingsDisablePersonalHotspot"})
+	assert.True(t, ok)
+	assert.Equal(t, []leastprivilege.Requirement{{Category: accounts.JssActions, Privilege: "Send Mobile Device Personal Hotspot Command"}}, r)
+	assert.True(t, accounts.IsKnownPrivilege(accounts.JssActions, r[0].Privilege))
+
+	r, ok = leastprivilege.RequirementsFor(leastprivilege.Call{Method: "POST", Domain: "mobiledevicecommands", Path: "/command/ClearPasscode"})
+	assert.True(t, ok)
+	assert.Equal(t, []leastprivilege.Requirement{{Category: accounts.JssActions, Privilege: "Send Mobile Device Remove Passcode Command"}}, r)
+	assert.True(t, accounts.IsKnownPrivilege(accounts.JssActions, r[0].Privilege))
+
+	r, ok = leastprivilege.RequirementsFor(leastprivilege.Call{Method: "GET", Domain: "jamf-pro-version", Pro: true})
+	assert.True(t, ok)
+	assert.Empty(t, r)
+
+	_, ok = leastprivilege.RequirementsFor(leastprivilege.Call{Method: "GET", Domain: "printers"})
+	assert.False(t, ok)
+
+	leastprivilege.RegisterDomain("printers", accounts.JssObjects, "Printers")
+	r, ok = leastprivilege.RequirementsFor(leastprivilege.Call{Method: "DELETE", Domain: "printers"})
+	assert.True(t, ok)
+	assert.Equal(t, accounts.Privilege("Delete Printers"), r[0].Privilege)
+}
+
+func TestRecordAndCalculate(t *testing.T) {
+	testServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/JSSResource/computers":
+			fmt.Fprint(w, `{"computers": [{"id": 1, "name": "mac"}]}`)
+		case "/JSSResource/scripts":
+			fmt.Fprint(w, `{"scripts": []}`)
+		case "/JSSResource/scripts/id/4":
+			w.Header().Set("Content-Type", "application/xml")
+			fmt.Fprint(w, `<script><id>4</id></script>`)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer testServer.Close()
+
+	rec := leastprivilege.NewRecorder(nil)
+	computerService, err := computers.NewService(testServer.URL, "fake-username", "mock-password-cool", rec.Client())
+	assert.Nil(t, err)
+	scriptService, err := scripts.NewService(testServer.URL, "fake-username", "mock-password-cool", rec.Client())
+	assert.Nil(t, err)
+
+	_, _, err = computerService.List()
+	assert.Nil(t, err)
+	_, _, err = computerService.List()
+	assert.Nil(t, err)
+	_, _, err = scriptService.Scripts()
+	assert.Nil(t, err)
+	_, _, err = scriptService.DeleteScript(4)
+	assert.Nil(t, err)
+
+	calls := rec.Calls()
+	assert.Equal(t, 4, len(calls))
+	assert.Equal(t, "DELETE scripts", calls[3].String())
+
+	res := leastprivilege.Calculate(append(calls, leastprivilege.Call{Method: "GET", Domain: "ldapservers"}))
+	assert.Equal(t, []accounts.Privilege{"Delete Scripts", "Read Computers", "Read Scripts"}, res.Privileges.JssObjects)
+	assert.Empty(t, res.Privileges.JssSettings)
+	assert.NotNil(t, res.Err())
+	assert.Equal(t, "unable to map 1 call(s) to privileges: GET ldapservers", res.Err().Error())
+
+	user := res.User("automation-scripts")
+	assert.Nil(t, accounts.ValidateUser(user))
+	data, err := xml.Marshal(user)
+	assert.Nil(t, err)
+	assert.Contains(t, string(data), "<privilege_set>Custom</privilege_set><privileges><jss_objects><privilege>Delete Scripts</privilege>")
+
+	data, err = json.Marshal(res.APIRole("automation-scripts"))
+	assert.Nil(t, err)
+	assert.Equal(t, `{"displayName":"automation-scripts","privileges":["Delete Scripts","Read Computers","Read Scripts"]}`, string(data))
+
+	rec.Reset()
+	assert.Empty(t, rec.Calls())
+}
+
+func TestRecordProAPI(t *testing.T) {
+	testServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v1/auth/token":
+			fmt.Fprintf(w, `{"token": "mock-token", "expires": "%s"}`, time.Now().Add(time.Hour).UTC().Format(time.RFC3339))
+		case "/api/v1/computers-inventory":
+			fmt.Fprint(w, `{"totalCount": 0, "results": []}`)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer testServer.Close()
+
+	rec := leastprivilege.NewRecorder(nil)
+	inventoryService, err := computersinventory.NewService(testServer.URL, "fake-username", "mock-password-cool", rec.Client())
+	assert.Nil(t, err)
+
+	_, _, err = inventoryService.List(nil)
+	assert.Nil(t, err)
+
+	calls := rec.Calls()
+	assert.Equal(t, 2, len(calls))
+	assert.Equal(t, []string{"POST auth", "GET computers-inventory"}, []string{calls[0].String(), calls[1].String()})
+
+	// the token request needs no privilege
+	res := leastprivilege.Calculate(calls)
+	assert.Nil(t, res.Err())
+	assert.Equal(t, []accounts.Privilege{"Read Computers"}, res.Privileges.JssObjects)
+}
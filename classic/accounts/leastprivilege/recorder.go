@@ -0,0 +1,107 @@
+// Unless explicitly stated otherwise all files in this repository are licensed under the Apache-2.0
+// This product includes software developed at Datadog (https://www.datadoghq.com/). Copyright 2020 Datadog, Inc.
+
+package leastprivilege
+
+import (
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Call is a single Jamf API request
+type Call struct {
+	Method string
+	// Domain is the API context requested, ex. computers for /JSSResource/computers/id/1 or
+	// computers-inventory for /api/v1/computers-inventory
+	Domain string
+	// Path is the request path following the domain, ex. /id/1
+	Path string
+	// Pro is set for Jamf Pro API requests
+	Pro bool
+}
+
+// String returns the method and domain of the call, ex. GET computers
+func (c Call) String() string {
+	return c.Method + " " + c.Domain
+}
+
+// Recorder is an http.RoundTripper which records every Jamf API request passing through it.
+// Every service sends its requests through client.MakeAPIrequest using the http.Client given to
+// NewService so passing Recorder.Client() to each service records everything a program calls
+type Recorder struct {
+	mu        sync.Mutex
+	transport http.RoundTripper
+	calls     []Call
+}
+
+// NewRecorder returns a recorder sending requests on to transport, http.DefaultTransport is used
+// when transport is nil
+func NewRecorder(transport http.RoundTripper) *Recorder {
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+	return &Recorder{transport: transport}
+}
+
+// Client returns an http.Client using the recorder to be passed to NewService
+func (r *Recorder) Client() *http.Client {
+	return &http.Client{Transport: r, Timeout: time.Minute}
+}
+
+// RoundTrip records the request and sends it on. Requests outside of the Jamf APIs are sent on
+// without being recorded
+func (r *Recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	if call, ok := ParseCall(req.Method, req.URL.Path); ok {
+		r.mu.Lock()
+		r.calls = append(r.calls, call)
+		r.mu.Unlock()
+	}
+	return r.transport.RoundTrip(req)
+}
+
+// Calls returns every recorded call in the order they were made
+func (r *Recorder) Calls() []Call {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]Call(nil), r.calls...)
+}
+
+// Reset forgets every recorded call
+func (r *Recorder) Reset() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.calls = nil
+}
+
+// ParseCall splits a Classic API (/JSSResource/<domain>/...) or Jamf Pro API
+// (/api/<version>/<domain>/...) path into a call
+func ParseCall(method string, path string) (Call, bool) {
+	call := Call{Method: strings.ToUpper(method)}
+
+	var rest string
+	if i := strings.Index(path, "/JSSResource/"); i != -1 {
+		rest = path[i+len("/JSSResource/"):]
+	} else if i := strings.Index(path, "/api/"); i != -1 {
+		// skip the version segment i.e v1
+		parts := strings.SplitN(path[i+len("/api/"):], "/", 2)
+		if len(parts) < 2 {
+			return call, false
+		}
+		rest = parts[1]
+		call.Pro = true
+	} else {
+		return call, false
+	}
+
+	parts := strings.SplitN(rest, "/", 2)
+	if parts[0] == "" {
+		return call, false
+	}
+	call.Domain = parts[0]
+	if len(parts) == 2 {
+		call.Path = "/" + parts[1]
+	}
+	return call, true
+}
@@ -4,6 +4,8 @@
 package client
 
 import (
+	"bytes"
+	"context"
 	"encoding/json"
 	"encoding/xml"
 	"fmt"
@@ -42,6 +44,16 @@ func DefaultHTTPClient() *http.Client {
 	}
 }
 
+// RetryingHTTPClient returns a client retrying the requests Jamf rejects because it is busy, see
+// RetryTransport. It can be passed to NewDomainClient and is the default of the Jamf Pro client.
+// Its timeout covers every attempt along with the waits between them
+func RetryingHTTPClient() *http.Client {
+	return &http.Client{
+		Timeout:   DefaultRetryTimeout,
+		Transport: NewRetryTransport(nil),
+	}
+}
+
 // NewDomainClient returns a new Jamf HTTP client to be used for API requests
 func NewDomainClient(baseUrl string, domain string, username string, password string, client *http.Client) (*Client, error) {
 	if baseUrl == "" || username == "" || password == "" {
@@ -83,6 +95,18 @@ func (j *Client) IdEndpoint(identifier int) string {
 	return fmt.Sprintf("%s/id/%d", j.Endpoint, identifier)
 }
 
+// IdentifierEndpoint can be utilized to query a specific API context via either name (string) or Id (int)
+func (j *Client) IdentifierEndpoint(identifier interface{}) (string, error) {
+	switch id := identifier.(type) {
+	case string:
+		return j.NameEndpoint(id), nil
+	case int:
+		return j.IdEndpoint(id), nil
+	default:
+		return "", fmt.Errorf("invalid identifier of type (%T) passed for %s please use name (string) or id (int)", identifier, j.Endpoint)
+	}
+}
+
 // EndpointBuilder can be utilized to query a specific API context via UserId
 func (j *Client) UserEndpoint(identifier int) string {
 	return fmt.Sprintf("%s/userid/%d", j.Endpoint, identifier)
@@ -93,6 +117,40 @@ func (j *Client) GroupEndpoint(identifier int) string {
 	return fmt.Sprintf("%s/groupid/%d", j.Endpoint, identifier)
 }
 
+// EndpointBuilder can be utilized to query a specific API context via UserName
+func (j *Client) UserNameEndpoint(identifier string) string {
+	return fmt.Sprintf("%s/username/%s", j.Endpoint, identifier)
+}
+
+// EndpointBuilder can be utilized to query a specific API context via GroupName
+func (j *Client) GroupNameEndpoint(identifier string) string {
+	return fmt.Sprintf("%s/groupname/%s", j.Endpoint, identifier)
+}
+
+// SendXML makes a request to an endpoint with an optional payload, Jamf only accepts XML
+// payloads on create and update, and decodes the response into v
+func SendXML(j *Client, method string, ep string, payload interface{}, v interface{}) (*http.Response, error) {
+	var body bytes.Buffer
+	if payload != nil {
+		data, err := xml.Marshal(payload)
+		if err != nil {
+			return nil, errors.Wrapf(err, "error building JAMF payload (%s)", ep)
+		}
+		body.Write(data)
+	}
+
+	req, err := http.NewRequestWithContext(context.Background(), method, ep, &body)
+	if err != nil {
+		return nil, errors.Wrapf(err, "error building JAMF request (%s)", ep)
+	}
+	return MakeAPIrequest(j, req, v)
+}
+
+// Bool returns a pointer to a bool for optional fields of payloads which are only sent when set
+func Bool(v bool) *bool {
+	return &v
+}
+
 func MakeAPIrequest(j *Client, r *http.Request, v interface{}) (*http.Response, error) {
 	// Jamf API only sends XML for some endpoints so we will accept both but prioritize
 	// JSON responses with the quallity value of 1.0 and 0.9 for XML responses
@@ -5,9 +5,12 @@ package client_test
 import (
 	"context"
 	"fmt"
+	"io/ioutil"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	jamf "github.com/trustero/jamf-api-client-go/classic/client"
@@ -69,3 +72,81 @@ func TestBadNewClient(t *testing.T) {
 	assert.Equal(t, "you must provide a valid Jamf base url, username, and password", err.Error())
 	assert.Nil(t, j)
 }
+
+func TestIdentifierEndpoint(t *testing.T) {
+	j, err := jamf.NewDomainClient("https://mock.test.com", "tests", "fake-username", "mock-password-cool", nil)
+	assert.Nil(t, err)
+
+	ep, err := j.IdentifierEndpoint(87)
+	assert.Nil(t, err)
+	assert.Equal(t, "https://mock.test.com/JSSResource/tests/id/87", ep)
+
+	ep, err = j.IdentifierEndpoint("the-one-that-passes")
+	assert.Nil(t, err)
+	assert.Equal(t, "https://mock.test.com/JSSResource/tests/name/the-one-that-passes", ep)
+
+	_, err = j.IdentifierEndpoint(1.23)
+	assert.NotNil(t, err)
+	assert.Equal(t, "invalid identifier of type (float64) passed for https://mock.test.com/JSSResource/tests please use name (string) or id (int)", err.Error())
+}
+
+func TestRetryTransport(t *testing.T) {
+	attempts := []string{}
+	testServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		data, err := ioutil.ReadAll(r.Body)
+		assert.Nil(t, err)
+		attempts = append(attempts, r.Method+" "+string(data))
+		switch {
+		case strings.HasSuffix(r.URL.Path, "/gateway"):
+			w.WriteHeader(http.StatusBadGateway)
+		case len(attempts) < 3:
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusTooManyRequests)
+		default:
+			fmt.Fprint(w, `{"status": "OK"}`)
+		}
+	}))
+	defer testServer.Close()
+
+	// the default client does not retry
+	j, err := jamf.NewDomainClient(testServer.URL, "mock", "fake-username", "mock-password-cool", nil)
+	assert.Nil(t, err)
+	req, err := http.NewRequestWithContext(context.Background(), "GET", j.NameEndpoint("test"), nil)
+	assert.Nil(t, err)
+	_, err = jamf.MakeAPIrequest(j, req, &MockResponse{})
+	assert.NotNil(t, err)
+	assert.Len(t, attempts, 1)
+
+	attempts = []string{}
+	j, err = jamf.NewDomainClient(testServer.URL, "mock", "fake-username", "mock-password-cool", jamf.RetryingHTTPClient())
+	assert.Nil(t, err)
+
+	// the body is sent again with every attempt
+	req, err = http.NewRequestWithContext(context.Background(), "POST", j.NameEndpoint("test"), strings.NewReader("<mock/>"))
+	assert.Nil(t, err)
+	res := &MockResponse{}
+	_, err = jamf.MakeAPIrequest(j, req, res)
+	assert.Nil(t, err)
+	assert.Equal(t, "OK", res.Status)
+	assert.Equal(t, []string{"POST <mock/>", "POST <mock/>", "POST <mock/>"}, attempts)
+
+	// a POST may have been processed by Jamf behind a bad gateway so it is not retried
+	attempts = []string{}
+	req, err = http.NewRequestWithContext(context.Background(), "POST", j.NameEndpoint("gateway"), strings.NewReader("<mock/>"))
+	assert.Nil(t, err)
+	_, err = jamf.MakeAPIrequest(j, req, res)
+	assert.NotNil(t, err)
+	assert.Len(t, attempts, 1)
+
+	// retries stop once the context is done
+	transport := jamf.NewRetryTransport(nil)
+	transport.Backoff = time.Hour
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	attempts = []string{}
+	req, err = http.NewRequestWithContext(ctx, "GET", j.NameEndpoint("gateway"), nil)
+	assert.Nil(t, err)
+	_, err = transport.RoundTrip(req)
+	assert.Equal(t, context.DeadlineExceeded, err)
+	assert.Len(t, attempts, 1)
+}
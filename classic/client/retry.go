@@ -0,0 +1,105 @@
+// Unless explicitly stated otherwise all files in this repository are licensed under the Apache-2.0
+// This product includes software developed at Datadog (https://www.datadoghq.com/). Copyright 2020 Datadog, Inc.
+
+package client
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+)
+
+const (
+	// DefaultMaxRetries is the number of times a request is retried by the default HTTP client
+	DefaultMaxRetries = 3
+	// DefaultRetryBackoff is the wait before the first retry, it doubles with every attempt
+	DefaultRetryBackoff = time.Second
+	// DefaultRetryTimeout is the timeout of RetryingHTTPClient, it leaves a minute for each
+	// attempt and room for the waits between them
+	DefaultRetryTimeout = (DefaultMaxRetries+1)*time.Minute + DefaultMaxRetries*maxRetryAfter
+	// maxRetryAfter caps the wait requested by a Retry-After header
+	maxRetryAfter = time.Minute
+)
+
+// RetryTransport retries requests Jamf rejected because it was busy. 429 and 503 responses are
+// retried for every method as the request was not processed, 502 and 504 responses and
+// connection errors are only retried for idempotent methods. A Retry-After header in seconds
+// is honoured over the backoff. The Jamf Pro client uses it by default, see RetryingHTTPClient
+type RetryTransport struct {
+	Base       http.RoundTripper
+	MaxRetries int
+	Backoff    time.Duration
+}
+
+// NewRetryTransport wraps the base transport, http.DefaultTransport is used when base is nil
+func NewRetryTransport(base http.RoundTripper) *RetryTransport {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	return &RetryTransport{Base: base, MaxRetries: DefaultMaxRetries, Backoff: DefaultRetryBackoff}
+}
+
+// RoundTrip sends the request retrying it until it succeeds, the retries are exhausted or
+// the request context is done
+func (t *RetryTransport) RoundTrip(r *http.Request) (*http.Response, error) {
+	backoff := t.Backoff
+	for attempt := 0; ; attempt++ {
+		res, err := t.Base.RoundTrip(r)
+		if attempt >= t.MaxRetries || !retryable(r, res, err) {
+			return res, err
+		}
+
+		// the body has been consumed so it must be rebuilt before the request is sent again
+		if r.Body != nil && r.Body != http.NoBody {
+			if r.GetBody == nil {
+				return res, err
+			}
+			body, bodyErr := r.GetBody()
+			if bodyErr != nil {
+				return res, err
+			}
+			r = r.Clone(r.Context())
+			r.Body = body
+		}
+
+		wait := backoff
+		if res != nil {
+			if seconds, parseErr := strconv.Atoi(res.Header.Get("Retry-After")); parseErr == nil && seconds >= 0 {
+				wait = time.Duration(seconds) * time.Second
+				if wait > maxRetryAfter {
+					wait = maxRetryAfter
+				}
+			}
+			res.Body.Close()
+		}
+		backoff *= 2
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-r.Context().Done():
+			timer.Stop()
+			return nil, r.Context().Err()
+		case <-timer.C:
+		}
+	}
+}
+
+func retryable(r *http.Request, res *http.Response, err error) bool {
+	if r.Context().Err() != nil {
+		return false
+	}
+
+	idempotent := r.Method == "GET" || r.Method == "HEAD" || r.Method == "PUT" || r.Method == "DELETE"
+	if err != nil {
+		return idempotent
+	}
+
+	switch res.StatusCode {
+	case http.StatusTooManyRequests, http.StatusServiceUnavailable:
+		return true
+	case http.StatusBadGateway, http.StatusGatewayTimeout:
+		return idempotent
+	default:
+		return false
+	}
+}
@@ -34,9 +34,34 @@ type ComputerExtensionAttribute struct {
 
 // ComputerExtensionAttrInputType represents an input type for a computer extension attribute in Jamf
 type ComputerExtensionAttrInputType struct {
-	Type     string `json:"type,omitempty" xml:"type,omitempty"`
-	Platform string `json:"platform,omitempty" xml:"platform,omitempty"`
-	Script   string `json:"script,omitempty" xml:"script,omitempty"`
+	Type             string       `json:"type,omitempty" xml:"type,omitempty"`
+	Platform         string       `json:"platform,omitempty" xml:"platform,omitempty"`
+	Script           string       `json:"script,omitempty" xml:"script,omitempty"`
+	PopupChoices     PopupChoices `json:"popup_choices,omitempty" xml:"popup_choices,omitempty"`
+	AttributeMapping string       `json:"attribute_mapping,omitempty" xml:"attribute_mapping,omitempty"`
+}
+
+// PopupChoices holds the choices for a pop-up menu extension attribute. Jamf represents them as a
+// list in JSON and as <popup_choices><choice>...</choice></popup_choices> in XML
+type PopupChoices []string
+
+type popupChoicesXML struct {
+	Choices []string `xml:"choice"`
+}
+
+// MarshalXML encodes each choice as a <choice> element
+func (p PopupChoices) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	return e.EncodeElement(popupChoicesXML{Choices: p}, start)
+}
+
+// UnmarshalXML decodes the <choice> elements of a <popup_choices> element
+func (p *PopupChoices) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	choices := popupChoicesXML{}
+	if err := d.DecodeElement(&choices, &start); err != nil {
+		return err
+	}
+	*p = choices.Choices
+	return nil
 }
 
 // ValidateComputerExtensionAttribute orchestrates computer extension content validation
@@ -94,15 +119,55 @@ func (ce *ComputerExtensionAttribute) ValidateReconDisplay() error {
 
 // ValidateInputType will validate that a computer extension attribute's input type is valid
 func (it *ComputerExtensionAttrInputType) ValidateInputType() error {
+	if err := it.ValidatePlatform(); err != nil {
+		return err
+	}
+
 	switch it.Type {
-	case "", "Text Field", "LDAP Mapping", "Pop-up Menu":
+	case "", "Text Field":
 		return nil
 	case "script":
 		if it.Script == "" {
 			return fmt.Errorf("script contents must be provided for input type %s", it.Type)
 		}
 		return nil
+	case "Pop-up Menu":
+		return it.ValidatePopupChoices()
+	case "LDAP Mapping":
+		if strings.TrimSpace(it.AttributeMapping) == "" {
+			return fmt.Errorf("attribute mapping must be provided for input type %s", it.Type)
+		}
+		return nil
 	default:
 		return fmt.Errorf("%s is not a valid computer extension attribute input type must be of type [ script, Text Field, LDAP Mapping, Pop-up Menu ]", it.Type)
 	}
 }
+
+// ValidatePlatform will validate that a computer extension attribute's platform is valid
+func (it *ComputerExtensionAttrInputType) ValidatePlatform() error {
+	switch strings.ToLower(it.Platform) {
+	case "", "mac", "windows":
+		return nil
+	default:
+		return fmt.Errorf("%s is not a valid computer extension attribute platform must be of type [ Mac, Windows ]", it.Platform)
+	}
+}
+
+// ValidatePopupChoices will validate that a pop-up menu has at least one choice and no blank or duplicate choices
+func (it *ComputerExtensionAttrInputType) ValidatePopupChoices() error {
+	if len(it.PopupChoices) == 0 {
+		return fmt.Errorf("at least one pop-up choice must be provided for input type %s", it.Type)
+	}
+
+	seen := map[string]bool{}
+	for _, c := range it.PopupChoices {
+		if strings.TrimSpace(c) == "" {
+			return fmt.Errorf("pop-up choices for input type %s can not be blank", it.Type)
+		}
+		if seen[c] {
+			return fmt.Errorf("%s is a duplicate pop-up choice for input type %s", c, it.Type)
+		}
+		seen[c] = true
+	}
+	return nil
+}
@@ -0,0 +1,366 @@
+// Unless explicitly stated otherwise all files in this repository are licensed under the Apache-2.0
+// This product includes software developed at Datadog (https://www.datadoghq.com/). Copyright 2020 Datadog, Inc.
+
+package computerextensionattributes
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/trustero/jamf-api-client-go/internal/filesync"
+	"github.com/trustero/jamf-api-client-go/internal/shebang"
+	"gopkg.in/yaml.v3"
+)
+
+// ScriptInputType is the input type Jamf uses for extension attributes populated by a script
+const ScriptInputType = "script"
+
+// scriptExtensions are the script file extensions read from a script directory, they match
+// the extensions ExportScripts writes
+var scriptExtensions = shebang.Extensions()
+
+// sidecarExtensions are the file extensions accepted for a script's metadata sidecar
+var sidecarExtensions = []string{".yaml", ".yml"}
+
+// ScriptMetadata is the sidecar YAML stored next to an extension attribute script on disk
+type ScriptMetadata struct {
+	// Name overrides the extension attribute name which defaults to the script file name
+	Name             string `yaml:"name,omitempty"`
+	Description      string `yaml:"description,omitempty"`
+	DataType         string `yaml:"data_type"`
+	InventoryDisplay string `yaml:"inventory_display"`
+	ReconDisplay     string `yaml:"recon_display"`
+	Enabled          bool   `yaml:"enabled"`
+	Platform         string `yaml:"platform,omitempty"`
+}
+
+// SyncAction describes what a sync will do to a single extension attribute
+type SyncAction = filesync.Action
+
+// Actions a sync plan can take
+const (
+	SyncCreate    = filesync.Create
+	SyncUpdate    = filesync.Update
+	SyncDelete    = filesync.Delete
+	SyncUnchanged = filesync.Unchanged
+)
+
+// SyncOptions holds the settings used when syncing a script directory with Jamf. Prune deletes
+// script extension attributes in Jamf that have no script on disk
+type SyncOptions = filesync.Options
+
+// SyncChange is a single planned change to an extension attribute
+type SyncChange struct {
+	filesync.Change
+	Desired *ComputerExtensionAttribute
+	Current *ComputerExtensionAttribute
+}
+
+// SyncPlan holds every change needed to bring Jamf in line with a script directory
+type SyncPlan = filesync.Plan[*SyncChange]
+
+// LoadScriptDirectory reads every extension attribute script in a directory along with its
+// sidecar YAML and validates the resulting extension attributes
+func LoadScriptDirectory(dir string) ([]*ComputerExtensionAttribute, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, errors.Wrapf(err, "unable to read extension attribute script directory: %s", dir)
+	}
+
+	var attrs []*ComputerExtensionAttribute
+	var problems []string
+	seen := map[string]string{}
+	for _, e := range entries {
+		if e.IsDir() || !hasExtension(e.Name(), scriptExtensions) {
+			continue
+		}
+
+		path := filepath.Join(dir, e.Name())
+		attr, err := loadScript(path)
+		if err != nil {
+			problems = append(problems, err.Error())
+			continue
+		}
+
+		if other, ok := seen[attr.Name]; ok {
+			problems = append(problems, fmt.Sprintf("%s: duplicate extension attribute name %q also used by %s", path, attr.Name, other))
+			continue
+		}
+		seen[attr.Name] = path
+
+		if err := ValidateComputerExtensionAttribute(attr); err != nil {
+			problems = append(problems, fmt.Sprintf("%s: %s", path, err.Error()))
+			continue
+		}
+		attrs = append(attrs, attr)
+	}
+
+	if len(problems) > 0 {
+		return nil, fmt.Errorf("invalid extension attribute scripts in %s: %s", dir, strings.Join(problems, "; "))
+	}
+
+	sort.Slice(attrs, func(a, b int) bool { return attrs[a].Name < attrs[b].Name })
+	return attrs, nil
+}
+
+func loadScript(path string) (*ComputerExtensionAttribute, error) {
+	script, err := os.ReadFile(path)
+	if err != nil {
+		return nil, errors.Wrapf(err, "unable to read extension attribute script: %s", path)
+	}
+
+	base := strings.TrimSuffix(path, filepath.Ext(path))
+	meta := &ScriptMetadata{}
+	found := false
+	for _, ext := range sidecarExtensions {
+		data, err := os.ReadFile(base + ext)
+		if os.IsNotExist(err) {
+			continue
+		}
+		if err != nil {
+			return nil, errors.Wrapf(err, "unable to read extension attribute metadata: %s", base+ext)
+		}
+		if err := yaml.Unmarshal(data, meta); err != nil {
+			return nil, errors.Wrapf(err, "unable to parse extension attribute metadata: %s", base+ext)
+		}
+		found = true
+		break
+	}
+	if !found {
+		return nil, fmt.Errorf("%s: missing metadata sidecar %s.yaml", path, filepath.Base(base))
+	}
+
+	name := meta.Name
+	if name == "" {
+		name = filepath.Base(base)
+	}
+
+	platform := meta.Platform
+	if platform == "" {
+		platform = "Mac"
+	}
+
+	return &ComputerExtensionAttribute{
+		Name:             name,
+		Enabled:          meta.Enabled,
+		Description:      meta.Description,
+		DataType:         meta.DataType,
+		InventoryDisplay: meta.InventoryDisplay,
+		ReconDisplay:     meta.ReconDisplay,
+		InputType: &ComputerExtensionAttrInputType{
+			Type:     ScriptInputType,
+			Platform: platform,
+			Script:   string(script),
+		},
+	}, nil
+}
+
+// PlanScriptSync compares a directory of extension attribute scripts with Jamf and returns the
+// changes needed to make Jamf match the directory. Nothing is modified in Jamf
+func (j *Service) PlanScriptSync(dir string, opts *SyncOptions) (*SyncPlan, error) {
+	if opts == nil {
+		opts = &SyncOptions{}
+	}
+
+	desired, err := LoadScriptDirectory(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	existing, err := j.ComputerExtensionAttributes()
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to plan extension attribute script sync")
+	}
+
+	ids := map[string]int{}
+	for _, e := range existing {
+		ids[e.Name] = e.ID
+	}
+
+	plan := filesync.NewPlan[*SyncChange]("computer extension attribute")
+	wanted := map[string]bool{}
+	for _, d := range desired {
+		wanted[d.Name] = true
+		id, ok := ids[d.Name]
+		if !ok {
+			plan.Changes = append(plan.Changes, &SyncChange{
+				Change:  filesync.Change{Action: SyncCreate, Name: d.Name, Diff: diffAttributes(nil, d)},
+				Desired: d,
+			})
+			continue
+		}
+
+		current, err := j.attributeDetails(id)
+		if err != nil {
+			return nil, errors.Wrap(err, "unable to plan extension attribute script sync")
+		}
+
+		change := &SyncChange{Change: filesync.Change{Action: SyncUnchanged, Name: d.Name, ID: id}, Desired: d, Current: current}
+		if diff := diffAttributes(current, d); diff != "" {
+			change.Action = SyncUpdate
+			change.Diff = diff
+		}
+		plan.Changes = append(plan.Changes, change)
+	}
+
+	if opts.Prune {
+		for _, e := range existing {
+			if wanted[e.Name] {
+				continue
+			}
+
+			current, err := j.attributeDetails(e.ID)
+			if err != nil {
+				return nil, errors.Wrap(err, "unable to plan extension attribute script sync")
+			}
+
+			// only script extension attributes are managed on disk
+			if current.InputType == nil || current.InputType.Type != ScriptInputType {
+				continue
+			}
+
+			plan.Changes = append(plan.Changes, &SyncChange{
+				Change:  filesync.Change{Action: SyncDelete, Name: e.Name, ID: e.ID, Diff: diffAttributes(current, nil)},
+				Current: current,
+			})
+		}
+	}
+
+	return plan, nil
+}
+
+// ApplyScriptSync applies every pending change in a plan. All changes are attempted and the
+// outcome of each is recorded on the change, an error is returned if any change failed
+func (j *Service) ApplyScriptSync(plan *SyncPlan) error {
+	return plan.Apply(j.applyChange)
+}
+
+// SyncScripts plans the changes needed to make Jamf match a directory of extension attribute
+// scripts, writes the plan diff to the Output provided and applies it unless DryRun is set
+func (j *Service) SyncScripts(dir string, opts *SyncOptions) (*SyncPlan, error) {
+	plan, err := j.PlanScriptSync(dir, opts)
+	if err != nil {
+		return nil, err
+	}
+	return plan, plan.Run(opts, j.applyChange)
+}
+
+func (j *Service) applyChange(c *SyncChange) (err error) {
+	switch c.Action {
+	case SyncCreate:
+		_, err = j.CreateComputerExtensionAttribute(c.Desired)
+	case SyncUpdate:
+		_, err = j.UpdateComputerExtensionAttribue(c.ID, c.Desired)
+	case SyncDelete:
+		_, err = j.DeleteComputerExtensionAttribute(c.ID)
+	}
+	return err
+}
+
+// ExportScripts writes every script extension attribute in Jamf to a directory using the same
+// layout read by SyncScripts and returns the paths of the scripts written
+func (j *Service) ExportScripts(dir string) ([]string, error) {
+	existing, err := j.ComputerExtensionAttributes()
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to export extension attribute scripts")
+	}
+
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, errors.Wrapf(err, "unable to create extension attribute script directory: %s", dir)
+	}
+
+	var written []string
+	for _, e := range existing {
+		attr, err := j.attributeDetails(e.ID)
+		if err != nil {
+			return written, errors.Wrap(err, "unable to export extension attribute scripts")
+		}
+
+		if attr.InputType == nil || attr.InputType.Type != ScriptInputType {
+			continue
+		}
+
+		base := filepath.Join(dir, ScriptFileName(attr.Name))
+		path := base + shebang.Extension(attr.InputType.Script)
+		if err := os.WriteFile(path, []byte(filesync.Normalize(attr.InputType.Script)), 0o755); err != nil {
+			return written, errors.Wrapf(err, "unable to write extension attribute script: %s", path)
+		}
+
+		meta, err := yaml.Marshal(&ScriptMetadata{
+			Name:             attr.Name,
+			Description:      attr.Description,
+			DataType:         attr.DataType,
+			InventoryDisplay: attr.InventoryDisplay,
+			ReconDisplay:     attr.ReconDisplay,
+			Enabled:          attr.Enabled,
+			Platform:         attr.InputType.Platform,
+		})
+		if err != nil {
+			return written, errors.Wrapf(err, "unable to build extension attribute metadata: %s", attr.Name)
+		}
+
+		if err := os.WriteFile(base+".yaml", meta, 0o644); err != nil {
+			return written, errors.Wrapf(err, "unable to write extension attribute metadata: %s.yaml", base)
+		}
+		written = append(written, path)
+	}
+	return written, nil
+}
+
+// ScriptFileName returns the file name without extension used to store an extension attribute on disk
+func ScriptFileName(name string) string {
+	return filesync.FileName(name)
+}
+
+func (j *Service) attributeDetails(id int) (*ComputerExtensionAttribute, error) {
+	details, err := j.ComputerExtensionAttributeDetails(id)
+	if err != nil {
+		return nil, err
+	}
+	if details.Details == nil {
+		return nil, fmt.Errorf("no computer extension attribute contents returned for Id: %d", id)
+	}
+	return details.Details, nil
+}
+
+// diffAttributes returns a unified diff between the extension attribute in Jamf and the one on disk
+// or an empty string when they match. Either side may be nil for a create or delete
+func diffAttributes(current, desired *ComputerExtensionAttribute) string {
+	return filesync.Diff(attributeLines(current), attributeLines(desired))
+}
+
+// attributeLines flattens the fields managed on disk into lines so metadata and
+// script body changes show up in a single diff
+func attributeLines(attr *ComputerExtensionAttribute) []string {
+	if attr == nil {
+		return nil
+	}
+
+	platform, script := "", ""
+	if attr.InputType != nil {
+		platform, script = attr.InputType.Platform, attr.InputType.Script
+	}
+
+	lines := []string{
+		fmt.Sprintf("# description: %s\n", attr.Description),
+		fmt.Sprintf("# data_type: %s\n", strings.ToLower(attr.DataType)),
+		fmt.Sprintf("# inventory_display: %s\n", strings.ToLower(attr.InventoryDisplay)),
+		fmt.Sprintf("# recon_display: %s\n", strings.ToLower(attr.ReconDisplay)),
+		fmt.Sprintf("# enabled: %t\n", attr.Enabled),
+		fmt.Sprintf("# platform: %s\n", platform),
+	}
+	return append(lines, filesync.SplitLines(filesync.Normalize(script))...)
+}
+
+func hasExtension(name string, exts []string) bool {
+	for _, ext := range exts {
+		if strings.EqualFold(filepath.Ext(name), ext) {
+			return true
+		}
+	}
+	return false
+}
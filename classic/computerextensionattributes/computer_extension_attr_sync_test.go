@@ -0,0 +1,240 @@
+// Unless explicitly stated otherwise all files in this repository are licensed under the Apache-2.0
+// This product includes software developed at Datadog (https://www.datadoghq.com/). Copyright 2020 Datadog, Inc.
+
+package computerextensionattributes_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	jamf "github.com/trustero/jamf-api-client-go/classic/computerextensionattributes"
+)
+
+type syncMock struct {
+	mu    sync.Mutex
+	attrs map[int]*jamf.ComputerExtensionAttribute
+	calls []string
+}
+
+func newSyncMock() *syncMock {
+	return &syncMock{attrs: map[int]*jamf.ComputerExtensionAttribute{
+		33: {
+			ID:               33,
+			Name:             "Check Firewall",
+			Enabled:          true,
+			DataType:         "String",
+			InventoryDisplay: "Extension Attributes",
+			ReconDisplay:     "Extension Attributes",
+			InputType: &jamf.ComputerExtensionAttrInputType{
+				Type:     "script",
+				Platform: "Mac",
+				Script:   "#!/bin/bash\r\necho \"<result>On</result>\"",
+			},
+		},
+		99: {
+			ID:               99,
+			Name:             "Python Version",
+			Enabled:          true,
+			DataType:         "String",
+			InventoryDisplay: "Extension Attributes",
+			ReconDisplay:     "Extension Attributes",
+			InputType: &jamf.ComputerExtensionAttrInputType{
+				Type:     "script",
+				Platform: "Mac",
+				Script:   "#!/usr/bin/env python3\nprint('<result>3</result>')\n",
+			},
+		},
+		103: {
+			ID:       103,
+			Name:     "Team",
+			DataType: "String",
+			InputType: &jamf.ComputerExtensionAttrInputType{
+				Type: "Text Field",
+			},
+		},
+	}}
+}
+
+func (m *syncMock) server() *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		m.mu.Lock()
+		defer m.mu.Unlock()
+
+		if r.RequestURI == COMPUTER_EXT_ATTR_API_BASE_ENDPOINT {
+			list := &jamf.ComputerExtensionAttributes{}
+			for _, id := range []int{33, 99, 103} {
+				list.List = append(list.List, jamf.ComputerExtensionAttribute{ID: id, Name: m.attrs[id].Name})
+			}
+			json.NewEncoder(w).Encode(list)
+			return
+		}
+
+		var id int
+		fmt.Sscanf(r.RequestURI, COMPUTER_EXT_ATTR_API_BASE_ENDPOINT+"/id/%d", &id)
+		if r.Method != "GET" {
+			m.calls = append(m.calls, fmt.Sprintf("%s %d", r.Method, id))
+			fmt.Fprintf(w, `{"id": %d}`, id)
+			return
+		}
+
+		attr, ok := m.attrs[id]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		json.NewEncoder(w).Encode(&jamf.ComputerExtensionAttributeDetails{Details: attr})
+	}))
+}
+
+func writeScript(t *testing.T, dir, name, script, meta string) {
+	assert.Nil(t, os.WriteFile(filepath.Join(dir, name), []byte(script), 0o644))
+	base := name[:len(name)-len(filepath.Ext(name))]
+	assert.Nil(t, os.WriteFile(filepath.Join(dir, base+".yaml"), []byte(meta), 0o644))
+}
+
+const testScriptMeta = `
+name: %s
+data_type: String
+inventory_display: Extension Attributes
+recon_display: Extension Attributes
+enabled: true
+`
+
+func TestLoadScriptDirectory(t *testing.T) {
+	dir := t.TempDir()
+	writeScript(t, dir, "Check_Firewall.sh", "#!/bin/bash\necho \"<result>On</result>\"\n", fmt.Sprintf(testScriptMeta, "Check Firewall"))
+	writeScript(t, dir, "Uptime.sh", "#!/bin/bash\necho \"<result>1</result>\"\n", "data_type: Integer\nenabled: false\n")
+	assert.Nil(t, os.WriteFile(filepath.Join(dir, "README.md"), []byte("ignored"), 0o644))
+
+	attrs, err := jamf.LoadScriptDirectory(dir)
+	assert.Nil(t, err)
+	assert.Equal(t, 2, len(attrs))
+	assert.Equal(t, "Check Firewall", attrs[0].Name)
+	assert.Equal(t, "script", attrs[0].InputType.Type)
+	assert.Equal(t, "Mac", attrs[0].InputType.Platform)
+	assert.True(t, attrs[0].Enabled)
+	assert.Equal(t, "Uptime", attrs[1].Name)
+	assert.Equal(t, "Integer", attrs[1].DataType)
+	assert.False(t, attrs[1].Enabled)
+}
+
+func TestLoadScriptDirectoryInvalid(t *testing.T) {
+	dir := t.TempDir()
+	writeScript(t, dir, "Bad.sh", "#!/bin/bash\n", "data_type: Float\n")
+	assert.Nil(t, os.WriteFile(filepath.Join(dir, "NoSidecar.py"), []byte("#!/usr/bin/env python3\n"), 0o644))
+
+	_, err := jamf.LoadScriptDirectory(dir)
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "Float is not a valid computer extension attribute data type")
+	assert.Contains(t, err.Error(), "missing metadata sidecar NoSidecar.yaml")
+}
+
+func TestSyncScriptsDryRun(t *testing.T) {
+	mock := newSyncMock()
+	testServer := mock.server()
+	defer testServer.Close()
+	j, err := jamf.NewService(testServer.URL, "fake-username", "mock-password-cool", nil)
+	assert.Nil(t, err)
+
+	dir := t.TempDir()
+	// same script with unix line endings is unchanged
+	writeScript(t, dir, "Check_Firewall.sh", "#!/bin/bash\necho \"<result>On</result>\"\n", fmt.Sprintf(testScriptMeta, "Check Firewall"))
+	writeScript(t, dir, "Python_Version.py", "#!/usr/bin/env python3\nprint('<result>2</result>')\n", fmt.Sprintf(testScriptMeta, "Python Version"))
+	writeScript(t, dir, "Uptime.sh", "#!/bin/bash\necho \"<result>1</result>\"\n", "data_type: Integer\nenabled: true\n")
+
+	out := &bytes.Buffer{}
+	plan, err := j.SyncScripts(dir, &jamf.SyncOptions{DryRun: true, Prune: true, Output: out})
+	assert.Nil(t, err)
+	assert.Empty(t, mock.calls)
+
+	actions := map[string]jamf.SyncAction{}
+	for _, c := range plan.Changes {
+		actions[c.Name] = c.Action
+	}
+	assert.Equal(t, map[string]jamf.SyncAction{
+		"Check Firewall": jamf.SyncUnchanged,
+		"Python Version": jamf.SyncUpdate,
+		"Uptime":         jamf.SyncCreate,
+	}, actions)
+	assert.Equal(t, 2, len(plan.Pending()))
+
+	assert.Contains(t, out.String(), "update computer extension attribute \"Python Version\"")
+	assert.Contains(t, out.String(), "-print('<result>3</result>')")
+	assert.Contains(t, out.String(), "+print('<result>2</result>')")
+	assert.Contains(t, out.String(), "create computer extension attribute \"Uptime\"")
+	assert.NotContains(t, out.String(), "Check Firewall")
+}
+
+func TestSyncScriptsApply(t *testing.T) {
+	mock := newSyncMock()
+	testServer := mock.server()
+	defer testServer.Close()
+	j, err := jamf.NewService(testServer.URL, "fake-username", "mock-password-cool", nil)
+	assert.Nil(t, err)
+
+	dir := t.TempDir()
+	writeScript(t, dir, "Python_Version.py", "#!/usr/bin/env python3\nprint('<result>2</result>')\n", fmt.Sprintf(testScriptMeta, "Python Version"))
+	writeScript(t, dir, "Uptime.sh", "#!/bin/bash\necho \"<result>1</result>\"\n", "data_type: Integer\nenabled: true\n")
+
+	plan, err := j.SyncScripts(dir, &jamf.SyncOptions{Prune: true})
+	assert.Nil(t, err)
+	assert.Equal(t, 3, len(plan.Pending()))
+	// Team is not a script extension attribute so it is never pruned
+	assert.ElementsMatch(t, []string{"PUT 99", "POST -1", "DELETE 33"}, mock.calls)
+}
+
+func TestExportScripts(t *testing.T) {
+	mock := newSyncMock()
+	testServer := mock.server()
+	defer testServer.Close()
+	j, err := jamf.NewService(testServer.URL, "fake-username", "mock-password-cool", nil)
+	assert.Nil(t, err)
+
+	dir := t.TempDir()
+	written, err := j.ExportScripts(dir)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{filepath.Join(dir, "Check_Firewall.sh"), filepath.Join(dir, "Python_Version.py")}, written)
+
+	script, err := os.ReadFile(filepath.Join(dir, "Check_Firewall.sh"))
+	assert.Nil(t, err)
+	assert.Equal(t, "#!/bin/bash\necho \"<result>On</result>\"\n", string(script))
+
+	// an export read back in matches Jamf
+	plan, err := j.PlanScriptSync(dir, nil)
+	assert.Nil(t, err)
+	assert.Equal(t, 2, len(plan.Changes))
+	assert.Empty(t, plan.Pending())
+}
+
+func TestExportScriptsZsh(t *testing.T) {
+	mock := newSyncMock()
+	mock.attrs[99].Name = "Shell Version"
+	mock.attrs[99].InputType.Script = "#!/bin/zsh\necho \"<result>$ZSH_VERSION</result>\"\n"
+	testServer := mock.server()
+	defer testServer.Close()
+	j, err := jamf.NewService(testServer.URL, "fake-username", "mock-password-cool", nil)
+	assert.Nil(t, err)
+
+	dir := t.TempDir()
+	written, err := j.ExportScripts(dir)
+	assert.Nil(t, err)
+	assert.Contains(t, written, filepath.Join(dir, "Shell_Version.zsh"))
+
+	attrs, err := jamf.LoadScriptDirectory(dir)
+	assert.Nil(t, err)
+	assert.Equal(t, 2, len(attrs))
+	assert.Equal(t, "Shell Version", attrs[1].Name)
+
+	// the zsh script is found on disk so a pruning sync leaves it in Jamf
+	plan, err := j.PlanScriptSync(dir, &jamf.SyncOptions{Prune: true})
+	assert.Nil(t, err)
+	assert.Empty(t, plan.Pending())
+}
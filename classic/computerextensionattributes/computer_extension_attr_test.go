@@ -112,100 +112,100 @@ func TestQueryAllComputerExtAttrs(t *testing.T) {
 	assert.Equal(t, "Is Logged In User Admin", compExtAttrs[1].Name)
 }
 
-//func TestQuerySpecificComputerExtAttrByName(t *testing.T) {
-//	testServer := computerExtAttrResponseMocks(t)
-//	defer testServer.Close()
-//	j, err := jamf.NewService(testServer.URL, "fake-username", "mock-password-cool", nil)
-//	assert.Nil(t, err)
-//	cea, err := j.ComputerExtensionAttributeDetails("Check Firewall")
-//	assert.Nil(t, err)
-//	assert.NotNil(t, cea)
-//	assert.Equal(t, 33, cea.Details.Id)
-//	assert.Equal(t, "Check Firewall", cea.Details.Name)
-//	assert.True(t, cea.Details.Enabled)
-//	assert.Equal(t, "Checks to ensure firewall is enabled on client", cea.Details.Description)
-//	assert.Equal(t, "String", cea.Details.DataType)
-//	assert.Empty(t, cea.Details.InputType.Type)
-//	assert.Equal(t, "Operating System", cea.Details.InventoryDisplay)
-//	assert.Equal(t, "Extension Attributes", cea.Details.ReconDisplay)
-//}
-
-//func TestQuerySpecificComputerExtAttrByID(t *testing.T) {
-//	testServer := computerExtAttrResponseMocks(t)
-//	defer testServer.Close()
-//	j, err := jamf.NewService(testServer.URL, "fake-username", "mock-password-cool", nil)
-//	assert.Nil(t, err)
-//	cea, err := j.ComputerExtensionAttributeDetails(33)
-//	assert.Nil(t, err)
-//	assert.NotNil(t, cea)
-//	assert.Equal(t, 33, cea.Details.Id)
-//	assert.Equal(t, "Check Firewall", cea.Details.Name)
-//	assert.True(t, cea.Details.Enabled)
-//	assert.Equal(t, "Checks to ensure firewall is enabled on client", cea.Details.Description)
-//	assert.Equal(t, "String", cea.Details.DataType)
-//	assert.Empty(t, cea.Details.InputType.Type)
-//	assert.Equal(t, "Operating System", cea.Details.InventoryDisplay)
-//	assert.Equal(t, "Extension Attributes", cea.Details.ReconDisplay)
-//}
-
-//func TestUpdateComputerExtAttr(t *testing.T) {
-//	testServer := computerExtAttrResponseMocks(t)
-//	defer testServer.Close()
-//	j, err := jamf.NewService(testServer.URL, "fake-username", "mock-password-cool", nil)
-//	assert.Nil(t, err)
-//
-//	update := &jamf.ComputerExtensionAttribute{
-//		Description: "Updated description",
-//		Enabled:     false,
-//	}
-//
-//	updatedComputerExtAttr, err := j.UpdateComputerExtensionAttribue(33, update)
-//	assert.Nil(t, err)
-//	assert.Equal(t, "Updated description", updatedComputerExtAttr.Description)
-//	assert.False(t, updatedComputerExtAttr.Enabled)
-//}
-
-//func TestCreateComputerExtAttr(t *testing.T) {
-//	testServer := computerExtAttrResponseMocks(t)
-//	defer testServer.Close()
-//	j, err := jamf.NewService(testServer.URL, "fake-username", "mock-password-cool", nil)
-//	assert.Nil(t, err)
-//
-//	newCompExtAttr := &jamf.ComputerExtensionAttribute{}
-//	_, err = j.CreateComputerExtensionAttribute(newCompExtAttr)
-//	assert.NotNil(t, err)
-//	assert.Contains(t, err.Error(), "Name required for new computer extension attribute")
-//
-//	newCompExtAttr = &jamf.ComputerExtensionAttribute{
-//		Name:        "Testing Ext Attr",
-//		Description: "This is a test description",
-//		Enabled:     true,
-//		DataType:    "String",
-//		InputType: &jamf.ComputerExtensionAttrInputType{
-//			Type:     "script",
-//			Platform: "Mac",
-//			Script:   "echo \"Hello World, I am a unit test\"",
-//		},
-//	}
-//	cea, err := j.CreateComputerExtensionAttribute(newCompExtAttr)
-//	assert.Nil(t, err)
-//	assert.Equal(t, "Testing Ext Attr", cea.Name)
-//	assert.Equal(t, "This is a test description", cea.Description)
-//	assert.True(t, cea.Enabled)
-//	assert.Equal(t, "Mac", cea.InputType.Platform)
-//	assert.Equal(t, "script", cea.InputType.Type)
-//	assert.Equal(t, "echo \"Hello World, I am a unit test\"", cea.InputType.Script)
-//}
-
-//func TestDeleteComputerExtAttr(t *testing.T) {
-//	testServer := computerExtAttrResponseMocks(t)
-//	defer testServer.Close()
-//	j, err := jamf.NewService(testServer.URL, "fake-username", "mock-password-cool", nil)
-//	assert.Nil(t, err)
-//	removed, err := j.DeleteComputerExtensionAttribute(33)
-//	assert.Nil(t, err)
-//	assert.Equal(t, 33, removed.Id)
-//}
+func TestQuerySpecificComputerExtAttrByName(t *testing.T) {
+	testServer := computerExtAttrResponseMocks(t)
+	defer testServer.Close()
+	j, err := jamf.NewService(testServer.URL, "fake-username", "mock-password-cool", nil)
+	assert.Nil(t, err)
+	cea, err := j.ComputerExtensionAttributeDetails("Check Firewall")
+	assert.Nil(t, err)
+	assert.NotNil(t, cea)
+	assert.Equal(t, 33, cea.Details.ID)
+	assert.Equal(t, "Check Firewall", cea.Details.Name)
+	assert.True(t, cea.Details.Enabled)
+	assert.Equal(t, "Checks to ensure firewall is enabled on client", cea.Details.Description)
+	assert.Equal(t, "String", cea.Details.DataType)
+	assert.Empty(t, cea.Details.InputType.Type)
+	assert.Equal(t, "Operating System", cea.Details.InventoryDisplay)
+	assert.Equal(t, "Extension Attributes", cea.Details.ReconDisplay)
+}
+
+func TestQuerySpecificComputerExtAttrByID(t *testing.T) {
+	testServer := computerExtAttrResponseMocks(t)
+	defer testServer.Close()
+	j, err := jamf.NewService(testServer.URL, "fake-username", "mock-password-cool", nil)
+	assert.Nil(t, err)
+	cea, err := j.ComputerExtensionAttributeDetails(33)
+	assert.Nil(t, err)
+	assert.NotNil(t, cea)
+	assert.Equal(t, 33, cea.Details.ID)
+	assert.Equal(t, "Check Firewall", cea.Details.Name)
+	assert.True(t, cea.Details.Enabled)
+	assert.Equal(t, "Checks to ensure firewall is enabled on client", cea.Details.Description)
+	assert.Equal(t, "String", cea.Details.DataType)
+	assert.Empty(t, cea.Details.InputType.Type)
+	assert.Equal(t, "Operating System", cea.Details.InventoryDisplay)
+	assert.Equal(t, "Extension Attributes", cea.Details.ReconDisplay)
+}
+
+func TestUpdateComputerExtAttr(t *testing.T) {
+	testServer := computerExtAttrResponseMocks(t)
+	defer testServer.Close()
+	j, err := jamf.NewService(testServer.URL, "fake-username", "mock-password-cool", nil)
+	assert.Nil(t, err)
+
+	update := &jamf.ComputerExtensionAttribute{
+		Description: "Updated description",
+		Enabled:     false,
+	}
+
+	updatedComputerExtAttr, err := j.UpdateComputerExtensionAttribue(33, update)
+	assert.Nil(t, err)
+	assert.Equal(t, "Updated description", updatedComputerExtAttr.Description)
+	assert.False(t, updatedComputerExtAttr.Enabled)
+}
+
+func TestCreateComputerExtAttr(t *testing.T) {
+	testServer := computerExtAttrResponseMocks(t)
+	defer testServer.Close()
+	j, err := jamf.NewService(testServer.URL, "fake-username", "mock-password-cool", nil)
+	assert.Nil(t, err)
+
+	newCompExtAttr := &jamf.ComputerExtensionAttribute{}
+	_, err = j.CreateComputerExtensionAttribute(newCompExtAttr)
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "Name required for new computer extension attribute")
+
+	newCompExtAttr = &jamf.ComputerExtensionAttribute{
+		Name:        "Testing Ext Attr",
+		Description: "This is a test description",
+		Enabled:     true,
+		DataType:    "String",
+		InputType: &jamf.ComputerExtensionAttrInputType{
+			Type:     "script",
+			Platform: "Mac",
+			Script:   "echo \"Hello World, I am a unit test\"",
+		},
+	}
+	cea, err := j.CreateComputerExtensionAttribute(newCompExtAttr)
+	assert.Nil(t, err)
+	assert.Equal(t, "Testing Ext Attr", cea.Name)
+	assert.Equal(t, "This is a test description", cea.Description)
+	assert.True(t, cea.Enabled)
+	assert.Equal(t, "Mac", cea.InputType.Platform)
+	assert.Equal(t, "script", cea.InputType.Type)
+	assert.Equal(t, "echo \"Hello World, I am a unit test\"", cea.InputType.Script)
+}
+
+func TestDeleteComputerExtAttr(t *testing.T) {
+	testServer := computerExtAttrResponseMocks(t)
+	defer testServer.Close()
+	j, err := jamf.NewService(testServer.URL, "fake-username", "mock-password-cool", nil)
+	assert.Nil(t, err)
+	removed, err := j.DeleteComputerExtensionAttribute(33)
+	assert.Nil(t, err)
+	assert.Equal(t, 33, removed.ID)
+}
 
 func TestValidateComputerExtAttrDataTypePass(t *testing.T) {
 	ce := &jamf.ComputerExtensionAttribute{}
@@ -216,15 +216,15 @@ func TestValidateComputerExtAttrDataTypePass(t *testing.T) {
 	}
 }
 
-//func TestValidateComputerExtAttrDataTypeFail(t *testing.T) {
-//	ce := &jamf.ComputerExtensionAttribute{}
-//	for _, dt := range []string{"IDK", "badData", "script", "policy"} {
-//		ce.DataType = dt
-//		err := ce.ValidateDataType()
-//		assert.NotNil(t, err)
-//		assert.Equal(t, fmt.Sprintf("%s is not a valid computer extension attribute data type must be of type [ String, Integer, Date ]", dt), err.Error())
-//	}
-//}
+func TestValidateComputerExtAttrDataTypeFail(t *testing.T) {
+	ce := &jamf.ComputerExtensionAttribute{}
+	for _, dt := range []string{"IDK", "badData", "script", "policy"} {
+		ce.DataType = dt
+		err := ce.ValidateDataType()
+		assert.NotNil(t, err)
+		assert.Equal(t, fmt.Sprintf("%s is not a valid computer extension attribute data type must be of type [ String, Integer, Date ]", dt), err.Error())
+	}
+}
 
 func TestValidateComputerExtAttrInventoryDisplayPass(t *testing.T) {
 	ce := &jamf.ComputerExtensionAttribute{}
@@ -265,7 +265,10 @@ func TestValidateComputerExtAttrReconDisplayFail(t *testing.T) {
 }
 
 func TestValidateComputerExtAttrInputTypePass(t *testing.T) {
-	ce := &jamf.ComputerExtensionAttrInputType{}
+	ce := &jamf.ComputerExtensionAttrInputType{
+		PopupChoices:     []string{"Engineering", "Sales"},
+		AttributeMapping: "department",
+	}
 	for _, dt := range []string{"", "Text Field", "LDAP Mapping", "Pop-up Menu"} {
 		ce.Type = dt
 		err := ce.ValidateInputType()
@@ -298,3 +301,95 @@ func TestValidateComputerExtAttrInputTypeFail(t *testing.T) {
 		assert.Equal(t, fmt.Sprintf("%s is not a valid computer extension attribute input type must be of type [ script, Text Field, LDAP Mapping, Pop-up Menu ]", dt), err.Error())
 	}
 }
+
+func TestValidateComputerExtAttrInputTypePopup(t *testing.T) {
+	ce := &jamf.ComputerExtensionAttrInputType{
+		Type: "Pop-up Menu",
+	}
+
+	// test failure missing choices which are required
+	err := ce.ValidateInputType()
+	assert.NotNil(t, err)
+	assert.Equal(t, "at least one pop-up choice must be provided for input type Pop-up Menu", err.Error())
+
+	ce.PopupChoices = []string{"Engineering", " "}
+	err = ce.ValidateInputType()
+	assert.NotNil(t, err)
+	assert.Equal(t, "pop-up choices for input type Pop-up Menu can not be blank", err.Error())
+
+	ce.PopupChoices = []string{"Engineering", "Engineering"}
+	err = ce.ValidateInputType()
+	assert.NotNil(t, err)
+	assert.Equal(t, "Engineering is a duplicate pop-up choice for input type Pop-up Menu", err.Error())
+
+	// test passing case with choices
+	ce.PopupChoices = []string{"Engineering", "Sales"}
+	err = ce.ValidateInputType()
+	assert.Nil(t, err)
+}
+
+func TestValidateComputerExtAttrInputTypeLDAP(t *testing.T) {
+	ce := &jamf.ComputerExtensionAttrInputType{
+		Type: "LDAP Mapping",
+	}
+
+	// test failure missing attribute mapping which is required
+	err := ce.ValidateInputType()
+	assert.NotNil(t, err)
+	assert.Equal(t, "attribute mapping must be provided for input type LDAP Mapping", err.Error())
+
+	// test passing case with attribute mapping
+	ce.AttributeMapping = "department"
+	err = ce.ValidateInputType()
+	assert.Nil(t, err)
+}
+
+func TestValidateComputerExtAttrPlatform(t *testing.T) {
+	ce := &jamf.ComputerExtensionAttrInputType{}
+	for _, p := range []string{"", "Mac", "Windows", "mac"} {
+		ce.Platform = p
+		assert.Nil(t, ce.ValidatePlatform())
+	}
+
+	for _, p := range []string{"Linux", "iOS"} {
+		ce.Platform = p
+		err := ce.ValidateInputType()
+		assert.NotNil(t, err)
+		assert.Equal(t, fmt.Sprintf("%s is not a valid computer extension attribute platform must be of type [ Mac, Windows ]", p), err.Error())
+	}
+}
+
+func TestComputerExtAttrInputTypeMarshal(t *testing.T) {
+	popup := &jamf.ComputerExtensionAttribute{
+		Name: "Team",
+		InputType: &jamf.ComputerExtensionAttrInputType{
+			Type:         "Pop-up Menu",
+			PopupChoices: []string{"Engineering", "Sales"},
+		},
+	}
+	data, err := xml.Marshal(popup)
+	assert.Nil(t, err)
+	assert.Contains(t, string(data), "<input_type><type>Pop-up Menu</type><popup_choices><choice>Engineering</choice><choice>Sales</choice></popup_choices></input_type>")
+
+	ldap := &jamf.ComputerExtensionAttribute{
+		Name: "Department",
+		InputType: &jamf.ComputerExtensionAttrInputType{
+			Type:             "LDAP Mapping",
+			AttributeMapping: "department",
+		},
+	}
+	data, err = xml.Marshal(ldap)
+	assert.Nil(t, err)
+	assert.Contains(t, string(data), "<input_type><type>LDAP Mapping</type><attribute_mapping>department</attribute_mapping></input_type>")
+	assert.NotContains(t, string(data), "popup_choices")
+
+	decoded := &jamf.ComputerExtensionAttribute{}
+	err = json.Unmarshal([]byte(`{"id": 103, "name": "Team", "input_type": {"type": "Pop-up Menu", "popup_choices": ["Engineering", "Sales"]}}`), decoded)
+	assert.Nil(t, err)
+	assert.Equal(t, jamf.PopupChoices{"Engineering", "Sales"}, decoded.InputType.PopupChoices)
+
+	decoded = &jamf.ComputerExtensionAttribute{}
+	err = xml.Unmarshal([]byte(`<computer_extension_attribute><input_type><type>Pop-up Menu</type><popup_choices><choice>Engineering</choice><choice>Sales</choice></popup_choices></input_type></computer_extension_attribute>`), decoded)
+	assert.Nil(t, err)
+	assert.Equal(t, jamf.PopupChoices{"Engineering", "Sales"}, decoded.InputType.PopupChoices)
+}
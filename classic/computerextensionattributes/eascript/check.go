@@ -0,0 +1,105 @@
+// Unless explicitly stated otherwise all files in this repository are licensed under the Apache-2.0
+// This product includes software developed at Datadog (https://www.datadoghq.com/). Copyright 2020 Datadog, Inc.
+
+// Package eascript checks and runs computer extension attribute scripts locally so broken
+// scripts can be caught before they are uploaded to Jamf
+package eascript
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/trustero/jamf-api-client-go/classic/computerextensionattributes"
+	"github.com/trustero/jamf-api-client-go/internal/shebang"
+)
+
+// Checks performed on an extension attribute script
+const (
+	CheckScript  = "script"
+	CheckShebang = "shebang"
+	CheckResult  = "result"
+)
+
+// CheckError describes a single problem found in an extension attribute script
+type CheckError struct {
+	Check   string
+	Message string
+}
+
+func (e *CheckError) Error() string {
+	return fmt.Sprintf("%s: %s", e.Check, e.Message)
+}
+
+// CheckErrors holds every problem found while checking an extension attribute script
+type CheckErrors []*CheckError
+
+func (e CheckErrors) Error() string {
+	msgs := make([]string, 0, len(e))
+	for _, ce := range e {
+		msgs = append(msgs, ce.Error())
+	}
+	return fmt.Sprintf("extension attribute script check failed with %d error(s): %s", len(e), strings.Join(msgs, "; "))
+}
+
+func (e *CheckErrors) add(check string, format string, args ...interface{}) {
+	*e = append(*e, &CheckError{Check: check, Message: fmt.Sprintf(format, args...)})
+}
+
+// Check statically checks the script of an extension attribute. The script must print a
+// <result>...</result> tag and have a shebang matching the extension attribute platform.
+// Mac scripts require a shebang with an absolute interpreter path and Windows scripts must not
+// use a Unix interpreter. All problems found are returned together as CheckErrors
+func Check(attr *computerextensionattributes.ComputerExtensionAttribute) error {
+	errs := CheckErrors{}
+	if attr == nil || attr.InputType == nil || attr.InputType.Type != computerextensionattributes.ScriptInputType {
+		errs.add(CheckScript, "extension attribute must have an input type of %s", computerextensionattributes.ScriptInputType)
+		return errs
+	}
+
+	if strings.TrimSpace(attr.InputType.Script) == "" {
+		errs.add(CheckScript, "script contents must be provided")
+		return errs
+	}
+
+	if err := attr.InputType.ValidatePlatform(); err != nil {
+		errs.add(CheckShebang, err.Error())
+	} else {
+		checkShebang(&errs, attr.InputType.Platform, attr.InputType.Script)
+	}
+
+	if !strings.Contains(attr.InputType.Script, "<result>") || !strings.Contains(attr.InputType.Script, "</result>") {
+		errs.add(CheckResult, "script never prints a <result>...</result> tag so Jamf will not record a value")
+	}
+
+	if len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
+
+func checkShebang(errs *CheckErrors, platform string, script string) {
+	interpreter, ok := Shebang(script)
+	switch strings.ToLower(platform) {
+	case "windows":
+		if ok && strings.HasPrefix(interpreter, "/") {
+			errs.add(CheckShebang, "%s is a Unix interpreter but the extension attribute platform is %s", interpreter, platform)
+		}
+	default:
+		if !ok {
+			errs.add(CheckShebang, "script must start with a shebang i.e #!/bin/bash for platform Mac")
+			return
+		}
+		if !strings.HasPrefix(interpreter, "/") {
+			errs.add(CheckShebang, "%s must be an absolute interpreter path for platform Mac", interpreter)
+		}
+	}
+}
+
+// Shebang returns the interpreter named on the first line of a script along with its arguments
+func Shebang(script string) (string, bool) {
+	s, ok := shebang.Parse(script)
+	if !ok {
+		return "", false
+	}
+	return s.String(), true
+}
@@ -0,0 +1,65 @@
+// Unless explicitly stated otherwise all files in this repository are licensed under the Apache-2.0
+// This product includes software developed at Datadog (https://www.datadoghq.com/). Copyright 2020 Datadog, Inc.
+
+package eascript_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/trustero/jamf-api-client-go/classic/computerextensionattributes"
+	"github.com/trustero/jamf-api-client-go/classic/computerextensionattributes/eascript"
+)
+
+func scriptAttr(platform string, script string) *computerextensionattributes.ComputerExtensionAttribute {
+	return &computerextensionattributes.ComputerExtensionAttribute{
+		Name:     "Test Extension Attribute",
+		DataType: "String",
+		InputType: &computerextensionattributes.ComputerExtensionAttrInputType{
+			Type:     "script",
+			Platform: platform,
+			Script:   script,
+		},
+	}
+}
+
+func TestCheckPass(t *testing.T) {
+	assert.Nil(t, eascript.Check(scriptAttr("Mac", "#!/bin/bash\necho \"<result>On</result>\"\n")))
+	assert.Nil(t, eascript.Check(scriptAttr("", "#!/usr/bin/env python3\nprint('<result>3</result>')\n")))
+	assert.Nil(t, eascript.Check(scriptAttr("Windows", "Write-Output \"<result>On</result>\"\r\n")))
+}
+
+func TestCheckFail(t *testing.T) {
+	err := eascript.Check(scriptAttr("Mac", "echo hello\n"))
+	assert.NotNil(t, err)
+	errs, ok := err.(eascript.CheckErrors)
+	assert.True(t, ok)
+	assert.Equal(t, 2, len(errs))
+	assert.Equal(t, eascript.CheckShebang, errs[0].Check)
+	assert.Equal(t, eascript.CheckResult, errs[1].Check)
+
+	err = eascript.Check(scriptAttr("Mac", "#!bash\necho \"<result>On</result>\"\n"))
+	assert.NotNil(t, err)
+	assert.Equal(t, "extension attribute script check failed with 1 error(s): shebang: bash must be an absolute interpreter path for platform Mac", err.Error())
+
+	err = eascript.Check(scriptAttr("Windows", "#!/bin/bash\necho \"<result>On</result>\"\n"))
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "/bin/bash is a Unix interpreter but the extension attribute platform is Windows")
+
+	err = eascript.Check(scriptAttr("Linux", "#!/bin/bash\necho \"<result>On</result>\"\n"))
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "Linux is not a valid computer extension attribute platform")
+
+	err = eascript.Check(&computerextensionattributes.ComputerExtensionAttribute{Name: "Team"})
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "extension attribute must have an input type of script")
+}
+
+func TestShebang(t *testing.T) {
+	interpreter, ok := eascript.Shebang("#! /usr/bin/env python3\r\nprint('hi')")
+	assert.True(t, ok)
+	assert.Equal(t, "/usr/bin/env python3", interpreter)
+
+	_, ok = eascript.Shebang("echo hi")
+	assert.False(t, ok)
+}
@@ -0,0 +1,165 @@
+// Unless explicitly stated otherwise all files in this repository are licensed under the Apache-2.0
+// This product includes software developed at Datadog (https://www.datadoghq.com/). Copyright 2020 Datadog, Inc.
+
+package eascript
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"runtime"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/trustero/jamf-api-client-go/classic/computerextensionattributes"
+	"github.com/trustero/jamf-api-client-go/classic/computers"
+)
+
+// DefaultTimeout is how long a script may run when no timeout is provided
+const DefaultTimeout = 30 * time.Second
+
+var resultTag = regexp.MustCompile(`(?s)<result>(.*?)</result>`)
+
+// RunOptions holds the settings used when running an extension attribute script locally
+type RunOptions struct {
+	// Timeout stops the script once it has run this long, defaults to DefaultTimeout
+	Timeout time.Duration
+	// Env holds extra KEY=value environment variables passed to the script
+	Env []string
+}
+
+// RunResult holds the outcome of running an extension attribute script locally
+type RunResult struct {
+	Stdout   string
+	Stderr   string
+	ExitCode int
+	Duration time.Duration
+	// Raw is the trimmed text found between the <result></result> tags
+	Raw string
+	// Value is Raw coerced using the extension attribute data type, see computers.CoerceExtensionAttributeValue
+	Value interface{}
+}
+
+// Run executes the script of an extension attribute in a subprocess the way a managed computer
+// would and parses the <result> tag according to the data type. The script runs from an empty
+// temporary directory which is also its HOME and TMPDIR, with no stdin and only PATH inherited
+// from the environment. The script is stopped once the timeout is reached
+func Run(ctx context.Context, attr *computerextensionattributes.ComputerExtensionAttribute, opts *RunOptions) (*RunResult, error) {
+	if opts == nil {
+		opts = &RunOptions{}
+	}
+
+	if err := Check(attr); err != nil {
+		return nil, err
+	}
+
+	if err := checkHostPlatform(attr.InputType.Platform); err != nil {
+		return nil, err
+	}
+
+	timeout := opts.Timeout
+	if timeout <= 0 {
+		timeout = DefaultTimeout
+	}
+
+	dir, err := os.MkdirTemp("", "eascript-")
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to create extension attribute script sandbox")
+	}
+	defer os.RemoveAll(dir)
+
+	script := filepath.Join(dir, "script")
+	if err := os.WriteFile(script, []byte(strings.ReplaceAll(attr.InputType.Script, "\r\n", "\n")), 0o700); err != nil {
+		return nil, errors.Wrapf(err, "unable to write extension attribute script: %s", attr.Name)
+	}
+
+	// output is written to files rather than pipes so a background process left running by
+	// the script can not hold the harness open past the timeout
+	stdout, err := os.Create(filepath.Join(dir, "stdout"))
+	if err != nil {
+		return nil, errors.Wrapf(err, "unable to capture extension attribute script output: %s", attr.Name)
+	}
+	defer stdout.Close()
+	stderr, err := os.Create(filepath.Join(dir, "stderr"))
+	if err != nil {
+		return nil, errors.Wrapf(err, "unable to capture extension attribute script output: %s", attr.Name)
+	}
+	defer stderr.Close()
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	cmd := exec.CommandContext(ctx, script)
+	cmd.Dir = dir
+	cmd.Env = append([]string{"PATH=" + os.Getenv("PATH"), "HOME=" + dir, "TMPDIR=" + dir, "LANG=C"}, opts.Env...)
+	cmd.Stdout = stdout
+	cmd.Stderr = stderr
+
+	start := time.Now()
+	runErr := cmd.Run()
+	res := &RunResult{Duration: time.Since(start)}
+
+	out, _ := os.ReadFile(stdout.Name())
+	errOut, _ := os.ReadFile(stderr.Name())
+	res.Stdout, res.Stderr = string(out), string(errOut)
+
+	if err := ctx.Err(); err != nil {
+		if errors.Is(err, context.DeadlineExceeded) {
+			return res, fmt.Errorf("extension attribute script %q timed out after %s", attr.Name, timeout)
+		}
+		return res, errors.Wrapf(err, "extension attribute script %q was stopped", attr.Name)
+	}
+
+	if runErr != nil {
+		exitErr := &exec.ExitError{}
+		if !errors.As(runErr, &exitErr) {
+			return res, errors.Wrapf(runErr, "unable to run extension attribute script %q", attr.Name)
+		}
+		res.ExitCode = exitErr.ExitCode()
+		return res, fmt.Errorf("extension attribute script %q exited with status %d: %s", attr.Name, res.ExitCode, strings.TrimSpace(res.Stderr))
+	}
+
+	res.Raw, res.Value, err = ParseResult(attr.DataType, res.Stdout)
+	if err != nil {
+		return res, errors.Wrapf(err, "extension attribute script %q", attr.Name)
+	}
+	return res, nil
+}
+
+// ParseResult finds the first <result></result> tag in script output and coerces its trimmed
+// contents using the data type. String is used when no data type is provided
+func ParseResult(dataType string, output string) (string, interface{}, error) {
+	match := resultTag.FindStringSubmatch(output)
+	if match == nil {
+		return "", nil, fmt.Errorf("no <result>...</result> tag found in script output")
+	}
+
+	raw := strings.TrimSpace(match[1])
+	if dataType == "" {
+		dataType = "String"
+	}
+
+	value, err := computers.CoerceExtensionAttributeValue(dataType, raw)
+	if err != nil {
+		return raw, nil, err
+	}
+	return raw, value, nil
+}
+
+// checkHostPlatform makes sure the script can run on this machine. Mac scripts run on any
+// Unix host so they can be checked in CI while Windows scripts only run on Windows
+func checkHostPlatform(platform string) error {
+	windowsHost := runtime.GOOS == "windows"
+	windowsScript := strings.EqualFold(platform, "windows")
+	if windowsHost != windowsScript {
+		if platform == "" {
+			platform = "Mac"
+		}
+		return fmt.Errorf("unable to run a %s extension attribute script on %s", platform, runtime.GOOS)
+	}
+	return nil
+}
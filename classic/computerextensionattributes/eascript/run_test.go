@@ -0,0 +1,86 @@
+// Unless explicitly stated otherwise all files in this repository are licensed under the Apache-2.0
+// This product includes software developed at Datadog (https://www.datadoghq.com/). Copyright 2020 Datadog, Inc.
+
+package eascript_test
+
+import (
+	"context"
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/trustero/jamf-api-client-go/classic/computerextensionattributes/eascript"
+)
+
+func skipOnWindows(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("Mac extension attribute scripts can not run on windows")
+	}
+}
+
+func TestRunString(t *testing.T) {
+	skipOnWindows(t)
+	attr := scriptAttr("Mac", "#!/bin/sh\necho starting\necho \"<result>  $HOME  </result>\"\n")
+	res, err := eascript.Run(context.Background(), attr, nil)
+	assert.Nil(t, err)
+	assert.Equal(t, 0, res.ExitCode)
+	// the script runs with a temporary HOME rather than the caller's
+	assert.NotEmpty(t, res.Raw)
+	assert.Equal(t, res.Raw, res.Value)
+	assert.Contains(t, res.Stdout, "starting")
+}
+
+func TestRunTypedResult(t *testing.T) {
+	skipOnWindows(t)
+	attr := scriptAttr("Mac", "#!/bin/sh\necho \"<result>42</result>\"\n")
+	attr.DataType = "Integer"
+	res, err := eascript.Run(context.Background(), attr, nil)
+	assert.Nil(t, err)
+	assert.Equal(t, 42, res.Value)
+
+	attr = scriptAttr("Mac", "#!/bin/sh\necho \"<result>2021-03-04 10:30:00</result>\"\n")
+	attr.DataType = "Date"
+	res, err = eascript.Run(context.Background(), attr, nil)
+	assert.Nil(t, err)
+	assert.Equal(t, time.Date(2021, 3, 4, 10, 30, 0, 0, time.UTC), res.Value)
+
+	attr = scriptAttr("Mac", "#!/bin/sh\necho \"<result>forty two</result>\"\n")
+	attr.DataType = "Integer"
+	res, err = eascript.Run(context.Background(), attr, nil)
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "forty two is not a valid value for an extension attribute of type Integer")
+	assert.Equal(t, "forty two", res.Raw)
+}
+
+func TestRunFailures(t *testing.T) {
+	skipOnWindows(t)
+	attr := scriptAttr("Mac", "#!/bin/sh\necho \"<result>On</result>\"\necho broken >&2\nexit 3\n")
+	res, err := eascript.Run(context.Background(), attr, nil)
+	assert.NotNil(t, err)
+	assert.Equal(t, 3, res.ExitCode)
+	assert.Contains(t, err.Error(), "exited with status 3: broken")
+
+	attr = scriptAttr("Mac", "#!/bin/sh\nif false; then echo \"<result>On</result>\"; fi\n")
+	_, err = eascript.Run(context.Background(), attr, nil)
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "no <result>...</result> tag found in script output")
+
+	attr = scriptAttr("Mac", "#!/bin/sh\nsleep 5\necho \"<result>On</result>\"\n")
+	start := time.Now()
+	_, err = eascript.Run(context.Background(), attr, &eascript.RunOptions{Timeout: 200 * time.Millisecond})
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "timed out after 200ms")
+	assert.Less(t, int64(time.Since(start)), int64(4*time.Second))
+
+	_, err = eascript.Run(context.Background(), scriptAttr("Windows", "Write-Output \"<result>On</result>\""), nil)
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "unable to run a Windows extension attribute script")
+}
+
+func TestParseResult(t *testing.T) {
+	raw, value, err := eascript.ParseResult("", "noise\n<result>\nfirst\n</result><result>second</result>")
+	assert.Nil(t, err)
+	assert.Equal(t, "first", raw)
+	assert.Equal(t, "first", value)
+}
@@ -0,0 +1,23 @@
+package computergroups
+
+import (
+	"github.com/trustero/jamf-api-client-go/classic/client"
+	"github.com/trustero/jamf-api-client-go/internal/groups"
+	"net/http"
+)
+
+const domain = "computergroups"
+
+type Service struct {
+	groups *groups.Service[ComputerGroup]
+}
+
+func NewService(baseUrl string, username string, password string, httpClient *http.Client) (*Service, error) {
+
+	j, err := client.NewDomainClient(baseUrl, domain, username, password, httpClient)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Service{groups: groups.NewService(j, kind)}, nil
+}
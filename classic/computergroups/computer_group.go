@@ -0,0 +1,77 @@
+// Unless explicitly stated otherwise all files in this repository are licensed under the Apache-2.0
+// This product includes software developed at Datadog (https://www.datadoghq.com/). Copyright 2020 Datadog, Inc.
+
+package computergroups
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/trustero/jamf-api-client-go/pager"
+)
+
+// ComputerGroups returns a list of the computer groups available in Jamf
+func (j *Service) ComputerGroups() (result []BasicComputerGroupInfo, response *http.Response, err error) {
+	return j.list(context.Background())
+}
+
+// Iterate returns an iterator over the computer groups available in Jamf
+func (j *Service) Iterate() *pager.Pager[BasicComputerGroupInfo] {
+	return pager.Single(func(ctx context.Context) ([]BasicComputerGroupInfo, error) {
+		groups, _, err := j.list(ctx)
+		return groups, err
+	})
+}
+
+func (j *Service) list(ctx context.Context) (result []BasicComputerGroupInfo, response *http.Response, err error) {
+	res := &ComputerGroups{}
+	if response, err = j.groups.List(ctx, res); err == nil {
+		result = res.List
+	}
+	return
+}
+
+// ComputerGroupDetails returns the details for a specific computer group given its Id or Name
+func (j *Service) ComputerGroupDetails(identifier interface{}) (result *ComputerGroup, response *http.Response, err error) {
+	res := &ComputerGroupDetails{}
+	if response, err = j.groups.Details(identifier, res); err == nil {
+		result = res.Group
+	}
+	return
+}
+
+// CreateComputerGroup will validate and create a computer group in Jamf
+func (j *Service) CreateComputerGroup(group *ComputerGroup) (result *ComputerGroup, response *http.Response, err error) {
+	return j.groups.Create(group)
+}
+
+// UpdateComputerGroup will validate and update a computer group in Jamf by either Id or Name. The name
+// may be left empty to keep it unchanged and the Computers Jamf lists for smart groups are not
+// sent back. When Computers is set the members of a static group are replaced, use AddComputers
+// and RemoveComputers to edit them instead
+func (j *Service) UpdateComputerGroup(identifier interface{}, group *ComputerGroup) (result *ComputerGroup, response *http.Response, err error) {
+	return j.groups.Update(identifier, group)
+}
+
+// DeleteComputerGroup will delete a computer group by either Id or Name
+func (j *Service) DeleteComputerGroup(identifier interface{}) (result *ComputerGroup, response *http.Response, err error) {
+	return j.groups.Delete(identifier)
+}
+
+// AddComputers adds computers by Id to a static computer group leaving its other members untouched
+func (j *Service) AddComputers(identifier interface{}, computerIDs ...int) (result *ComputerGroup, response *http.Response, err error) {
+	return j.groups.EditMembership(identifier, &membershipUpdate{Additions: groupComputers(computerIDs)}, len(computerIDs))
+}
+
+// RemoveComputers removes computers by Id from a static computer group leaving its other members untouched
+func (j *Service) RemoveComputers(identifier interface{}, computerIDs ...int) (result *ComputerGroup, response *http.Response, err error) {
+	return j.groups.EditMembership(identifier, &membershipUpdate{Deletions: groupComputers(computerIDs)}, len(computerIDs))
+}
+
+func groupComputers(ids []int) GroupComputers {
+	computers := make(GroupComputers, len(ids))
+	for i, id := range ids {
+		computers[i] = &GroupComputer{ID: id}
+	}
+	return computers
+}
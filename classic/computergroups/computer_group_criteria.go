@@ -0,0 +1,161 @@
+// Unless explicitly stated otherwise all files in this repository are licensed under the Apache-2.0
+// This product includes software developed at Datadog (https://www.datadoghq.com/). Copyright 2020 Datadog, Inc.
+
+package computergroups
+
+import "encoding/xml"
+
+// AndOr joins a criterion to the criteria before it
+type AndOr string
+
+// Joins supported by Jamf
+const (
+	And AndOr = "and"
+	Or  AndOr = "or"
+)
+
+// SearchType is the operator a criterion compares an inventory field with
+type SearchType string
+
+// Search types supported by Jamf smart groups
+const (
+	Is                 SearchType = "is"
+	IsNot              SearchType = "is not"
+	Like               SearchType = "like"
+	NotLike            SearchType = "not like"
+	Has                SearchType = "has"
+	DoesNotHave        SearchType = "does not have"
+	MatchesRegex       SearchType = "matches regex"
+	DoesNotMatchRegex  SearchType = "does not match regex"
+	GreaterThan        SearchType = "greater than"
+	LessThan           SearchType = "less than"
+	GreaterThanOrEqual SearchType = "greater than or equal"
+	LessThanOrEqual    SearchType = "less than or equal"
+	MoreThanXDaysAgo   SearchType = "more than x days ago"
+	LessThanXDaysAgo   SearchType = "less than x days ago"
+	BeforeDate         SearchType = "before (yyyy-mm-dd)"
+	AfterDate          SearchType = "after (yyyy-mm-dd)"
+	MemberOf           SearchType = "member of"
+	NotMemberOf        SearchType = "not member of"
+)
+
+// SearchTypes lists every search type supported by Jamf
+var SearchTypes = []SearchType{
+	Is, IsNot, Like, NotLike, Has, DoesNotHave, MatchesRegex, DoesNotMatchRegex, GreaterThan, LessThan,
+	GreaterThanOrEqual, LessThanOrEqual, MoreThanXDaysAgo, LessThanXDaysAgo, BeforeDate, AfterDate,
+	MemberOf, NotMemberOf,
+}
+
+// Criterion is a single rule of a smart group, ex. Operating System Version like 14.
+type Criterion struct {
+	Name         string     `json:"name" xml:"name"`
+	Priority     int        `json:"priority" xml:"priority"`
+	AndOr        AndOr      `json:"and_or" xml:"and_or"`
+	SearchType   SearchType `json:"search_type" xml:"search_type"`
+	Value        string     `json:"value" xml:"value"`
+	OpeningParen bool       `json:"opening_paren" xml:"opening_paren"`
+	ClosingParen bool       `json:"closing_paren" xml:"closing_paren"`
+}
+
+// Criteria holds the rules of a smart group ordered by priority
+type Criteria []*Criterion
+
+// MarshalXML writes each criterion as a criterion element and omits the criteria element when empty
+func (c Criteria) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	if len(c) == 0 {
+		return nil
+	}
+	return e.EncodeElement(struct {
+		List []*Criterion `xml:"criterion"`
+	}{c}, start)
+}
+
+// UnmarshalXML reads each criterion element
+func (c *Criteria) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	list := struct {
+		List []*Criterion `xml:"criterion"`
+	}{}
+	if err := d.DecodeElement(&list, &start); err != nil {
+		return err
+	}
+	*c = list.List
+	return nil
+}
+
+// CriteriaBuilder builds smart group criteria. Jamf supports a single level of parentheses
+// which are added with AndGroup and OrGroup:
+//
+//	criteria, err := computergroups.NewCriteriaBuilder().
+//		Where("Operating System Version", computergroups.Like, "14.").
+//		AndGroup(func(b *computergroups.CriteriaBuilder) {
+//			b.Where("Computer Group", computergroups.MemberOf, "Engineering").
+//				Or("Department", computergroups.Is, "IT")
+//		}).
+//		Build()
+type CriteriaBuilder struct {
+	criteria Criteria
+}
+
+// NewCriteriaBuilder returns an empty criteria builder
+func NewCriteriaBuilder() *CriteriaBuilder {
+	return &CriteriaBuilder{}
+}
+
+// Where adds the first criterion. Criteria added after the first are joined with and
+func (b *CriteriaBuilder) Where(name string, searchType SearchType, value string) *CriteriaBuilder {
+	return b.add(And, name, searchType, value)
+}
+
+// And adds a criterion which must match along with the criteria before it
+func (b *CriteriaBuilder) And(name string, searchType SearchType, value string) *CriteriaBuilder {
+	return b.add(And, name, searchType, value)
+}
+
+// Or adds a criterion which may match instead of the criteria before it
+func (b *CriteriaBuilder) Or(name string, searchType SearchType, value string) *CriteriaBuilder {
+	return b.add(Or, name, searchType, value)
+}
+
+// AndGroup adds the criteria built by group wrapped in parentheses and joined with and
+func (b *CriteriaBuilder) AndGroup(group func(g *CriteriaBuilder)) *CriteriaBuilder {
+	return b.group(And, group)
+}
+
+// OrGroup adds the criteria built by group wrapped in parentheses and joined with or
+func (b *CriteriaBuilder) OrGroup(group func(g *CriteriaBuilder)) *CriteriaBuilder {
+	return b.group(Or, group)
+}
+
+// Build numbers the criteria by priority and validates them
+func (b *CriteriaBuilder) Build() (Criteria, error) {
+	criteria := make(Criteria, len(b.criteria))
+	for i, c := range b.criteria {
+		copied := *c
+		copied.Priority = i
+		criteria[i] = &copied
+	}
+
+	if err := criteria.Validate(); err != nil {
+		return nil, err
+	}
+	return criteria, nil
+}
+
+func (b *CriteriaBuilder) add(andOr AndOr, name string, searchType SearchType, value string) *CriteriaBuilder {
+	b.criteria = append(b.criteria, &Criterion{Name: name, AndOr: andOr, SearchType: searchType, Value: value})
+	return b
+}
+
+func (b *CriteriaBuilder) group(andOr AndOr, group func(g *CriteriaBuilder)) *CriteriaBuilder {
+	g := &CriteriaBuilder{}
+	group(g)
+	if len(g.criteria) == 0 {
+		return b
+	}
+
+	g.criteria[0].AndOr = andOr
+	g.criteria[0].OpeningParen = true
+	g.criteria[len(g.criteria)-1].ClosingParen = true
+	b.criteria = append(b.criteria, g.criteria...)
+	return b
+}
@@ -0,0 +1,82 @@
+// Unless explicitly stated otherwise all files in this repository are licensed under the Apache-2.0
+// This product includes software developed at Datadog (https://www.datadoghq.com/). Copyright 2020 Datadog, Inc.
+
+package computergroups
+
+import "encoding/xml"
+
+// ComputerGroups holds a list of all the computer groups available in Jamf
+type ComputerGroups struct {
+	List []BasicComputerGroupInfo `json:"computer_groups"`
+}
+
+// BasicComputerGroupInfo holds the most basic information about a computer group in Jamf
+type BasicComputerGroupInfo struct {
+	ID      int    `json:"id,omitempty" xml:"id,omitempty"`
+	Name    string `json:"name" xml:"name"`
+	IsSmart bool   `json:"is_smart" xml:"is_smart"`
+}
+
+// ComputerGroupDetails holds the details to a specific computer group queried by Id or Name
+type ComputerGroupDetails struct {
+	Group *ComputerGroup `json:"computer_group" xml:"computer_group,omitempty"`
+}
+
+// ComputerGroup represents a static or smart computer group in Jamf. Smart groups hold criteria
+// which Jamf evaluates to find their members while static groups list their members directly
+type ComputerGroup struct {
+	XMLName   xml.Name       `json:"-" xml:"computer_group,omitempty"`
+	ID        int            `json:"id,omitempty" xml:"id,omitempty"`
+	Name      string         `json:"name" xml:"name,omitempty"`
+	IsSmart   *bool          `json:"is_smart,omitempty" xml:"is_smart,omitempty"`
+	Site      *Site          `json:"site,omitempty" xml:"site,omitempty"`
+	Criteria  Criteria       `json:"criteria,omitempty" xml:"criteria,omitempty"`
+	Computers GroupComputers `json:"computers,omitempty" xml:"computers,omitempty"`
+}
+
+// GroupComputer is a computer which is a member of a computer group
+type GroupComputer struct {
+	ID            int    `json:"id,omitempty" xml:"id,omitempty"`
+	Name          string `json:"name,omitempty" xml:"name,omitempty"`
+	MacAddress    string `json:"mac_address,omitempty" xml:"mac_address,omitempty"`
+	AltMacAddress string `json:"alt_mac_address,omitempty" xml:"alt_mac_address,omitempty"`
+	SerialNumber  string `json:"serial_number,omitempty" xml:"serial_number,omitempty"`
+}
+
+// GroupComputers holds the members of a computer group
+type GroupComputers []*GroupComputer
+
+// MarshalXML writes each member as a computer element and omits the computers element when empty
+func (g GroupComputers) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	if len(g) == 0 {
+		return nil
+	}
+	return e.EncodeElement(struct {
+		List []*GroupComputer `xml:"computer"`
+	}{g}, start)
+}
+
+// UnmarshalXML reads each computer element as a member
+func (g *GroupComputers) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	list := struct {
+		List []*GroupComputer `xml:"computer"`
+	}{}
+	if err := d.DecodeElement(&list, &start); err != nil {
+		return err
+	}
+	*g = list.List
+	return nil
+}
+
+// Site is the site a computer group belongs to
+type Site struct {
+	ID   int    `json:"id" xml:"id"`
+	Name string `json:"name,omitempty" xml:"name,omitempty"`
+}
+
+// membershipUpdate is the payload used to add or remove computers of a static group
+type membershipUpdate struct {
+	XMLName   xml.Name       `xml:"computer_group"`
+	Additions GroupComputers `xml:"computer_additions,omitempty"`
+	Deletions GroupComputers `xml:"computer_deletions,omitempty"`
+}
@@ -0,0 +1,520 @@
+// Unless explicitly stated otherwise all files in this repository are licensed under the Apache-2.0
+// This product includes software developed at Datadog (https://www.datadoghq.com/). Copyright 2020 Datadog, Inc.
+
+package computergroups
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/trustero/jamf-api-client-go/classic/computers"
+)
+
+// ComputerGroupField is the criterion name used for group membership
+const ComputerGroupField = "Computer Group"
+
+// dateLayouts are the layouts accepted when reading a date from inventory or a criterion value
+var dateLayouts = []string{computers.ExtensionAttributeDateLayout, "2006-01-02", time.RFC3339}
+
+// Field returns the inventory values of a computer compared by criteria on a given name.
+// Fields holding several values, such as Application Title, return one value per item
+type Field func(c *computers.Computer) []string
+
+var (
+	fieldsMu sync.RWMutex
+	fields   = map[string]Field{}
+)
+
+func init() {
+	builtin := map[string]Field{
+		"Computer Name":               single(func(c *computers.Computer) string { return c.General.Name }),
+		"Serial Number":               single(func(c *computers.Computer) string { return c.General.SerialNumber }),
+		"UDID":                        single(func(c *computers.Computer) string { return c.General.UDID }),
+		"MAC Address":                 single(func(c *computers.Computer) string { return c.General.MACAddress }),
+		"Platform":                    single(func(c *computers.Computer) string { return c.General.Platform }),
+		"Jamf Version":                single(func(c *computers.Computer) string { return c.General.JamfVersion }),
+		"Last Inventory Update":       single(func(c *computers.Computer) string { return c.General.ReportDate }),
+		"Username":                    single(func(c *computers.Computer) string { return c.UserLocation.Username }),
+		"Full Name":                   single(func(c *computers.Computer) string { return c.UserLocation.RealName }),
+		"Email Address":               single(func(c *computers.Computer) string { return c.UserLocation.EmailAddress }),
+		"Position":                    single(func(c *computers.Computer) string { return c.UserLocation.Position }),
+		"Department":                  single(func(c *computers.Computer) string { return c.UserLocation.Department }),
+		"Building":                    single(func(c *computers.Computer) string { return c.UserLocation.Building }),
+		"Make":                        single(func(c *computers.Computer) string { return c.Hardware.Make }),
+		"Model":                       single(func(c *computers.Computer) string { return c.Hardware.Model }),
+		"Model Identifier":            single(func(c *computers.Computer) string { return c.Hardware.ModelIdentifier }),
+		"Operating System":            single(func(c *computers.Computer) string { return c.Hardware.OSName }),
+		"Operating System Name":       single(func(c *computers.Computer) string { return c.Hardware.OSName }),
+		"Operating System Version":    single(func(c *computers.Computer) string { return c.Hardware.OSVersion }),
+		"Operating System Build":      single(func(c *computers.Computer) string { return c.Hardware.OSBuild }),
+		"Processor Type":              single(func(c *computers.Computer) string { return c.Hardware.ProcessorType }),
+		"Architecture Type":           single(func(c *computers.Computer) string { return c.Hardware.ProcessorArchitecture }),
+		"Total RAM MB":                single(func(c *computers.Computer) string { return strconv.FormatInt(c.Hardware.TotalRAMMb, 10) }),
+		"System Integrity Protection": single(func(c *computers.Computer) string { return c.Hardware.SipStatus }),
+		"Gatekeeper":                  single(func(c *computers.Computer) string { return c.Hardware.GatekeeperStatus }),
+		"Disk Encryption Configuration": single(func(c *computers.Computer) string {
+			return c.Hardware.DiskEncryptionConfiguration
+		}),
+		"Application Title": func(c *computers.Computer) []string {
+			values := make([]string, 0, len(c.Software.Applications))
+			for _, app := range c.Software.Applications {
+				values = append(values, app.Name)
+			}
+			return values
+		},
+		"Application Version": func(c *computers.Computer) []string {
+			values := make([]string, 0, len(c.Software.Applications))
+			for _, app := range c.Software.Applications {
+				values = append(values, app.Version)
+			}
+			return values
+		},
+		"FileVault 2 Status": single(fileVaultStatus),
+		"FileVault 2 Partition Encryption State": func(c *computers.Computer) []string {
+			var values []string
+			for _, p := range partitions(c) {
+				values = append(values, p.Filevault2Status)
+			}
+			return values
+		},
+		"FileVault 2 Enabled User": func(c *computers.Computer) []string {
+			return c.Hardware.FilevaultUsers
+		},
+	}
+	for name, field := range builtin {
+		fields[strings.ToLower(name)] = field
+	}
+}
+
+// RegisterField adds or replaces the inventory field compared by criteria named name. Criteria
+// whose name is not a field are compared with the extension attribute of the same name
+func RegisterField(name string, field Field) {
+	fieldsMu.Lock()
+	defer fieldsMu.Unlock()
+	fields[strings.ToLower(name)] = field
+}
+
+// Evaluator evaluates smart group criteria against computer records already fetched from Jamf
+// so membership can be previewed without waiting for Jamf to recalculate it on inventory update.
+// Criteria are evaluated the way Jamf does: and takes precedence over or and parentheses group
+// criteria. Text comparisons are case insensitive and greater/less than compare numbers and
+// versions component by component so 14.10 is greater than 14.9
+type Evaluator struct {
+	// Groups returns the names of the groups a computer is a member of for member of criteria.
+	// The computer group memberships from inventory are used when nil
+	Groups func(c *computers.Computer) []string
+	// Now returns the current time for days ago criteria, time.Now is used when nil
+	Now func() time.Time
+}
+
+// NewEvaluator returns an evaluator using the group memberships from inventory
+func NewEvaluator() *Evaluator {
+	return &Evaluator{}
+}
+
+// CriterionResult holds the outcome of a single criterion for a computer
+type CriterionResult struct {
+	Criterion *Criterion
+	// Values holds the inventory values the criterion was compared with
+	Values  []string
+	Matched bool
+	// Reason explains a result which does not follow from the values, ex. a value which can not
+	// be compared as a number or an extension attribute the computer does not have
+	Reason string
+}
+
+// String returns the criterion and its result, ex. Department is IT: true (IT)
+func (r *CriterionResult) String() string {
+	c := r.Criterion
+	s := fmt.Sprintf("%s %s %s: %t (%s)", c.Name, c.SearchType, c.Value, r.Matched, strings.Join(r.Values, ", "))
+	if r.Reason != "" {
+		s += " " + r.Reason
+	}
+	return s
+}
+
+// Evaluation holds whether a computer is a member of a smart group and why
+type Evaluation struct {
+	Computer *computers.Computer
+	Member   bool
+	// Results holds the result of each criterion ordered by priority
+	Results []*CriterionResult
+}
+
+// Explain returns the membership of the computer followed by the result of each criterion, ex.
+//
+//	MacBook-1 (12): member
+//	  Operating System Version like 14.: true (14.2.1)
+//	  and (Department is IT: false (Sales)
+//	  or Building is HQ: true (HQ))
+func (e *Evaluation) Explain() string {
+	var b strings.Builder
+	membership := "not a member"
+	if e.Member {
+		membership = "member"
+	}
+	fmt.Fprintf(&b, "%s (%d): %s\n", e.Computer.General.Name, e.Computer.General.Id, membership)
+
+	for i, r := range e.Results {
+		b.WriteString("  ")
+		if i > 0 {
+			b.WriteString(string(r.Criterion.AndOr) + " ")
+		}
+		if r.Criterion.OpeningParen {
+			b.WriteString("(")
+		}
+		b.WriteString(r.String())
+		if r.Criterion.ClosingParen {
+			b.WriteString(")")
+		}
+		b.WriteString("\n")
+	}
+	return b.String()
+}
+
+// Preview holds the computers matching and not matching smart group criteria
+type Preview struct {
+	Members    []*Evaluation
+	NonMembers []*Evaluation
+}
+
+// MembershipChange holds the computers joining and leaving a smart group when its criteria change.
+// Evaluations are against the new criteria so they explain why a computer joins or leaves
+type MembershipChange struct {
+	Added   []*Evaluation
+	Removed []*Evaluation
+}
+
+// Evaluate returns whether a computer matches the criteria and the result of each criterion.
+// It fails when the criteria are not valid once ordered by priority or a regex does not compile.
+// A criterion naming neither an inventory field nor an extension attribute of the computer is
+// compared with an empty value, the way Jamf treats a computer without the attribute
+func (ev *Evaluator) Evaluate(criteria Criteria, computer *computers.Computer) (*Evaluation, error) {
+	ordered := make(Criteria, len(criteria))
+	copy(ordered, criteria)
+	sort.SliceStable(ordered, func(a, b int) bool { return ordered[a].Priority < ordered[b].Priority })
+
+	if err := ordered.Validate(); err != nil {
+		return nil, err
+	}
+
+	eval := &Evaluation{Computer: computer}
+	var outer, inner []term
+	var innerJoin AndOr
+	inGroup := false
+	for i, c := range ordered {
+		res, err := ev.evaluate(c, computer)
+		if err != nil {
+			return nil, fmt.Errorf("criterion %d: %s", i, err.Error())
+		}
+		eval.Results = append(eval.Results, res)
+
+		if c.OpeningParen {
+			inGroup = true
+			innerJoin = c.AndOr
+			inner = nil
+		}
+		if !inGroup {
+			outer = append(outer, term{andOr: c.AndOr, matched: res.Matched})
+			continue
+		}
+
+		inner = append(inner, term{andOr: c.AndOr, matched: res.Matched})
+		if c.ClosingParen {
+			inGroup = false
+			outer = append(outer, term{andOr: innerJoin, matched: combine(inner)})
+		}
+	}
+
+	// a smart group without criteria holds every computer
+	eval.Member = len(outer) == 0 || combine(outer)
+	return eval, nil
+}
+
+// Preview evaluates the criteria against every computer
+func (ev *Evaluator) Preview(criteria Criteria, list []*computers.Computer) (*Preview, error) {
+	preview := &Preview{}
+	for _, c := range list {
+		eval, err := ev.Evaluate(criteria, c)
+		if err != nil {
+			return nil, fmt.Errorf("unable to evaluate computer %s (%d): %s", c.General.Name, c.General.Id, err.Error())
+		}
+		if eval.Member {
+			preview.Members = append(preview.Members, eval)
+		} else {
+			preview.NonMembers = append(preview.NonMembers, eval)
+		}
+	}
+	return preview, nil
+}
+
+// PreviewChange returns the computers whose membership changes when a smart group's criteria are
+// replaced, to be checked before the group is saved
+func (ev *Evaluator) PreviewChange(current Criteria, proposed Criteria, list []*computers.Computer) (*MembershipChange, error) {
+	change := &MembershipChange{}
+	for _, c := range list {
+		before, err := ev.Evaluate(current, c)
+		if err != nil {
+			return nil, fmt.Errorf("unable to evaluate current criteria for computer %s (%d): %s", c.General.Name, c.General.Id, err.Error())
+		}
+		after, err := ev.Evaluate(proposed, c)
+		if err != nil {
+			return nil, fmt.Errorf("unable to evaluate proposed criteria for computer %s (%d): %s", c.General.Name, c.General.Id, err.Error())
+		}
+
+		switch {
+		case after.Member && !before.Member:
+			change.Added = append(change.Added, after)
+		case before.Member && !after.Member:
+			change.Removed = append(change.Removed, after)
+		}
+	}
+	return change, nil
+}
+
+type term struct {
+	andOr   AndOr
+	matched bool
+}
+
+// combine joins terms giving and precedence over or
+func combine(terms []term) bool {
+	result, current := false, true
+	for i, t := range terms {
+		if i > 0 && t.andOr == Or {
+			result = result || current
+			current = t.matched
+			continue
+		}
+		current = current && t.matched
+	}
+	return result || current
+}
+
+func (ev *Evaluator) evaluate(c *Criterion, computer *computers.Computer) (*CriterionResult, error) {
+	values, found := ev.values(c.Name, computer)
+	res := &CriterionResult{Criterion: c, Values: values}
+	if !found {
+		res.Reason = fmt.Sprintf("%s is not an inventory field or an extension attribute of the computer", c.Name)
+	}
+	value := strings.TrimSpace(c.Value)
+	switch c.SearchType {
+	case Is, Has, MemberOf:
+		res.Matched = anyValue(values, func(v string) bool { return strings.EqualFold(strings.TrimSpace(v), value) })
+	case IsNot, DoesNotHave, NotMemberOf:
+		res.Matched = !anyValue(values, func(v string) bool { return strings.EqualFold(strings.TrimSpace(v), value) })
+	case Like:
+		res.Matched = anyValue(values, func(v string) bool { return containsFold(v, value) })
+	case NotLike:
+		res.Matched = !anyValue(values, func(v string) bool { return containsFold(v, value) })
+	case MatchesRegex, DoesNotMatchRegex:
+		re, err := regexp.Compile("(?i)" + c.Value)
+		if err != nil {
+			return nil, fmt.Errorf("%s is not a valid regex: %s", c.Value, err.Error())
+		}
+		res.Matched = anyValue(values, re.MatchString)
+		if c.SearchType == DoesNotMatchRegex {
+			res.Matched = !res.Matched
+		}
+	case GreaterThan, LessThan, GreaterThanOrEqual, LessThanOrEqual:
+		want, ok := parseVersion(value)
+		if !ok {
+			res.Reason = fmt.Sprintf("%s is not a number or version", c.Value)
+			return res, nil
+		}
+		res.Matched = anyValue(values, func(v string) bool {
+			got, ok := parseVersion(v)
+			return ok && compareResult(c.SearchType, compareVersions(got, want))
+		})
+	case MoreThanXDaysAgo, LessThanXDaysAgo:
+		days, err := strconv.Atoi(value)
+		if err != nil {
+			res.Reason = fmt.Sprintf("%s is not a number of days", c.Value)
+			return res, nil
+		}
+		cutoff := ev.now().AddDate(0, 0, -days)
+		res.Matched = anyValue(values, func(v string) bool {
+			t, ok := parseDate(v)
+			if !ok {
+				return false
+			}
+			if c.SearchType == MoreThanXDaysAgo {
+				return t.Before(cutoff)
+			}
+			return t.After(cutoff)
+		})
+	case BeforeDate, AfterDate:
+		want, ok := parseDate(value)
+		if !ok {
+			res.Reason = fmt.Sprintf("%s is not a yyyy-mm-dd date", c.Value)
+			return res, nil
+		}
+		res.Matched = anyValue(values, func(v string) bool {
+			t, ok := parseDate(v)
+			if !ok {
+				return false
+			}
+			if c.SearchType == BeforeDate {
+				return t.Before(want)
+			}
+			return t.After(want)
+		})
+	}
+	return res, nil
+}
+
+// values returns the inventory values of a criterion checking group membership, registered
+// fields and then extension attributes. It reports whether the name was found, a single empty
+// value is returned when it was not
+func (ev *Evaluator) values(name string, computer *computers.Computer) ([]string, bool) {
+	if strings.EqualFold(name, ComputerGroupField) {
+		if ev.Groups != nil {
+			return ev.Groups(computer), true
+		}
+		return computer.Groups.Memberships, true
+	}
+
+	fieldsMu.RLock()
+	field, ok := fields[strings.ToLower(name)]
+	fieldsMu.RUnlock()
+	if ok {
+		return field(computer), true
+	}
+
+	for _, ea := range computer.ExtensionAttributes {
+		if strings.EqualFold(ea.Name, name) {
+			return []string{ea.Value}, true
+		}
+	}
+	return []string{""}, false
+}
+
+func (ev *Evaluator) now() time.Time {
+	if ev.Now != nil {
+		return ev.Now()
+	}
+	return time.Now()
+}
+
+func single(value func(c *computers.Computer) string) Field {
+	return func(c *computers.Computer) []string {
+		return []string{value(c)}
+	}
+}
+
+// partitions returns every partition of every disk of a computer
+func partitions(c *computers.Computer) []computers.Partition {
+	var list []computers.Partition
+	for _, disk := range c.Hardware.Storage {
+		list = append(list, disk.Partition...)
+	}
+	return list
+}
+
+// fileVaultStatus summarises the encryption of the partitions of a computer the way Jamf reports
+// FileVault 2 Status
+func fileVaultStatus(c *computers.Computer) string {
+	list := partitions(c)
+	if len(list) == 0 {
+		return "Not Available"
+	}
+
+	encrypted, bootEncrypted := 0, false
+	for _, p := range list {
+		if strings.EqualFold(p.Filevault2Status, "Encrypted") {
+			encrypted++
+			bootEncrypted = bootEncrypted || strings.EqualFold(p.PartitionType, "boot")
+		}
+	}
+
+	switch {
+	case encrypted == len(list):
+		return "All Partitions Encrypted"
+	case bootEncrypted:
+		return "Boot Partitions Encrypted"
+	case encrypted > 0:
+		return "Some Partitions Encrypted"
+	default:
+		return "No Partitions Encrypted"
+	}
+}
+
+func anyValue(values []string, match func(v string) bool) bool {
+	for _, v := range values {
+		if match(v) {
+			return true
+		}
+	}
+	return false
+}
+
+func containsFold(s string, substr string) bool {
+	return strings.Contains(strings.ToLower(s), strings.ToLower(substr))
+}
+
+// parseVersion splits a number or a dotted version such as 14.2.1 into its components
+func parseVersion(s string) ([]int, bool) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return nil, false
+	}
+
+	parts := strings.Split(s, ".")
+	version := make([]int, len(parts))
+	for i, part := range parts {
+		n, err := strconv.Atoi(part)
+		if err != nil {
+			return nil, false
+		}
+		version[i] = n
+	}
+	return version, true
+}
+
+// compareVersions returns -1, 0 or 1, missing components count as 0 so 14 equals 14.0
+func compareVersions(a []int, b []int) int {
+	for i := 0; i < len(a) || i < len(b); i++ {
+		var x, y int
+		if i < len(a) {
+			x = a[i]
+		}
+		if i < len(b) {
+			y = b[i]
+		}
+		if x != y {
+			if x < y {
+				return -1
+			}
+			return 1
+		}
+	}
+	return 0
+}
+
+func compareResult(searchType SearchType, cmp int) bool {
+	switch searchType {
+	case GreaterThan:
+		return cmp > 0
+	case LessThan:
+		return cmp < 0
+	case GreaterThanOrEqual:
+		return cmp >= 0
+	default:
+		return cmp <= 0
+	}
+}
+
+func parseDate(s string) (time.Time, bool) {
+	s = strings.TrimSpace(s)
+	for _, layout := range dateLayouts {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t, true
+		}
+	}
+	return time.Time{}, false
+}
@@ -0,0 +1,196 @@
+// Unless explicitly stated otherwise all files in this repository are licensed under the Apache-2.0
+// This product includes software developed at Datadog (https://www.datadoghq.com/). Copyright 2020 Datadog, Inc.
+
+package computergroups_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	jamf "github.com/trustero/jamf-api-client-go/classic/computergroups"
+	"github.com/trustero/jamf-api-client-go/classic/computers"
+)
+
+func evaluateComputer(id int, name string, osVersion string, department string) *computers.Computer {
+	c := &computers.Computer{}
+	c.General.Id = id
+	c.General.Name = name
+	c.General.SerialNumber = "C02" + name
+	c.General.ReportDate = "2024-03-01 10:00:00"
+	c.Hardware.Model = "MacBook Pro (16-inch, 2021)"
+	c.Hardware.OSVersion = osVersion
+	c.UserLocation.Department = department
+	c.UserLocation.Building = "HQ"
+	c.Software.Applications = []computers.ApplicationInformation{
+		{Name: "Google Chrome.app", Version: "122.0.6261.94"},
+		{Name: "Slack.app", Version: "4.36.140"},
+	}
+	c.ExtensionAttributes = []computers.ExtensionAttributes{{ID: 1, Name: "Battery Cycle Count", Type: "Integer", Value: "412"}}
+	c.Groups.Memberships = []string{"All Managed Clients"}
+	c.Hardware.Storage = []computers.Storage{{
+		Disk: "disk1",
+		Partition: []computers.Partition{
+			{Name: "Macintosh HD (Boot Partition)", PartitionType: "boot", Filevault2Status: "Encrypted"},
+		},
+	}}
+	return c
+}
+
+func TestEvaluateSearchTypes(t *testing.T) {
+	computer := evaluateComputer(1, "MBP-1", "14.10.1", "Engineering")
+	ev := jamf.NewEvaluator()
+	ev.Now = func() time.Time { return time.Date(2024, 3, 11, 0, 0, 0, 0, time.UTC) }
+
+	testCases := []struct {
+		name       string
+		searchType jamf.SearchType
+		value      string
+		matched    bool
+	}{
+		{"Department", jamf.Is, "engineering", true},
+		{"Department", jamf.IsNot, "Engineering", false},
+		{"Model", jamf.Like, "macbook pro", true},
+		{"Model", jamf.NotLike, "Mac mini", true},
+		{"Serial Number", jamf.MatchesRegex, "^c02", true},
+		{"Serial Number", jamf.DoesNotMatchRegex, "^C02", false},
+		{"Operating System Version", jamf.GreaterThan, "14.9", true},
+		{"Operating System Version", jamf.LessThan, "14.9", false},
+		{"Operating System Version", jamf.GreaterThanOrEqual, "14.10.1", true},
+		{"Operating System Version", jamf.LessThanOrEqual, "14", false},
+		{"Application Title", jamf.Has, "Slack.app", true},
+		{"Application Title", jamf.DoesNotHave, "Zoom.app", true},
+		{"Application Version", jamf.Like, "4.36", true},
+		{"Battery Cycle Count", jamf.GreaterThan, "400", true},
+		{"FileVault 2 Status", jamf.Is, "All Partitions Encrypted", true},
+		{"Last Inventory Update", jamf.MoreThanXDaysAgo, "7", true},
+		{"Last Inventory Update", jamf.LessThanXDaysAgo, "7", false},
+		{"Last Inventory Update", jamf.AfterDate, "2024-02-28", true},
+		{"Last Inventory Update", jamf.BeforeDate, "2024-02-28", false},
+		{"Computer Group", jamf.MemberOf, "All Managed Clients", true},
+		{"Computer Group", jamf.NotMemberOf, "All Managed Clients", false},
+	}
+
+	for _, tc := range testCases {
+		criteria := jamf.Criteria{{Name: tc.name, AndOr: jamf.And, SearchType: tc.searchType, Value: tc.value}}
+		eval, err := ev.Evaluate(criteria, computer)
+		assert.Nil(t, err, tc.name)
+		assert.Equal(t, tc.matched, eval.Member, "%s %s %s", tc.name, tc.searchType, tc.value)
+	}
+}
+
+func TestEvaluatePrecedence(t *testing.T) {
+	computer := evaluateComputer(1, "MBP-1", "13.6", "Sales")
+	ev := jamf.NewEvaluator()
+
+	// and binds tighter than or: false and false or true
+	criteria, err := jamf.NewCriteriaBuilder().
+		Where("Department", jamf.Is, "IT").
+		And("Operating System Version", jamf.Like, "14.").
+		Or("Building", jamf.Is, "HQ").
+		Build()
+	assert.Nil(t, err)
+	eval, err := ev.Evaluate(criteria, computer)
+	assert.Nil(t, err)
+	assert.True(t, eval.Member)
+
+	// parentheses group the or: false and (false or true)
+	criteria, err = jamf.NewCriteriaBuilder().
+		Where("Operating System Version", jamf.Like, "14.").
+		AndGroup(func(b *jamf.CriteriaBuilder) {
+			b.Where("Department", jamf.Is, "IT").Or("Building", jamf.Is, "HQ")
+		}).
+		Build()
+	assert.Nil(t, err)
+	eval, err = ev.Evaluate(criteria, computer)
+	assert.Nil(t, err)
+	assert.False(t, eval.Member)
+	assert.Len(t, eval.Results, 3)
+	assert.Equal(t, "MBP-1 (1): not a member\n"+
+		"  Operating System Version like 14.: false (13.6)\n"+
+		"  and (Department is IT: false (Sales)\n"+
+		"  or Building is HQ: true (HQ))\n", eval.Explain())
+}
+
+func TestEvaluateErrors(t *testing.T) {
+	computer := evaluateComputer(1, "MBP-1", "14.4", "IT")
+	ev := jamf.NewEvaluator()
+
+	// a missing extension attribute is compared as empty rather than failing the evaluation
+	eval, err := ev.Evaluate(jamf.Criteria{{Name: "Printer Name", AndOr: jamf.And, SearchType: jamf.IsNot, Value: "x"}}, computer)
+	assert.Nil(t, err)
+	assert.True(t, eval.Member)
+	assert.Equal(t, []string{""}, eval.Results[0].Values)
+	assert.Equal(t, "Printer Name is not an inventory field or an extension attribute of the computer", eval.Results[0].Reason)
+
+	for _, searchType := range []jamf.SearchType{jamf.Is, jamf.Like} {
+		eval, err = ev.Evaluate(jamf.Criteria{{Name: "Printer Name", AndOr: jamf.And, SearchType: searchType, Value: ""}}, computer)
+		assert.Nil(t, err)
+		assert.True(t, eval.Member, searchType)
+	}
+	eval, err = ev.Evaluate(jamf.Criteria{{Name: "Printer Name", AndOr: jamf.And, SearchType: jamf.Is, Value: "x"}}, computer)
+	assert.Nil(t, err)
+	assert.False(t, eval.Member)
+
+	// parentheses are checked once the criteria are ordered by priority
+	eval, err = ev.Evaluate(jamf.Criteria{
+		{Name: "Department", Priority: 1, AndOr: jamf.Or, SearchType: jamf.Is, Value: "IT", ClosingParen: true},
+		{Name: "Department", Priority: 0, AndOr: jamf.And, SearchType: jamf.Is, Value: "Sales", OpeningParen: true},
+	}, computer)
+	assert.Nil(t, err)
+	assert.True(t, eval.Member)
+
+	_, err = ev.Evaluate(jamf.Criteria{{Name: "Model", AndOr: jamf.And, SearchType: jamf.MatchesRegex, Value: "("}}, computer)
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "is not a valid regex")
+
+	eval, err = ev.Evaluate(jamf.Criteria{{Name: "Model", AndOr: jamf.And, SearchType: jamf.GreaterThan, Value: "new"}}, computer)
+	assert.Nil(t, err)
+	assert.False(t, eval.Member)
+	assert.Equal(t, "new is not a number or version", eval.Results[0].Reason)
+
+	jamf.RegisterField("Printer Name", func(c *computers.Computer) []string { return []string{"Lobby"} })
+	eval, err = ev.Evaluate(jamf.Criteria{{Name: "Printer Name", AndOr: jamf.And, SearchType: jamf.Is, Value: "lobby"}}, computer)
+	assert.Nil(t, err)
+	assert.True(t, eval.Member)
+}
+
+func TestPreview(t *testing.T) {
+	list := []*computers.Computer{
+		evaluateComputer(1, "MBP-1", "14.4", "Engineering"),
+		evaluateComputer(2, "MBP-2", "13.6", "Engineering"),
+		evaluateComputer(3, "MBP-3", "14.4", "Sales"),
+	}
+	ev := &jamf.Evaluator{Groups: func(c *computers.Computer) []string {
+		if c.General.Id == 3 {
+			return []string{"Pilot"}
+		}
+		return nil
+	}}
+
+	current, err := jamf.NewCriteriaBuilder().Where("Department", jamf.Is, "Engineering").Build()
+	assert.Nil(t, err)
+	preview, err := ev.Preview(current, list)
+	assert.Nil(t, err)
+	assert.Len(t, preview.Members, 2)
+	assert.Len(t, preview.NonMembers, 1)
+
+	proposed, err := jamf.NewCriteriaBuilder().
+		Where("Operating System Version", jamf.GreaterThanOrEqual, "14").
+		AndGroup(func(b *jamf.CriteriaBuilder) {
+			b.Where("Department", jamf.Is, "Engineering").Or("Computer Group", jamf.MemberOf, "Pilot")
+		}).
+		Build()
+	assert.Nil(t, err)
+	change, err := ev.PreviewChange(current, proposed, list)
+	assert.Nil(t, err)
+	assert.Len(t, change.Added, 1)
+	assert.Equal(t, 3, change.Added[0].Computer.General.Id)
+	assert.Len(t, change.Removed, 1)
+	assert.Equal(t, 2, change.Removed[0].Computer.General.Id)
+	assert.True(t, strings.HasPrefix(change.Removed[0].Explain(), "MBP-2 (2): not a member\n"))
+
+	_, err = ev.Preview(jamf.Criteria{{Name: "", AndOr: jamf.And, SearchType: jamf.Is}}, list)
+	assert.NotNil(t, err)
+}
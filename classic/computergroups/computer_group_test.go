@@ -0,0 +1,177 @@
+// Unless explicitly stated otherwise all files in this repository are licensed under the Apache-2.0
+// This product includes software developed at Datadog (https://www.datadoghq.com/). Copyright 2020 Datadog, Inc.
+
+package computergroups_test
+
+import (
+	"context"
+	"encoding/xml"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/trustero/jamf-api-client-go/classic/client"
+	jamf "github.com/trustero/jamf-api-client-go/classic/computergroups"
+)
+
+var COMPUTER_GROUPS_API_BASE_ENDPOINT = "/JSSResource/computergroups"
+
+func computerGroupsResponseMocks(t *testing.T, payloads *[]string) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.RequestURI {
+		case COMPUTER_GROUPS_API_BASE_ENDPOINT:
+			fmt.Fprint(w, `{
+				"computer_groups": [
+					{"id": 1, "name": "All Managed Clients", "is_smart": true},
+					{"id": 6, "name": "Pilot", "is_smart": false}
+				]
+			}`)
+		case fmt.Sprintf("%s/id/1", COMPUTER_GROUPS_API_BASE_ENDPOINT), fmt.Sprintf("%s/name/All%%20Managed%%20Clients", COMPUTER_GROUPS_API_BASE_ENDPOINT):
+			fmt.Fprint(w, `{
+				"computer_group": {
+					"id": 1,
+					"name": "All Managed Clients",
+					"is_smart": true,
+					"site": {"id": -1, "name": "None"},
+					"criteria": [
+						{"name": "Last Check-in", "priority": 0, "and_or": "and", "search_type": "less than x days ago", "value": "7", "opening_paren": false, "closing_paren": false}
+					],
+					"computers": [
+						{"id": 12, "name": "mac-12", "mac_address": "00:00:00:00:00:12", "alt_mac_address": "", "serial_number": "C02X"}
+					]
+				}
+			}`)
+		case fmt.Sprintf("%s/id/-1", COMPUTER_GROUPS_API_BASE_ENDPOINT), fmt.Sprintf("%s/id/6", COMPUTER_GROUPS_API_BASE_ENDPOINT), fmt.Sprintf("%s/name/Pilot", COMPUTER_GROUPS_API_BASE_ENDPOINT):
+			data, err := ioutil.ReadAll(r.Body)
+			assert.Nil(t, err)
+			*payloads = append(*payloads, r.Method+" "+string(data))
+			w.Header().Set("Content-Type", "application/xml")
+			fmt.Fprint(w, `<computer_group><id>6</id></computer_group>`)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+}
+
+func TestQueryComputerGroups(t *testing.T) {
+	testServer := computerGroupsResponseMocks(t, &[]string{})
+	defer testServer.Close()
+	j, err := jamf.NewService(testServer.URL, "fake-username", "mock-password-cool", nil)
+	assert.Nil(t, err)
+
+	groups, _, err := j.ComputerGroups()
+	assert.Nil(t, err)
+	assert.Equal(t, []jamf.BasicComputerGroupInfo{{ID: 1, Name: "All Managed Clients", IsSmart: true}, {ID: 6, Name: "Pilot"}}, groups)
+
+	all, err := j.Iterate().All(context.Background())
+	assert.Nil(t, err)
+	assert.Equal(t, groups, all)
+}
+
+func TestQueryComputerGroupDetails(t *testing.T) {
+	testServer := computerGroupsResponseMocks(t, &[]string{})
+	defer testServer.Close()
+	j, err := jamf.NewService(testServer.URL, "fake-username", "mock-password-cool", nil)
+	assert.Nil(t, err)
+
+	group, _, err := j.ComputerGroupDetails(1)
+	assert.Nil(t, err)
+	assert.True(t, *group.IsSmart)
+	assert.Equal(t, &jamf.Criterion{Name: "Last Check-in", AndOr: jamf.And, SearchType: jamf.LessThanXDaysAgo, Value: "7"}, group.Criteria[0])
+	assert.Equal(t, "C02X", group.Computers[0].SerialNumber)
+
+	group, _, err = j.ComputerGroupDetails("All Managed Clients")
+	assert.Nil(t, err)
+	assert.Equal(t, 1, group.ID)
+
+	_, _, err = j.ComputerGroupDetails(404)
+	assert.NotNil(t, err)
+}
+
+func TestCreateSmartComputerGroup(t *testing.T) {
+	payloads := []string{}
+	testServer := computerGroupsResponseMocks(t, &payloads)
+	defer testServer.Close()
+	j, err := jamf.NewService(testServer.URL, "fake-username", "mock-password-cool", nil)
+	assert.Nil(t, err)
+
+	criteria, err := jamf.NewCriteriaBuilder().
+		Where("Operating System Version", jamf.Like, "14.").
+		OrGroup(func(b *jamf.CriteriaBuilder) {
+			b.Where("Computer Group", jamf.MemberOf, "Engineering").And("Department", jamf.Is, "IT")
+		}).
+		Build()
+	assert.Nil(t, err)
+
+	created, _, err := j.CreateComputerGroup(&jamf.ComputerGroup{Name: "Sonoma", IsSmart: client.Bool(true), Criteria: criteria})
+	assert.Nil(t, err)
+	assert.Equal(t, 6, created.ID)
+
+	assert.Equal(t, 1, len(payloads))
+	assert.Equal(t, "POST <computer_group><name>Sonoma</name><is_smart>true</is_smart><criteria>"+
+		"<criterion><name>Operating System Version</name><priority>0</priority><and_or>and</and_or><search_type>like</search_type><value>14.</value><opening_paren>false</opening_paren><closing_paren>false</closing_paren></criterion>"+
+		"<criterion><name>Computer Group</name><priority>1</priority><and_or>or</and_or><search_type>member of</search_type><value>Engineering</value><opening_paren>true</opening_paren><closing_paren>false</closing_paren></criterion>"+
+		"<criterion><name>Department</name><priority>2</priority><and_or>and</and_or><search_type>is</search_type><value>IT</value><opening_paren>false</opening_paren><closing_paren>true</closing_paren></criterion>"+
+		"</criteria></computer_group>", payloads[0])
+
+	// validation runs before anything is sent
+	_, _, err = j.CreateComputerGroup(&jamf.ComputerGroup{Name: "Broken", IsSmart: client.Bool(true), Criteria: jamf.Criteria{{Name: "Model", AndOr: jamf.And, SearchType: "sounds like"}}})
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "computer group validation failed: Broken")
+	assert.Equal(t, 1, len(payloads))
+}
+
+func TestEditStaticComputerGroup(t *testing.T) {
+	payloads := []string{}
+	testServer := computerGroupsResponseMocks(t, &payloads)
+	defer testServer.Close()
+	j, err := jamf.NewService(testServer.URL, "fake-username", "mock-password-cool", nil)
+	assert.Nil(t, err)
+
+	_, _, err = j.AddComputers("Pilot", 12, 13)
+	assert.Nil(t, err)
+	_, _, err = j.RemoveComputers(6, 14)
+	assert.Nil(t, err)
+	_, _, err = j.RemoveComputers(6)
+	assert.NotNil(t, err)
+
+	_, _, err = j.UpdateComputerGroup(6, &jamf.ComputerGroup{Name: "Pilot", Computers: []*jamf.GroupComputer{{ID: 12}}})
+	assert.Nil(t, err)
+
+	// a rename leaves the type of the group unchanged
+	_, _, err = j.UpdateComputerGroup(6, &jamf.ComputerGroup{Name: "Managed Clients"})
+	assert.Nil(t, err)
+
+	// the name may be left out and the computers Jamf lists for smart groups are not sent back
+	smart := &jamf.ComputerGroup{IsSmart: client.Bool(true), Criteria: jamf.Criteria{{Name: "Model", AndOr: jamf.And, SearchType: jamf.Like, Value: "MacBook"}},
+		Computers: []*jamf.GroupComputer{{ID: 12}}}
+	_, _, err = j.UpdateComputerGroup(6, smart)
+	assert.Nil(t, err)
+	assert.Equal(t, 1, len(smart.Computers))
+
+	_, _, err = j.DeleteComputerGroup(6)
+	assert.Nil(t, err)
+
+	assert.Equal(t, []string{
+		"PUT <computer_group><computer_additions><computer><id>12</id></computer><computer><id>13</id></computer></computer_additions></computer_group>",
+		"PUT <computer_group><computer_deletions><computer><id>14</id></computer></computer_deletions></computer_group>",
+		"PUT <computer_group><name>Pilot</name><computers><computer><id>12</id></computer></computers></computer_group>",
+		"PUT <computer_group><name>Managed Clients</name></computer_group>",
+		"PUT <computer_group><is_smart>true</is_smart><criteria><criterion><name>Model</name><priority>0</priority><and_or>and</and_or>" +
+			"<search_type>like</search_type><value>MacBook</value><opening_paren>false</opening_paren><closing_paren>false</closing_paren></criterion></criteria></computer_group>",
+		"DELETE ",
+	}, payloads)
+}
+
+func TestComputerGroupXMLRoundTrip(t *testing.T) {
+	group := &jamf.ComputerGroup{Name: "Sonoma", IsSmart: client.Bool(true), Criteria: jamf.Criteria{{Name: "Model", AndOr: jamf.And, SearchType: jamf.Like, Value: "MacBook"}}}
+	data, err := xml.Marshal(group)
+	assert.Nil(t, err)
+
+	decoded := &jamf.ComputerGroup{}
+	assert.Nil(t, xml.Unmarshal(data, decoded))
+	assert.Equal(t, group.Criteria, decoded.Criteria)
+}
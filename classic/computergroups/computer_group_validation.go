@@ -0,0 +1,110 @@
+// Unless explicitly stated otherwise all files in this repository are licensed under the Apache-2.0
+// This product includes software developed at Datadog (https://www.datadoghq.com/). Copyright 2020 Datadog, Inc.
+
+package computergroups
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/trustero/jamf-api-client-go/internal/groups"
+)
+
+// kind describes computer groups to the shared group requests and validation
+var kind = &groups.Kind[ComputerGroup]{
+	Group:   "computer group",
+	Members: "computers",
+	Fields: func(g *ComputerGroup) groups.Fields {
+		return groups.Fields{
+			Name:             g.Name,
+			IsSmart:          g.IsSmart,
+			Criteria:         len(g.Criteria),
+			Members:          len(g.Computers),
+			ValidateCriteria: g.Criteria.Validate,
+		}
+	},
+	ClearMembers: func(g *ComputerGroup) {
+		g.Computers = nil
+	},
+}
+
+// ValidateComputerGroup orchestrates the validation of a computer group about to be created,
+// see UpdateComputerGroup for updates
+func ValidateComputerGroup(g *ComputerGroup) error {
+	return kind.Validate(g)
+}
+
+// Validate will validate every criterion and that parentheses are balanced. Jamf does not
+// support nested parentheses
+func (c Criteria) Validate() error {
+	open := false
+	for i, criterion := range c {
+		if err := criterion.Validate(); err != nil {
+			return fmt.Errorf("criterion %d: %s", i, err.Error())
+		}
+
+		if criterion.OpeningParen {
+			if open {
+				return fmt.Errorf("criterion %d: nested parentheses are not supported", i)
+			}
+			open = true
+		}
+
+		if criterion.ClosingParen {
+			if !open {
+				return fmt.Errorf("criterion %d: closing parenthesis without an opening parenthesis", i)
+			}
+			open = false
+		}
+	}
+
+	if open {
+		return fmt.Errorf("criteria have an unclosed parenthesis")
+	}
+	return nil
+}
+
+// Validate will validate that a criterion names an inventory field and uses a supported join and search type
+func (c *Criterion) Validate() error {
+	if strings.TrimSpace(c.Name) == "" {
+		return fmt.Errorf("criterion name is required")
+	}
+
+	if err := c.AndOr.Validate(); err != nil {
+		return err
+	}
+
+	if err := c.SearchType.Validate(); err != nil {
+		return err
+	}
+
+	switch c.SearchType {
+	case MemberOf, NotMemberOf:
+		if strings.TrimSpace(c.Value) == "" {
+			return fmt.Errorf("%s %s requires a group name", c.Name, c.SearchType)
+		}
+	}
+	return nil
+}
+
+// Validate will validate that a join is either and or or
+func (a AndOr) Validate() error {
+	switch strings.ToLower(string(a)) {
+	case "and", "or":
+		return nil
+	default:
+		return fmt.Errorf("%s is not a valid criterion join must be of type [ and, or ]", a)
+	}
+}
+
+// Validate will validate that a search type is supported by Jamf
+func (s SearchType) Validate() error {
+	names := make([]string, len(SearchTypes))
+	for i, t := range SearchTypes {
+		if strings.EqualFold(string(s), string(t)) {
+			return nil
+		}
+		names[i] = string(t)
+	}
+	return fmt.Errorf("%s is not a valid search type must be of type [ %s ]", s, strings.Join(names, ", "))
+}
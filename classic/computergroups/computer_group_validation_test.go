@@ -0,0 +1,77 @@
+// Unless explicitly stated otherwise all files in this repository are licensed under the Apache-2.0
+// This product includes software developed at Datadog (https://www.datadoghq.com/). Copyright 2020 Datadog, Inc.
+
+package computergroups_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/trustero/jamf-api-client-go/classic/client"
+	jamf "github.com/trustero/jamf-api-client-go/classic/computergroups"
+)
+
+func TestCriteriaBuilder(t *testing.T) {
+	criteria, err := jamf.NewCriteriaBuilder().
+		Where("Last Check-in", jamf.LessThanXDaysAgo, "7").
+		And("Architecture Type", jamf.Is, "arm64").
+		AndGroup(func(b *jamf.CriteriaBuilder) {}).
+		Build()
+	assert.Nil(t, err)
+	assert.Equal(t, 2, len(criteria))
+	assert.Equal(t, 1, criteria[1].Priority)
+	assert.False(t, criteria[1].OpeningParen)
+
+	_, err = jamf.NewCriteriaBuilder().Where("Computer Group", jamf.NotMemberOf, "").Build()
+	assert.NotNil(t, err)
+	assert.Equal(t, "criterion 0: Computer Group not member of requires a group name", err.Error())
+}
+
+func TestValidateCriteriaParentheses(t *testing.T) {
+	err := jamf.Criteria{
+		{Name: "A", AndOr: jamf.And, SearchType: jamf.Is, OpeningParen: true},
+		{Name: "B", AndOr: jamf.Or, SearchType: jamf.Is, OpeningParen: true},
+	}.Validate()
+	assert.NotNil(t, err)
+	assert.Equal(t, "criterion 1: nested parentheses are not supported", err.Error())
+
+	err = jamf.Criteria{{Name: "A", AndOr: jamf.And, SearchType: jamf.Is, ClosingParen: true}}.Validate()
+	assert.NotNil(t, err)
+	assert.Equal(t, "criterion 0: closing parenthesis without an opening parenthesis", err.Error())
+
+	err = jamf.Criteria{{Name: "A", AndOr: jamf.And, SearchType: jamf.Is, OpeningParen: true}}.Validate()
+	assert.NotNil(t, err)
+	assert.Equal(t, "criteria have an unclosed parenthesis", err.Error())
+}
+
+func TestValidateCriterion(t *testing.T) {
+	err := (&jamf.Criterion{Name: "Model", AndOr: "xor", SearchType: jamf.Is}).Validate()
+	assert.NotNil(t, err)
+	assert.Equal(t, "xor is not a valid criterion join must be of type [ and, or ]", err.Error())
+
+	err = (&jamf.Criterion{Name: "Model", AndOr: jamf.And, SearchType: "sounds like"}).Validate()
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "sounds like is not a valid search type must be of type [ is, is not, like,")
+
+	err = (&jamf.Criterion{AndOr: jamf.And, SearchType: jamf.Is}).Validate()
+	assert.NotNil(t, err)
+	assert.Equal(t, "criterion name is required", err.Error())
+
+	assert.Nil(t, (&jamf.Criterion{Name: "Model", AndOr: "AND", SearchType: "Greater Than"}).Validate())
+}
+
+func TestValidateComputerGroup(t *testing.T) {
+	err := jamf.ValidateComputerGroup(&jamf.ComputerGroup{})
+	assert.NotNil(t, err)
+	assert.Equal(t, "computer group name is required", err.Error())
+
+	err = jamf.ValidateComputerGroup(&jamf.ComputerGroup{Name: "Pilot", Criteria: jamf.Criteria{{Name: "Model"}}})
+	assert.NotNil(t, err)
+	assert.Equal(t, "static computer group Pilot can not have criteria", err.Error())
+
+	err = jamf.ValidateComputerGroup(&jamf.ComputerGroup{Name: "Smart", IsSmart: client.Bool(true), Computers: []*jamf.GroupComputer{{ID: 1}}})
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "smart computer group Smart can not list computers")
+
+	assert.Nil(t, jamf.ValidateComputerGroup(&jamf.ComputerGroup{Name: "Pilot", Computers: []*jamf.GroupComputer{{ID: 1}}}))
+}
@@ -7,6 +7,7 @@ import (
 	"context"
 	"fmt"
 	"github.com/trustero/jamf-api-client-go/classic/client"
+	"github.com/trustero/jamf-api-client-go/pager"
 	"net/http"
 
 	"github.com/pkg/errors"
@@ -19,7 +20,19 @@ type ListResponse struct {
 
 // Computers returns all enrolled computer devices
 func (j *Service) List() (computers []ComputerNameId, response *http.Response, err error) {
-	req, err := http.NewRequestWithContext(context.Background(), "GET", j.client.Endpoint, nil)
+	return j.list(context.Background())
+}
+
+// Iterate returns an iterator over all enrolled computer devices
+func (j *Service) Iterate() *pager.Pager[ComputerNameId] {
+	return pager.Single(func(ctx context.Context) ([]ComputerNameId, error) {
+		computers, _, err := j.list(ctx)
+		return computers, err
+	})
+}
+
+func (j *Service) list(ctx context.Context) (computers []ComputerNameId, response *http.Response, err error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", j.client.Endpoint, nil)
 	if err != nil {
 		return nil, nil, errors.Wrap(err, "error building JAMF computer query request")
 	}
@@ -0,0 +1,332 @@
+// Unless explicitly stated otherwise all files in this repository are licensed under the Apache-2.0
+// This product includes software developed at Datadog (https://www.datadoghq.com/). Copyright 2020 Datadog, Inc.
+
+package computers
+
+import (
+	"bytes"
+	"context"
+	"encoding/xml"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/trustero/jamf-api-client-go/classic/client"
+	"github.com/trustero/jamf-api-client-go/classic/computerextensionattributes"
+)
+
+// ExtensionAttributeDateLayout is the layout Jamf uses for Date extension attribute values
+const ExtensionAttributeDateLayout = "2006-01-02 15:04:05"
+
+// DefaultBulkConcurrency is the number of computers updated at once when no concurrency is provided
+const DefaultBulkConcurrency = 4
+
+// extensionAttributeDateLayouts are the layouts accepted when parsing a Date extension attribute value
+var extensionAttributeDateLayouts = []string{ExtensionAttributeDateLayout, "2006-01-02"}
+
+// ExtensionAttributeUpdate is a single extension attribute value written to a computer.
+// Either the Id or the Name of the extension attribute must be set
+type ExtensionAttributeUpdate struct {
+	XMLName xml.Name `xml:"extension_attribute"`
+	ID      int      `xml:"id,omitempty"`
+	Name    string   `xml:"name,omitempty"`
+	Value   string   `xml:"value"`
+}
+
+type extensionAttributesUpdate struct {
+	XMLName             xml.Name                    `xml:"computer"`
+	ExtensionAttributes []*ExtensionAttributeUpdate `xml:"extension_attributes>extension_attribute"`
+}
+
+// UpdateExtensionAttributes writes extension attribute values to a computer given its Id.
+// Values are written as is, see ExtensionAttributeValues for writes checked against the definition
+func (j *Service) UpdateExtensionAttributes(identifier int, values ...*ExtensionAttributeUpdate) (response *http.Response, err error) {
+	return j.updateExtensionAttributes(context.Background(), identifier, values...)
+}
+
+func (j *Service) updateExtensionAttributes(ctx context.Context, identifier int, values ...*ExtensionAttributeUpdate) (response *http.Response, err error) {
+	ep := j.client.IdEndpoint(identifier)
+	if len(values) == 0 {
+		err = fmt.Errorf("no extension attribute values provided for computer: %v (%s)", identifier, ep)
+		return
+	}
+
+	bodyContent, err := xml.Marshal(&extensionAttributesUpdate{ExtensionAttributes: values})
+	if err != nil {
+		err = errors.Wrapf(err, "error building JAMF extension attribute payload for computer: %v", identifier)
+		return
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "PUT", ep, bytes.NewReader(bodyContent))
+	if err != nil {
+		err = errors.Wrapf(err, "error building JAMF extension attribute update request for computer: %v (%s)", identifier, ep)
+		return
+	}
+
+	res := &GeneralInformation{}
+	if response, err = client.MakeAPIrequest(j.client, req, res); err != nil {
+		err = errors.Wrapf(err, "unable to update extension attributes for computer: %v (%s)", identifier, ep)
+	}
+	return
+}
+
+// ExtensionAttributeValue is an extension attribute value read from a computer and coerced
+// using the extension attribute definition's data type
+type ExtensionAttributeValue struct {
+	ID       int
+	Name     string
+	DataType string
+	// Raw is the value exactly as returned by Jamf
+	Raw string
+	// Value is an int for Integer, a time.Time for Date and a string for String data types.
+	// It is nil when the value is empty or could not be coerced
+	Value interface{}
+	// Err holds the reason the raw value could not be coerced
+	Err error
+}
+
+// Int returns the value of an Integer extension attribute
+func (v *ExtensionAttributeValue) Int() (int, bool) {
+	i, ok := v.Value.(int)
+	return i, ok
+}
+
+// Time returns the value of a Date extension attribute
+func (v *ExtensionAttributeValue) Time() (time.Time, bool) {
+	t, ok := v.Value.(time.Time)
+	return t, ok
+}
+
+// String returns the raw value of the extension attribute
+func (v *ExtensionAttributeValue) String() string {
+	return v.Raw
+}
+
+// CoerceExtensionAttributeValue converts a raw extension attribute value to an int, time.Time
+// or string based on the data type. Empty values are returned as nil
+func CoerceExtensionAttributeValue(dataType string, raw string) (interface{}, error) {
+	trimmed := strings.TrimSpace(raw)
+	switch strings.ToLower(dataType) {
+	case "integer", "number":
+		if trimmed == "" {
+			return nil, nil
+		}
+		i, err := strconv.Atoi(trimmed)
+		if err != nil {
+			return nil, fmt.Errorf("%s is not a valid value for an extension attribute of type %s", raw, dataType)
+		}
+		return i, nil
+	case "date":
+		if trimmed == "" {
+			return nil, nil
+		}
+		for _, layout := range extensionAttributeDateLayouts {
+			if t, err := time.Parse(layout, trimmed); err == nil {
+				return t, nil
+			}
+		}
+		return nil, fmt.Errorf("%s is not a valid value for an extension attribute of type %s must be formatted as %s", raw, dataType, ExtensionAttributeDateLayout)
+	default:
+		return raw, nil
+	}
+}
+
+// FormatExtensionAttributeValue converts a value to the string Jamf expects for the data type.
+// Integer values accept an int or a numeric string, Date values accept a time.Time or a string
+// in the Jamf date layout and String values accept a string or any fmt.Stringer
+func FormatExtensionAttributeValue(dataType string, value interface{}) (string, error) {
+	if value == nil {
+		return "", nil
+	}
+
+	switch strings.ToLower(dataType) {
+	case "integer", "number":
+		switch v := value.(type) {
+		case int:
+			return strconv.Itoa(v), nil
+		case int64:
+			return strconv.FormatInt(v, 10), nil
+		case string:
+			if _, err := CoerceExtensionAttributeValue(dataType, v); err != nil {
+				return "", err
+			}
+			return strings.TrimSpace(v), nil
+		}
+	case "date":
+		switch v := value.(type) {
+		case time.Time:
+			return v.Format(ExtensionAttributeDateLayout), nil
+		case string:
+			if _, err := CoerceExtensionAttributeValue(dataType, v); err != nil {
+				return "", err
+			}
+			return strings.TrimSpace(v), nil
+		}
+	default:
+		switch v := value.(type) {
+		case string:
+			return v, nil
+		case fmt.Stringer:
+			return v.String(), nil
+		}
+	}
+	return "", fmt.Errorf("value of type (%T) can not be used for an extension attribute of type %s", value, dataType)
+}
+
+// ExtensionAttributeValues reads and writes computer extension attribute values using the
+// extension attribute definitions to coerce values to the correct type
+type ExtensionAttributeValues struct {
+	computers   *Service
+	attributes  *computerextensionattributes.Service
+	mu          sync.Mutex
+	definitions map[interface{}]*computerextensionattributes.ComputerExtensionAttribute
+}
+
+// NewExtensionAttributeValues returns a helper for reading and writing extension attribute values.
+// Extension attribute definitions are cached for the lifetime of the helper
+func NewExtensionAttributeValues(computers *Service, attributes *computerextensionattributes.Service) *ExtensionAttributeValues {
+	return &ExtensionAttributeValues{
+		computers:   computers,
+		attributes:  attributes,
+		definitions: map[interface{}]*computerextensionattributes.ComputerExtensionAttribute{},
+	}
+}
+
+// Definition returns the extension attribute definition given its Id or Name
+func (v *ExtensionAttributeValues) Definition(identifier interface{}) (*computerextensionattributes.ComputerExtensionAttribute, error) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	if def, ok := v.definitions[identifier]; ok {
+		return def, nil
+	}
+
+	details, err := v.attributes.ComputerExtensionAttributeDetails(identifier)
+	if err != nil {
+		return nil, err
+	}
+	if details.Details == nil {
+		return nil, fmt.Errorf("no computer extension attribute definition returned for: %v", identifier)
+	}
+
+	def := details.Details
+	v.definitions[def.ID] = def
+	v.definitions[def.Name] = def
+	return def, nil
+}
+
+// Get returns every extension attribute value on a computer given its Id coerced using the
+// extension attribute definitions. When a definition can not be found the type reported on
+// the computer record is used instead
+func (v *ExtensionAttributeValues) Get(identifier int) ([]*ExtensionAttributeValue, error) {
+	computer, _, err := v.computers.GetById(identifier)
+	if err != nil {
+		return nil, err
+	}
+
+	values := make([]*ExtensionAttributeValue, 0, len(computer.ExtensionAttributes))
+	for _, ea := range computer.ExtensionAttributes {
+		dataType := ea.Type
+		if def, err := v.Definition(ea.ID); err == nil && def.DataType != "" {
+			dataType = def.DataType
+		}
+
+		value := &ExtensionAttributeValue{ID: ea.ID, Name: ea.Name, DataType: dataType, Raw: ea.Value}
+		value.Value, value.Err = CoerceExtensionAttributeValue(dataType, ea.Value)
+		values = append(values, value)
+	}
+	return values, nil
+}
+
+// Set writes a value for the extension attribute given its Id or Name to a computer given its Id.
+// The value is checked against the extension attribute definition's data type before it is written
+func (v *ExtensionAttributeValues) Set(computer int, attribute interface{}, value interface{}) (*http.Response, error) {
+	update, err := v.update(attribute, value)
+	if err != nil {
+		return nil, err
+	}
+	return v.computers.UpdateExtensionAttributes(computer, update)
+}
+
+func (v *ExtensionAttributeValues) update(attribute interface{}, value interface{}) (*ExtensionAttributeUpdate, error) {
+	def, err := v.Definition(attribute)
+	if err != nil {
+		return nil, errors.Wrapf(err, "unable to find extension attribute definition: %v", attribute)
+	}
+
+	formatted, err := FormatExtensionAttributeValue(def.DataType, value)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid value for extension attribute: %s", def.Name)
+	}
+	return &ExtensionAttributeUpdate{ID: def.ID, Value: formatted}, nil
+}
+
+// BulkResult is the outcome of writing an extension attribute value to a single computer
+type BulkResult struct {
+	ComputerID int
+	Response   *http.Response
+	Err        error
+}
+
+// BulkReport holds the outcome for every computer in a bulk extension attribute write
+type BulkReport struct {
+	Results []*BulkResult
+}
+
+// Failed returns the results for computers that were not updated
+func (r *BulkReport) Failed() []*BulkResult {
+	var failed []*BulkResult
+	for _, res := range r.Results {
+		if res.Err != nil {
+			failed = append(failed, res)
+		}
+	}
+	return failed
+}
+
+// SetBulk writes the same extension attribute value to many computers updating at most concurrency
+// computers at once. The value is checked against the definition once before any computer is updated,
+// after that a failure on one computer does not stop the others and is recorded in the report
+func (v *ExtensionAttributeValues) SetBulk(ctx context.Context, attribute interface{}, value interface{}, computers []int, concurrency int) (*BulkReport, error) {
+	update, err := v.update(attribute, value)
+	if err != nil {
+		return nil, err
+	}
+
+	if concurrency <= 0 {
+		concurrency = DefaultBulkConcurrency
+	}
+
+	report := &BulkReport{Results: make([]*BulkResult, len(computers))}
+	sem := make(chan struct{}, concurrency)
+	wg := sync.WaitGroup{}
+	for i, id := range computers {
+		report.Results[i] = &BulkResult{ComputerID: id}
+		// wait for a free worker unless the context is done first
+		select {
+		case <-ctx.Done():
+			report.Results[i].Err = ctx.Err()
+			continue
+		case sem <- struct{}{}:
+		}
+		if err := ctx.Err(); err != nil {
+			<-sem
+			report.Results[i].Err = err
+			continue
+		}
+
+		wg.Add(1)
+		go func(res *BulkResult) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			res.Response, res.Err = v.computers.updateExtensionAttributes(ctx, res.ComputerID, update)
+		}(report.Results[i])
+	}
+	wg.Wait()
+
+	return report, nil
+}
@@ -0,0 +1,217 @@
+// Unless explicitly stated otherwise all files in this repository are licensed under the Apache-2.0
+// This product includes software developed at Datadog (https://www.datadoghq.com/). Copyright 2020 Datadog, Inc.
+package computers_test
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/trustero/jamf-api-client-go/classic/computerextensionattributes"
+	jamf "github.com/trustero/jamf-api-client-go/classic/computers"
+)
+
+var EXT_ATTR_API_BASE_ENDPOINT = "/JSSResource/computerextensionattributes"
+
+type extAttrValueMock struct {
+	mu      sync.Mutex
+	updates map[string]string
+	// busy is signalled when computer 600 is updated, the update only ends once release is closed
+	busy    chan struct{}
+	release chan struct{}
+}
+
+func (m *extAttrValueMock) server() *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.RequestURI {
+		case fmt.Sprintf("%s/id/6", EXT_ATTR_API_BASE_ENDPOINT):
+			fmt.Fprintf(w, `{"computer_extension_attribute": {"id": 6, "name": "osquery Status", "data_type": "String"}}`)
+		case fmt.Sprintf("%s/id/7", EXT_ATTR_API_BASE_ENDPOINT), fmt.Sprintf("%s/name/Uptime%sDays", EXT_ATTR_API_BASE_ENDPOINT, "%20"):
+			fmt.Fprintf(w, `{"computer_extension_attribute": {"id": 7, "name": "Uptime Days", "data_type": "Integer"}}`)
+		case fmt.Sprintf("%s/id/8", EXT_ATTR_API_BASE_ENDPOINT):
+			fmt.Fprintf(w, `{"computer_extension_attribute": {"id": 8, "name": "Last Patched", "data_type": "Date"}}`)
+		case fmt.Sprintf("%s/id/82", COMPUTER_API_BASE_ENDPOINT):
+			if r.Method == "PUT" {
+				data, _ := ioutil.ReadAll(r.Body)
+				m.mu.Lock()
+				m.updates[r.RequestURI] = string(data)
+				m.mu.Unlock()
+				fmt.Fprintf(w, `{"id": 82}`)
+				return
+			}
+			fmt.Fprintf(w, `{
+				"computer": {
+					"general": {"id": 82, "name": "Go Service Test Machine"},
+					"extension_attributes": [
+						{"id": 6, "name": "osquery Status", "type": "String", "value": "OSquery NOT Running"},
+						{"id": 7, "name": "Uptime Days", "type": "Number", "value": "12"},
+						{"id": 8, "name": "Last Patched", "type": "Date", "value": "2021-03-04 10:30:00"},
+						{"id": 9, "name": "Removed Attribute", "type": "Number", "value": "not a number"}
+					]
+				}
+			}`)
+		default:
+			if r.Method == "PUT" && m.busy != nil && r.RequestURI == fmt.Sprintf("%s/id/600", COMPUTER_API_BASE_ENDPOINT) {
+				m.busy <- struct{}{}
+				<-m.release
+				return
+			}
+			if r.Method == "PUT" {
+				m.mu.Lock()
+				m.updates[r.RequestURI] = ""
+				m.mu.Unlock()
+				if r.RequestURI == fmt.Sprintf("%s/id/500", COMPUTER_API_BASE_ENDPOINT) {
+					w.WriteHeader(http.StatusConflict)
+					fmt.Fprintf(w, "Conflict")
+					return
+				}
+				fmt.Fprintf(w, `{"id": 1}`)
+				return
+			}
+			w.WriteHeader(http.StatusNotFound)
+			fmt.Fprintf(w, "The server has not found anything matching the request URI")
+		}
+	}))
+}
+
+func newExtAttrValues(t *testing.T, mock *extAttrValueMock) (*jamf.ExtensionAttributeValues, *httptest.Server) {
+	testServer := mock.server()
+	computers, err := jamf.NewService(testServer.URL, "fake-username", "mock-password-cool", nil)
+	assert.Nil(t, err)
+	attrs, err := computerextensionattributes.NewService(testServer.URL, "fake-username", "mock-password-cool", nil)
+	assert.Nil(t, err)
+	return jamf.NewExtensionAttributeValues(computers, attrs), testServer
+}
+
+func TestGetExtensionAttributeValues(t *testing.T) {
+	values, testServer := newExtAttrValues(t, &extAttrValueMock{updates: map[string]string{}})
+	defer testServer.Close()
+
+	eas, err := values.Get(82)
+	assert.Nil(t, err)
+	assert.Equal(t, 4, len(eas))
+
+	assert.Equal(t, "OSquery NOT Running", eas[0].Value)
+
+	uptime, ok := eas[1].Int()
+	assert.True(t, ok)
+	assert.Equal(t, 12, uptime)
+	assert.Equal(t, "Integer", eas[1].DataType)
+
+	patched, ok := eas[2].Time()
+	assert.True(t, ok)
+	assert.Equal(t, time.Date(2021, 3, 4, 10, 30, 0, 0, time.UTC), patched)
+
+	// the definition no longer exists so the type on the computer record is used
+	assert.Equal(t, "Number", eas[3].DataType)
+	assert.Nil(t, eas[3].Value)
+	assert.NotNil(t, eas[3].Err)
+	assert.Equal(t, "not a number", eas[3].String())
+}
+
+func TestSetExtensionAttributeValue(t *testing.T) {
+	mock := &extAttrValueMock{updates: map[string]string{}}
+	values, testServer := newExtAttrValues(t, mock)
+	defer testServer.Close()
+
+	_, err := values.Set(82, "Uptime Days", 30)
+	assert.Nil(t, err)
+	assert.Equal(t, "<computer><extension_attributes><extension_attribute><id>7</id><value>30</value></extension_attribute></extension_attributes></computer>", mock.updates[fmt.Sprintf("%s/id/82", COMPUTER_API_BASE_ENDPOINT)])
+
+	_, err = values.Set(82, 8, time.Date(2021, 5, 6, 7, 8, 9, 0, time.UTC))
+	assert.Nil(t, err)
+	assert.Contains(t, mock.updates[fmt.Sprintf("%s/id/82", COMPUTER_API_BASE_ENDPOINT)], "<id>8</id><value>2021-05-06 07:08:09</value>")
+
+	// values that do not match the definition data type are never written
+	delete(mock.updates, fmt.Sprintf("%s/id/82", COMPUTER_API_BASE_ENDPOINT))
+	_, err = values.Set(82, 7, "thirty")
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "thirty is not a valid value for an extension attribute of type Integer")
+	_, err = values.Set(82, 8, 12)
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "value of type (int) can not be used for an extension attribute of type Date")
+	assert.Empty(t, mock.updates)
+}
+
+func TestSetExtensionAttributeValueBulk(t *testing.T) {
+	mock := &extAttrValueMock{updates: map[string]string{}}
+	values, testServer := newExtAttrValues(t, mock)
+	defer testServer.Close()
+
+	report, err := values.SetBulk(context.Background(), 6, "Running", []int{1, 2, 500, 3}, 2)
+	assert.Nil(t, err)
+	assert.Equal(t, 4, len(report.Results))
+	assert.Equal(t, 4, len(mock.updates))
+
+	for i, id := range []int{1, 2, 500, 3} {
+		assert.Equal(t, id, report.Results[i].ComputerID)
+	}
+
+	failed := report.Failed()
+	assert.Equal(t, 1, len(failed))
+	assert.Equal(t, 500, failed[0].ComputerID)
+	assert.Contains(t, failed[0].Err.Error(), "Conflict")
+
+	// a cancelled context skips every remaining computer
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	report, err = values.SetBulk(ctx, 6, "Running", []int{4, 5}, 0)
+	assert.Nil(t, err)
+	assert.Equal(t, 2, len(report.Failed()))
+}
+
+func TestSetExtensionAttributeValueBulkCancelled(t *testing.T) {
+	mock := &extAttrValueMock{updates: map[string]string{}, busy: make(chan struct{}, 1), release: make(chan struct{})}
+	values, testServer := newExtAttrValues(t, mock)
+	defer testServer.Close()
+	defer close(mock.release)
+
+	// cancelling while every worker is busy stops the computers still waiting for a worker
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan *jamf.BulkReport)
+	go func() {
+		report, err := values.SetBulk(ctx, 6, "Running", []int{600, 601, 602}, 1)
+		assert.Nil(t, err)
+		done <- report
+	}()
+	<-mock.busy
+	cancel()
+
+	select {
+	case report := <-done:
+		assert.Equal(t, 3, len(report.Failed()))
+		assert.Equal(t, context.Canceled, report.Results[1].Err)
+		assert.Equal(t, context.Canceled, report.Results[2].Err)
+		assert.Empty(t, mock.updates)
+	case <-time.After(5 * time.Second):
+		t.Fatal("SetBulk did not stop after the context was cancelled")
+	}
+}
+
+func TestCoerceExtensionAttributeValue(t *testing.T) {
+	v, err := jamf.CoerceExtensionAttributeValue("Integer", " 42 ")
+	assert.Nil(t, err)
+	assert.Equal(t, 42, v)
+
+	v, err = jamf.CoerceExtensionAttributeValue("Date", "2021-03-04")
+	assert.Nil(t, err)
+	assert.Equal(t, time.Date(2021, 3, 4, 0, 0, 0, 0, time.UTC), v)
+
+	v, err = jamf.CoerceExtensionAttributeValue("Date", "")
+	assert.Nil(t, err)
+	assert.Nil(t, v)
+
+	_, err = jamf.CoerceExtensionAttributeValue("Date", "March 4th")
+	assert.NotNil(t, err)
+	assert.Equal(t, "March 4th is not a valid value for an extension attribute of type Date must be formatted as 2006-01-02 15:04:05", err.Error())
+
+	v, err = jamf.CoerceExtensionAttributeValue("String", "42")
+	assert.Nil(t, err)
+	assert.Equal(t, "42", v)
+}
@@ -3,6 +3,7 @@
 package computers_test
 
 import (
+	"context"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
@@ -195,7 +196,7 @@ func TestQuerySpecificComputer(t *testing.T) {
 	// Hardware info
 	assert.Equal(t, "Apple", computer.Hardware.Make)
 	assert.Equal(t, "App Store and identified developers", computer.Hardware.GatekeeperStatus)
-	assert.Equal(t, "Enabled", computer.Hardware.SIPStatus)
+	assert.Equal(t, "Enabled", computer.Hardware.SipStatus)
 	assert.Equal(t, []string{"test.user"}, computer.Hardware.FilevaultUsers)
 
 	// Certificate Information
@@ -221,3 +222,21 @@ func TestQuerySpecificComputer(t *testing.T) {
 	assert.Equal(t, "Test Config Profile", computer.ConfigProfiles[0].Name)
 	assert.Equal(t, false, computer.ConfigProfiles[0].Removable)
 }
+
+func TestIterateComputers(t *testing.T) {
+	testServer := computerResponseMocks(t)
+	defer testServer.Close()
+	j, err := jamf.NewService(testServer.URL, "fake-username", "mock-password-cool", nil)
+	assert.Nil(t, err)
+
+	it := j.Iterate()
+	assert.True(t, it.Next(context.Background()))
+	assert.Equal(t, "Test MacBook #3", it.Value().Name)
+	total, ok := it.TotalCount()
+	assert.True(t, ok)
+	assert.Equal(t, 6, total)
+
+	rest, err := it.All(context.Background())
+	assert.Nil(t, err)
+	assert.Len(t, rest, 5)
+}
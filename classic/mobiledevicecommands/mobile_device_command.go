@@ -0,0 +1,196 @@
+// Unless explicitly stated otherwise all files in this repository are licensed under the Apache-2.0
+// This product includes software developed at Datadog (https://www.datadoghq.com/). Copyright 2020 Datadog, Inc.
+
+package mobiledevicecommands
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/xml"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/trustero/jamf-api-client-go/classic/client"
+)
+
+// DefaultPollInterval is the time waited between status queries when no interval is provided
+const DefaultPollInterval = 5 * time.Second
+
+// DeviceLock locks mobile devices given their Ids showing an optional message on the lock screen
+func (j *Service) DeviceLock(message string, ids ...int) (result *CommandResult, response *http.Response, err error) {
+	return j.Send(&Command{Name: CommandDeviceLock, LockMessage: message}, ids...)
+}
+
+// EraseDevice wipes mobile devices given their Ids
+func (j *Service) EraseDevice(options *EraseOptions, ids ...int) (result *CommandResult, response *http.Response, err error) {
+	command := &Command{Name: CommandEraseDevice}
+	if options != nil {
+		command.PreserveDataPlan = options.PreserveDataPlan
+		command.DisallowProximitySetup = options.DisallowProximitySetup
+	}
+	return j.Send(command, ids...)
+}
+
+// ClearPasscode removes the passcode of mobile devices given their Ids
+func (j *Service) ClearPasscode(ids ...int) (result *CommandResult, response *http.Response, err error) {
+	return j.Send(&Command{Name: CommandClearPasscode}, ids...)
+}
+
+// UpdateInventory requests an inventory update from mobile devices given their Ids
+func (j *Service) UpdateInventory(ids ...int) (result *CommandResult, response *http.Response, err error) {
+	return j.Send(&Command{Name: CommandUpdateInventory}, ids...)
+}
+
+// RestartDevice restarts mobile devices given their Ids
+func (j *Service) RestartDevice(ids ...int) (result *CommandResult, response *http.Response, err error) {
+	return j.Send(&Command{Name: CommandRestartDevice}, ids...)
+}
+
+// ShutDownDevice shuts down mobile devices given their Ids
+func (j *Service) ShutDownDevice(ids ...int) (result *CommandResult, response *http.Response, err error) {
+	return j.Send(&Command{Name: CommandShutDownDevice}, ids...)
+}
+
+// EnableLostMode puts supervised mobile devices given their Ids in lost mode
+func (j *Service) EnableLostMode(lostMode *LostMode, ids ...int) (result *CommandResult, response *http.Response, err error) {
+	command := &Command{Name: CommandEnableLostMode}
+	if lostMode != nil {
+		command.LostModeMessage = lostMode.Message
+		command.LostModePhone = lostMode.Phone
+		command.LostModeFootnote = lostMode.Footnote
+		command.AlwaysEnforceLostMode = lostMode.AlwaysEnforce
+		command.LostModeWithSound = lostMode.WithSound
+	}
+	return j.Send(command, ids...)
+}
+
+// DisableLostMode takes mobile devices given their Ids out of lost mode
+func (j *Service) DisableLostMode(ids ...int) (result *CommandResult, response *http.Response, err error) {
+	return j.Send(&Command{Name: CommandDisableLostMode}, ids...)
+}
+
+// PlayLostModeSound plays a sound on mobile devices in lost mode given their Ids
+func (j *Service) PlayLostModeSound(ids ...int) (result *CommandResult, response *http.Response, err error) {
+	return j.Send(&Command{Name: CommandPlayLostModeSound}, ids...)
+}
+
+// SetWallpaper sets the wallpaper of supervised mobile devices given their Ids
+func (j *Service) SetWallpaper(wallpaper *Wallpaper, ids ...int) (result *CommandResult, response *http.Response, err error) {
+	command := &Command{Name: CommandWallpaper}
+	if wallpaper != nil {
+		command.WallpaperSetting = wallpaper.Location
+		command.WallpaperID = wallpaper.ID
+		if len(wallpaper.Image) > 0 {
+			command.WallpaperContent = base64.StdEncoding.EncodeToString(wallpaper.Image)
+		}
+	}
+	return j.Send(command, ids...)
+}
+
+// Settings enables or disables a setting on supervised mobile devices given their Ids
+func (j *Service) Settings(setting Setting, enabled bool, ids ...int) (result *CommandResult, response *http.Response, err error) {
+	if err = setting.Validate(); err != nil {
+		err = errors.Wrapf(err, "mobile device command validation failed: %v", ids)
+		return
+	}
+	return j.Send(&Command{Name: setting.Command(enabled)}, ids...)
+}
+
+// Send will validate and send a command to mobile devices given their Ids. The result holds the
+// UUID of the command which can be polled with Status or WaitForCommand
+func (j *Service) Send(command *Command, ids ...int) (result *CommandResult, response *http.Response, err error) {
+	if command == nil {
+		err = errors.Wrapf(fmt.Errorf("Empty payload"), "unable to process JAMF mobile device command request (%s)", j.client.Endpoint)
+		return
+	}
+
+	ep := fmt.Sprintf("%s/command/%s", j.client.Endpoint, command.Name)
+	if err = ValidateCommand(command, ids); err != nil {
+		err = errors.Wrapf(err, "mobile device command validation failed: %v", command.Name)
+		return
+	}
+
+	payload := &commandPayload{General: command}
+	for _, id := range ids {
+		payload.MobileDevices = append(payload.MobileDevices, &CommandDevice{ID: id})
+	}
+
+	bodyContent, err := xml.Marshal(payload)
+	if err != nil {
+		err = errors.Wrapf(err, "error building JAMF mobile device command payload: %v", command.Name)
+		return
+	}
+
+	req, err := http.NewRequestWithContext(context.Background(), "POST", ep, bytes.NewReader(bodyContent))
+	if err != nil {
+		err = errors.Wrapf(err, "error building JAMF mobile device command request: %v (%s)", command.Name, ep)
+		return
+	}
+
+	result = &CommandResult{}
+	if response, err = client.MakeAPIrequest(j.client, req, result); err != nil {
+		err = errors.Wrapf(err, "unable to send mobile device command: %v (%s)", command.Name, ep)
+	}
+	return
+}
+
+// Status returns the status of a command given its UUID
+func (j *Service) Status(uuid string) (result *CommandStatus, response *http.Response, err error) {
+	return j.status(context.Background(), uuid)
+}
+
+func (j *Service) status(ctx context.Context, uuid string) (result *CommandStatus, response *http.Response, err error) {
+	ep := fmt.Sprintf("%s/uuid/%s", j.client.Endpoint, uuid)
+	req, err := http.NewRequestWithContext(ctx, "GET", ep, nil)
+	if err != nil {
+		err = errors.Wrapf(err, "error building JAMF mobile device command status request for command: %v (%s)", uuid, ep)
+		return
+	}
+
+	res := &commandStatusDetails{}
+	if response, err = client.MakeAPIrequest(j.client, req, res); err != nil {
+		err = errors.Wrapf(err, "unable to query mobile device command status for command: %v (%s)", uuid, ep)
+		return
+	}
+	result = res.Status
+	return
+}
+
+// WaitForCommand polls the status of a command every interval until a device answers it or ctx is
+// done. DefaultPollInterval is used when interval is not positive. The last status is returned
+// along with an error when the command failed or ctx is done first
+func (j *Service) WaitForCommand(ctx context.Context, uuid string, interval time.Duration) (*CommandStatus, error) {
+	if interval <= 0 {
+		interval = DefaultPollInterval
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		status, _, err := j.status(ctx, uuid)
+		if err != nil {
+			if ctx.Err() != nil {
+				return status, errors.Wrapf(ctx.Err(), "stopped waiting for mobile device command: %s", uuid)
+			}
+			return status, err
+		}
+		if status == nil {
+			return nil, fmt.Errorf("no status returned for mobile device command: %s", uuid)
+		}
+		if status.Done() {
+			if status.Failed() {
+				return status, fmt.Errorf("mobile device command %s (%s) failed with status: %s", status.Command, uuid, status.Status)
+			}
+			return status, nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return status, errors.Wrapf(ctx.Err(), "stopped waiting for mobile device command: %s with status: %s", uuid, status.Status)
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,175 @@
+// Unless explicitly stated otherwise all files in this repository are licensed under the Apache-2.0
+// This product includes software developed at Datadog (https://www.datadoghq.com/). Copyright 2020 Datadog, Inc.
+
+package mobiledevicecommands
+
+import "encoding/xml"
+
+// CommandName is an MDM command Jamf sends to mobile devices
+type CommandName string
+
+// Commands supported by the typed helpers of Service, other commands can be sent with Service.Send
+const (
+	CommandDeviceLock        CommandName = "DeviceLock"
+	CommandEraseDevice       CommandName = "EraseDevice"
+	CommandClearPasscode     CommandName = "ClearPasscode"
+	CommandUpdateInventory   CommandName = "UpdateInventory"
+	CommandRestartDevice     CommandName = "RestartDevice"
+	CommandShutDownDevice    CommandName = "ShutDownDevice"
+	CommandEnableLostMode    CommandName = "EnableLostMode"
+	CommandDisableLostMode   CommandName = "DisableLostMode"
+	CommandPlayLostModeSound CommandName = "PlayLostModeSound"
+	CommandWallpaper         CommandName = "Wallpaper"
+)
+
+// Setting is a device setting toggled with Service.Settings
+type Setting string
+
+// Settings which can be enabled or disabled on supervised devices
+const (
+	Bluetooth            Setting = "Bluetooth"
+	DataRoaming          Setting = "DataRoaming"
+	VoiceRoaming         Setting = "VoiceRoaming"
+	PersonalHotspot      Setting = "PersonalHotspot"
+	AppAnalytics         Setting = "AppAnalytics"
+	DiagnosticSubmission Setting = "DiagnosticSubmission"
+)
+
+// Settings lists every setting which can be toggled
+var Settings = []Setting{Bluetooth, DataRoaming, VoiceRoaming, PersonalHotspot, AppAnalytics, DiagnosticSubmission}
+
+// Command returns the command enabling or disabling the setting, ex. SettingsEnableBluetooth
+func (s Setting) Command(enabled bool) CommandName {
+	if enabled {
+		return CommandName("SettingsEnable" + string(s))
+	}
+	return CommandName("SettingsDisable" + string(s))
+}
+
+// WallpaperLocation is where a wallpaper is set
+type WallpaperLocation int
+
+// Wallpaper locations supported by Jamf
+const (
+	LockScreen  WallpaperLocation = 1
+	HomeScreen  WallpaperLocation = 2
+	BothScreens WallpaperLocation = 3
+)
+
+// Command holds an MDM command and its options. Only the options of the command sent are used
+type Command struct {
+	Name CommandName `xml:"command"`
+	// LockMessage is shown on the lock screen by DeviceLock
+	LockMessage string `xml:"lock_message,omitempty"`
+	// PreserveDataPlan and DisallowProximitySetup are used by EraseDevice
+	PreserveDataPlan       bool `xml:"preserve_data_plan,omitempty"`
+	DisallowProximitySetup bool `xml:"disallow_proximity_setup,omitempty"`
+	// Lost mode options are used by EnableLostMode, a message or a phone number is required
+	LostModeMessage       string `xml:"lost_mode_message,omitempty"`
+	LostModePhone         string `xml:"lost_mode_phone,omitempty"`
+	LostModeFootnote      string `xml:"lost_mode_footnote,omitempty"`
+	AlwaysEnforceLostMode bool   `xml:"always_enforce_lost_mode,omitempty"`
+	LostModeWithSound     bool   `xml:"lost_mode_with_sound,omitempty"`
+	// Wallpaper options are used by Wallpaper, either the base64 encoded content of an image or
+	// the Id of an image uploaded to Jamf is required
+	WallpaperSetting WallpaperLocation `xml:"wallpaper_setting,omitempty"`
+	WallpaperContent string            `xml:"wallpaper_content,omitempty"`
+	WallpaperID      int               `xml:"wallpaper_id,omitempty"`
+}
+
+// LostMode holds the options of the EnableLostMode command
+type LostMode struct {
+	Message  string
+	Phone    string
+	Footnote string
+	// AlwaysEnforce keeps the device in lost mode after it is wiped
+	AlwaysEnforce bool
+	// WithSound plays a sound once lost mode is enabled
+	WithSound bool
+}
+
+// EraseOptions holds the options of the EraseDevice command
+type EraseOptions struct {
+	PreserveDataPlan       bool
+	DisallowProximitySetup bool
+}
+
+// Wallpaper holds the options of the Wallpaper command. Either Image or ID must be set
+type Wallpaper struct {
+	Location WallpaperLocation
+	// Image holds the content of a PNG or JPEG image, it is base64 encoded when sent
+	Image []byte
+	// ID is the Id of an image uploaded to Jamf
+	ID int
+}
+
+// commandPayload is the payload used to send a command to mobile devices
+type commandPayload struct {
+	XMLName       xml.Name         `xml:"mobile_device_command"`
+	General       *Command         `xml:"general"`
+	MobileDevices []*CommandDevice `xml:"mobile_devices>mobile_device"`
+}
+
+// CommandDevice is a mobile device a command was sent to
+type CommandDevice struct {
+	ID           int    `json:"id" xml:"id"`
+	ManagementID string `json:"management_id,omitempty" xml:"management_id,omitempty"`
+	Status       string `json:"status,omitempty" xml:"status,omitempty"`
+}
+
+// CommandResult is returned by Jamf once a command is queued, use UUID to poll its status
+type CommandResult struct {
+	XMLName       xml.Name         `json:"-" xml:"mobile_device_command"`
+	UUID          string           `json:"uuid" xml:"uuid"`
+	Command       CommandName      `json:"command" xml:"command"`
+	MobileDevices []*CommandDevice `json:"mobile_devices" xml:"mobile_devices>mobile_device"`
+}
+
+// Command statuses reported by Jamf once a device answers a command
+const (
+	StatusAcknowledged       = "Acknowledged"
+	StatusError              = "Error"
+	StatusCommandFormatError = "CommandFormatError"
+	StatusNotNow             = "NotNow"
+	StatusPending            = "Pending"
+)
+
+// CommandStatus holds the status of a command queried by UUID
+type CommandStatus struct {
+	XMLName  xml.Name    `json:"-" xml:"mobile_device_command"`
+	UUID     string      `json:"uuid" xml:"uuid"`
+	Command  CommandName `json:"command" xml:"command"`
+	Username string      `json:"username,omitempty" xml:"username,omitempty"`
+	// Status is the APNs result of the command, it is empty or Pending until a device answers
+	Status           string           `json:"apns_result_status" xml:"apns_result_status"`
+	DateSentEpoch    int64            `json:"date_sent_epoch,omitempty" xml:"date_sent_epoch,omitempty"`
+	DateSentUTC      string           `json:"date_sent_utc,omitempty" xml:"date_sent_utc,omitempty"`
+	DateCompletedUTC string           `json:"date_completed_utc,omitempty" xml:"date_completed_utc,omitempty"`
+	MobileDevices    []*CommandDevice `json:"mobile_devices,omitempty" xml:"mobile_devices>mobile_device"`
+}
+
+// Done returns whether the command was answered by the device
+func (s *CommandStatus) Done() bool {
+	switch s.Status {
+	case StatusAcknowledged, StatusError, StatusCommandFormatError:
+		return true
+	default:
+		return false
+	}
+}
+
+// Failed returns whether the device answered the command with an error
+func (s *CommandStatus) Failed() bool {
+	return s.Status == StatusError || s.Status == StatusCommandFormatError
+}
+
+// commandStatusDetails holds the status of a command as returned by Jamf
+type commandStatusDetails struct {
+	Status *CommandStatus `json:"mobile_device_command"`
+}
+
+// UnmarshalXML reads the mobile_device_command root element Jamf sends in XML responses
+func (c *commandStatusDetails) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	c.Status = &CommandStatus{}
+	return d.DecodeElement(c.Status, &start)
+}
@@ -0,0 +1,166 @@
+// Unless explicitly stated otherwise all files in this repository are licensed under the Apache-2.0
+// This product includes software developed at Datadog (https://www.datadoghq.com/). Copyright 2020 Datadog, Inc.
+
+package mobiledevicecommands_test
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	jamf "github.com/trustero/jamf-api-client-go/classic/mobiledevicecommands"
+)
+
+var MOBILE_DEVICE_COMMANDS_API_BASE_ENDPOINT = "/JSSResource/mobiledevicecommands"
+
+// mobileDeviceCommandResponseMocks records command payloads and answers status queries with each
+// of statuses in turn
+func mobileDeviceCommandResponseMocks(t *testing.T, payloads *[]string, statuses []string) *httptest.Server {
+	polls := 0
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/xml")
+		switch {
+		case r.Method == "POST":
+			data, err := ioutil.ReadAll(r.Body)
+			assert.Nil(t, err)
+			*payloads = append(*payloads, r.RequestURI+" "+string(data))
+			fmt.Fprint(w, `<?xml version="1.0" encoding="UTF-8"?>
+				<mobile_device_command>
+					<uuid>0b2e6c1c-3f1d-4a57-9c55-6f1c2f3f0001</uuid>
+					<command>DeviceLock</command>
+					<mobile_devices><mobile_device><id>14</id><management_id>a1b2</management_id><status/></mobile_device></mobile_devices>
+				</mobile_device_command>`)
+		case r.RequestURI == MOBILE_DEVICE_COMMANDS_API_BASE_ENDPOINT+"/uuid/0b2e6c1c-3f1d-4a57-9c55-6f1c2f3f0001":
+			status := statuses[polls]
+			if polls < len(statuses)-1 {
+				polls++
+			}
+			fmt.Fprintf(w, `<?xml version="1.0" encoding="UTF-8"?>
+				<mobile_device_command>
+					<uuid>0b2e6c1c-3f1d-4a57-9c55-6f1c2f3f0001</uuid>
+					<command>DeviceLock</command>
+					<username>api</username>
+					<apns_result_status>%s</apns_result_status>
+					<mobile_devices><mobile_device><id>14</id></mobile_device></mobile_devices>
+				</mobile_device_command>`, status)
+		default:
+			http.Error(w, fmt.Sprintf("bad Jamf mobile device commands API call to %s", r.RequestURI), http.StatusInternalServerError)
+		}
+	}))
+}
+
+func TestSendMobileDeviceCommands(t *testing.T) {
+	var payloads []string
+	testServer := mobileDeviceCommandResponseMocks(t, &payloads, []string{""})
+	defer testServer.Close()
+	j, err := jamf.NewService(testServer.URL, "fake-username", "mock-password-cool", nil)
+	assert.Nil(t, err)
+
+	result, _, err := j.DeviceLock("Call IT", 14, 15)
+	assert.Nil(t, err)
+	assert.Equal(t, "0b2e6c1c-3f1d-4a57-9c55-6f1c2f3f0001", result.UUID)
+	assert.Equal(t, "a1b2", result.MobileDevices[0].ManagementID)
+
+	_, _, err = j.EnableLostMode(&jamf.LostMode{Message: "Lost iPad", Phone: "555-0100", WithSound: true}, 14)
+	assert.Nil(t, err)
+	_, _, err = j.SetWallpaper(&jamf.Wallpaper{Location: jamf.BothScreens, Image: []byte("png")}, 14)
+	assert.Nil(t, err)
+	_, _, err = j.Settings(jamf.PersonalHotspot, false, 14)
+	assert.Nil(t, err)
+	_, _, err = j.UpdateInventory(14)
+	assert.Nil(t, err)
+
+	assert.Equal(t, []string{
+		"/JSSResource/mobiledevicecommands/command/DeviceLock <mobile_device_command><general><command>DeviceLock</command><lock_message>Call IT</lock_message></general>" +
+			"<mobile_devices><mobile_device><id>14</id></mobile_device><mobile_device><id>15</id></mobile_device></mobile_devices></mobile_device_command>",
+		"/JSSResource/mobiledevicecommands/command/EnableLostMode <mobile_device_command><general><command>EnableLostMode</command><lost_mode_message>Lost iPad</lost_mode_message>" +
+			"<lost_mode_phone>555-0100</lost_mode_phone><lost_mode_with_sound>true</lost_mode_with_sound></general>" +
+			"<mobile_devices><mobile_device><id>14</id></mobile_device></mobile_devices></mobile_device_command>",
+		"/JSSResource/mobiledevicecommands/command/Wallpaper <mobile_device_command><general><command>Wallpaper</command><wallpaper_setting>3</wallpaper_setting>" +
+			"<wallpaper_content>cG5n</wallpaper_content></general><mobile_devices><mobile_device><id>14</id></mobile_device></mobile_devices></mobile_device_command>",
+		"/JSSResource/mobiledevicecommands/command/SettingsDisablePersonalHotspot <mobile_device_command><general><command>SettingsDisablePersonalHotspot</command></general>" +
+			"<mobile_devices><mobile_device><id>14</id></mobile_device></mobile_devices></mobile_device_command>",
+		"/JSSResource/mobiledevicecommands/command/UpdateInventory <mobile_device_command><general><command>UpdateInventory</command></general>" +
+			"<mobile_devices><mobile_device><id>14</id></mobile_device></mobile_devices></mobile_device_command>",
+	}, payloads)
+}
+
+func TestMobileDeviceCommandValidation(t *testing.T) {
+	var payloads []string
+	testServer := mobileDeviceCommandResponseMocks(t, &payloads, []string{""})
+	defer testServer.Close()
+	j, err := jamf.NewService(testServer.URL, "fake-username", "mock-password-cool", nil)
+	assert.Nil(t, err)
+
+	testCases := []struct {
+		send func() error
+		err  string
+	}{
+		{func() error { _, _, err := j.RestartDevice(); return err }, "No mobile devices provided"},
+		{func() error { _, _, err := j.ShutDownDevice(0); return err }, "0 is not a valid mobile device id"},
+		{func() error { _, _, err := j.EnableLostMode(&jamf.LostMode{Footnote: "x"}, 14); return err }, "EnableLostMode requires a lost mode message or phone number"},
+		{func() error { _, _, err := j.SetWallpaper(&jamf.Wallpaper{Location: 4, ID: 2}, 14); return err }, "4 is not a valid wallpaper location"},
+		{func() error { _, _, err := j.SetWallpaper(&jamf.Wallpaper{Location: jamf.LockScreen}, 14); return err }, "Wallpaper requires an image"},
+		{func() error { _, _, err := j.Settings("Wi-Fi", true, 14); return err }, "Wi-Fi is not a valid setting"},
+		{func() error { _, _, err := j.Send(nil, 14); return err }, "Empty payload"},
+	}
+	for _, tc := range testCases {
+		err := tc.send()
+		assert.NotNil(t, err, tc.err)
+		assert.Contains(t, err.Error(), tc.err)
+	}
+	assert.Empty(t, payloads)
+}
+
+func TestWaitForMobileDeviceCommand(t *testing.T) {
+	var payloads []string
+	testServer := mobileDeviceCommandResponseMocks(t, &payloads, []string{"", jamf.StatusNotNow, jamf.StatusAcknowledged})
+	defer testServer.Close()
+	j, err := jamf.NewService(testServer.URL, "fake-username", "mock-password-cool", nil)
+	assert.Nil(t, err)
+
+	status, _, err := j.Status("0b2e6c1c-3f1d-4a57-9c55-6f1c2f3f0001")
+	assert.Nil(t, err)
+	assert.False(t, status.Done())
+	assert.Equal(t, "api", status.Username)
+
+	status, err = j.WaitForCommand(context.Background(), "0b2e6c1c-3f1d-4a57-9c55-6f1c2f3f0001", time.Millisecond)
+	assert.Nil(t, err)
+	assert.Equal(t, jamf.StatusAcknowledged, status.Status)
+	assert.Equal(t, 14, status.MobileDevices[0].ID)
+
+	failing := mobileDeviceCommandResponseMocks(t, &payloads, []string{jamf.StatusError})
+	defer failing.Close()
+	j, err = jamf.NewService(failing.URL, "fake-username", "mock-password-cool", nil)
+	assert.Nil(t, err)
+	status, err = j.WaitForCommand(context.Background(), "0b2e6c1c-3f1d-4a57-9c55-6f1c2f3f0001", time.Millisecond)
+	assert.NotNil(t, err)
+	assert.True(t, status.Failed())
+
+	pending := mobileDeviceCommandResponseMocks(t, &payloads, []string{jamf.StatusPending})
+	defer pending.Close()
+	j, err = jamf.NewService(pending.URL, "fake-username", "mock-password-cool", nil)
+	assert.Nil(t, err)
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	status, err = j.WaitForCommand(ctx, "0b2e6c1c-3f1d-4a57-9c55-6f1c2f3f0001", time.Millisecond)
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "stopped waiting for mobile device command")
+
+	empty := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{}`)
+	}))
+	defer empty.Close()
+	j, err = jamf.NewService(empty.URL, "fake-username", "mock-password-cool", nil)
+	assert.Nil(t, err)
+	status, err = j.WaitForCommand(context.Background(), "0b2e6c1c-3f1d-4a57-9c55-6f1c2f3f0001", time.Millisecond)
+	assert.Nil(t, status)
+	assert.NotNil(t, err)
+	assert.Equal(t, "no status returned for mobile device command: 0b2e6c1c-3f1d-4a57-9c55-6f1c2f3f0001", err.Error())
+}
@@ -0,0 +1,62 @@
+// Unless explicitly stated otherwise all files in this repository are licensed under the Apache-2.0
+// This product includes software developed at Datadog (https://www.datadoghq.com/). Copyright 2020 Datadog, Inc.
+
+package mobiledevicecommands
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ValidateCommand orchestrates mobile device command validation
+func ValidateCommand(c *Command, ids []int) error {
+	if strings.TrimSpace(string(c.Name)) == "" {
+		return fmt.Errorf("command name is required")
+	}
+
+	if len(ids) == 0 {
+		return fmt.Errorf("No mobile devices provided")
+	}
+	for _, id := range ids {
+		if id <= 0 {
+			return fmt.Errorf("%d is not a valid mobile device id", id)
+		}
+	}
+
+	switch c.Name {
+	case CommandEnableLostMode:
+		if strings.TrimSpace(c.LostModeMessage) == "" && strings.TrimSpace(c.LostModePhone) == "" {
+			return fmt.Errorf("%s requires a lost mode message or phone number", c.Name)
+		}
+	case CommandWallpaper:
+		if err := c.WallpaperSetting.Validate(); err != nil {
+			return err
+		}
+		if c.WallpaperContent == "" && c.WallpaperID == 0 {
+			return fmt.Errorf("%s requires an image or the id of an image uploaded to Jamf", c.Name)
+		}
+	}
+	return nil
+}
+
+// Validate will validate that a wallpaper location is supported by Jamf
+func (w WallpaperLocation) Validate() error {
+	switch w {
+	case LockScreen, HomeScreen, BothScreens:
+		return nil
+	default:
+		return fmt.Errorf("%d is not a valid wallpaper location must be of type [ 1 (lock screen), 2 (home screen), 3 (both) ]", w)
+	}
+}
+
+// Validate will validate that a setting can be toggled
+func (s Setting) Validate() error {
+	names := make([]string, len(Settings))
+	for i, setting := range Settings {
+		if s == setting {
+			return nil
+		}
+		names[i] = string(setting)
+	}
+	return fmt.Errorf("%s is not a valid setting must be of type [ %s ]", s, strings.Join(names, ", "))
+}
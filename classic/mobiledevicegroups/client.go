@@ -0,0 +1,23 @@
+package mobiledevicegroups
+
+import (
+	"github.com/trustero/jamf-api-client-go/classic/client"
+	"github.com/trustero/jamf-api-client-go/internal/groups"
+	"net/http"
+)
+
+const domain = "mobiledevicegroups"
+
+type Service struct {
+	groups *groups.Service[MobileDeviceGroup]
+}
+
+func NewService(baseUrl string, username string, password string, httpClient *http.Client) (*Service, error) {
+
+	j, err := client.NewDomainClient(baseUrl, domain, username, password, httpClient)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Service{groups: groups.NewService(j, kind)}, nil
+}
@@ -0,0 +1,77 @@
+// Unless explicitly stated otherwise all files in this repository are licensed under the Apache-2.0
+// This product includes software developed at Datadog (https://www.datadoghq.com/). Copyright 2020 Datadog, Inc.
+
+package mobiledevicegroups
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/trustero/jamf-api-client-go/pager"
+)
+
+// MobileDeviceGroups returns a list of the mobile device groups available in Jamf
+func (j *Service) MobileDeviceGroups() (result []BasicMobileDeviceGroupInfo, response *http.Response, err error) {
+	return j.list(context.Background())
+}
+
+// Iterate returns an iterator over the mobile device groups available in Jamf
+func (j *Service) Iterate() *pager.Pager[BasicMobileDeviceGroupInfo] {
+	return pager.Single(func(ctx context.Context) ([]BasicMobileDeviceGroupInfo, error) {
+		groups, _, err := j.list(ctx)
+		return groups, err
+	})
+}
+
+func (j *Service) list(ctx context.Context) (result []BasicMobileDeviceGroupInfo, response *http.Response, err error) {
+	res := &MobileDeviceGroups{}
+	if response, err = j.groups.List(ctx, res); err == nil {
+		result = res.List
+	}
+	return
+}
+
+// MobileDeviceGroupDetails returns the details for a specific mobile device group given its Id or Name
+func (j *Service) MobileDeviceGroupDetails(identifier interface{}) (result *MobileDeviceGroup, response *http.Response, err error) {
+	res := &MobileDeviceGroupDetails{}
+	if response, err = j.groups.Details(identifier, res); err == nil {
+		result = res.Group
+	}
+	return
+}
+
+// CreateMobileDeviceGroup will validate and create a mobile device group in Jamf
+func (j *Service) CreateMobileDeviceGroup(group *MobileDeviceGroup) (result *MobileDeviceGroup, response *http.Response, err error) {
+	return j.groups.Create(group)
+}
+
+// UpdateMobileDeviceGroup will validate and update a mobile device group in Jamf by either Id or Name. The name
+// may be left empty to keep it unchanged and the MobileDevices Jamf lists for smart groups are not
+// sent back. When MobileDevices is set the members of a static group are replaced, use AddMobileDevices
+// and RemoveMobileDevices to edit them instead
+func (j *Service) UpdateMobileDeviceGroup(identifier interface{}, group *MobileDeviceGroup) (result *MobileDeviceGroup, response *http.Response, err error) {
+	return j.groups.Update(identifier, group)
+}
+
+// DeleteMobileDeviceGroup will delete a mobile device group by either Id or Name
+func (j *Service) DeleteMobileDeviceGroup(identifier interface{}) (result *MobileDeviceGroup, response *http.Response, err error) {
+	return j.groups.Delete(identifier)
+}
+
+// AddMobileDevices adds mobile devices by Id to a static mobile device group leaving its other members untouched
+func (j *Service) AddMobileDevices(identifier interface{}, mobileDeviceIDs ...int) (result *MobileDeviceGroup, response *http.Response, err error) {
+	return j.groups.EditMembership(identifier, &membershipUpdate{Additions: groupMobileDevices(mobileDeviceIDs)}, len(mobileDeviceIDs))
+}
+
+// RemoveMobileDevices removes mobile devices by Id from a static mobile device group leaving its other members untouched
+func (j *Service) RemoveMobileDevices(identifier interface{}, mobileDeviceIDs ...int) (result *MobileDeviceGroup, response *http.Response, err error) {
+	return j.groups.EditMembership(identifier, &membershipUpdate{Deletions: groupMobileDevices(mobileDeviceIDs)}, len(mobileDeviceIDs))
+}
+
+func groupMobileDevices(ids []int) GroupMobileDevices {
+	devices := make(GroupMobileDevices, len(ids))
+	for i, id := range ids {
+		devices[i] = &GroupMobileDevice{ID: id}
+	}
+	return devices
+}
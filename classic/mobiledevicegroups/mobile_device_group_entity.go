@@ -0,0 +1,100 @@
+// Unless explicitly stated otherwise all files in this repository are licensed under the Apache-2.0
+// This product includes software developed at Datadog (https://www.datadoghq.com/). Copyright 2020 Datadog, Inc.
+
+package mobiledevicegroups
+
+import (
+	"encoding/xml"
+
+	"github.com/trustero/jamf-api-client-go/classic/computergroups"
+)
+
+// Smart mobile device group criteria are built and validated the same way as smart computer group
+// criteria, see computergroups.NewCriteriaBuilder
+type (
+	Criteria        = computergroups.Criteria
+	Criterion       = computergroups.Criterion
+	CriteriaBuilder = computergroups.CriteriaBuilder
+)
+
+// NewCriteriaBuilder returns an empty criteria builder
+func NewCriteriaBuilder() *CriteriaBuilder {
+	return computergroups.NewCriteriaBuilder()
+}
+
+// MobileDeviceGroups holds a list of all the mobile device groups available in Jamf
+type MobileDeviceGroups struct {
+	List []BasicMobileDeviceGroupInfo `json:"mobile_device_groups"`
+}
+
+// BasicMobileDeviceGroupInfo holds the most basic information about a mobile device group in Jamf
+type BasicMobileDeviceGroupInfo struct {
+	ID      int    `json:"id,omitempty" xml:"id,omitempty"`
+	Name    string `json:"name" xml:"name"`
+	IsSmart bool   `json:"is_smart" xml:"is_smart"`
+}
+
+// MobileDeviceGroupDetails holds the details to a specific mobile device group queried by Id or Name
+type MobileDeviceGroupDetails struct {
+	Group *MobileDeviceGroup `json:"mobile_device_group" xml:"mobile_device_group,omitempty"`
+}
+
+// MobileDeviceGroup represents a static or smart mobile device group in Jamf. Smart groups hold
+// criteria which Jamf evaluates to find their members while static groups list their members directly
+type MobileDeviceGroup struct {
+	XMLName       xml.Name           `json:"-" xml:"mobile_device_group,omitempty"`
+	ID            int                `json:"id,omitempty" xml:"id,omitempty"`
+	Name          string             `json:"name" xml:"name,omitempty"`
+	IsSmart       *bool              `json:"is_smart,omitempty" xml:"is_smart,omitempty"`
+	Site          *Site              `json:"site,omitempty" xml:"site,omitempty"`
+	Criteria      Criteria           `json:"criteria,omitempty" xml:"criteria,omitempty"`
+	MobileDevices GroupMobileDevices `json:"mobile_devices,omitempty" xml:"mobile_devices,omitempty"`
+}
+
+// GroupMobileDevice is a mobile device which is a member of a mobile device group
+type GroupMobileDevice struct {
+	ID             int    `json:"id,omitempty" xml:"id,omitempty"`
+	Name           string `json:"name,omitempty" xml:"name,omitempty"`
+	MacAddress     string `json:"mac_address,omitempty" xml:"mac_address,omitempty"`
+	UDID           string `json:"udid,omitempty" xml:"udid,omitempty"`
+	WifiMacAddress string `json:"wifi_mac_address,omitempty" xml:"wifi_mac_address,omitempty"`
+	SerialNumber   string `json:"serial_number,omitempty" xml:"serial_number,omitempty"`
+}
+
+// GroupMobileDevices holds the members of a mobile device group
+type GroupMobileDevices []*GroupMobileDevice
+
+// MarshalXML writes each member as a mobile_device element and omits the mobile_devices element when empty
+func (g GroupMobileDevices) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	if len(g) == 0 {
+		return nil
+	}
+	return e.EncodeElement(struct {
+		List []*GroupMobileDevice `xml:"mobile_device"`
+	}{g}, start)
+}
+
+// UnmarshalXML reads each mobile_device element as a member
+func (g *GroupMobileDevices) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	list := struct {
+		List []*GroupMobileDevice `xml:"mobile_device"`
+	}{}
+	if err := d.DecodeElement(&list, &start); err != nil {
+		return err
+	}
+	*g = list.List
+	return nil
+}
+
+// Site is the site a mobile device group belongs to
+type Site struct {
+	ID   int    `json:"id" xml:"id"`
+	Name string `json:"name,omitempty" xml:"name,omitempty"`
+}
+
+// membershipUpdate is the payload used to add or remove mobile devices of a static group
+type membershipUpdate struct {
+	XMLName   xml.Name           `xml:"mobile_device_group"`
+	Additions GroupMobileDevices `xml:"mobile_device_additions,omitempty"`
+	Deletions GroupMobileDevices `xml:"mobile_device_deletions,omitempty"`
+}
@@ -0,0 +1,134 @@
+// Unless explicitly stated otherwise all files in this repository are licensed under the Apache-2.0
+// This product includes software developed at Datadog (https://www.datadoghq.com/). Copyright 2020 Datadog, Inc.
+
+package mobiledevicegroups_test
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/trustero/jamf-api-client-go/classic/client"
+	"github.com/trustero/jamf-api-client-go/classic/computergroups"
+	jamf "github.com/trustero/jamf-api-client-go/classic/mobiledevicegroups"
+)
+
+var MOBILE_DEVICE_GROUPS_API_BASE_ENDPOINT = "/JSSResource/mobiledevicegroups"
+
+func mobileDeviceGroupsResponseMocks(t *testing.T, payloads *[]string) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.RequestURI {
+		case MOBILE_DEVICE_GROUPS_API_BASE_ENDPOINT:
+			fmt.Fprint(w, `{
+				"mobile_device_groups": [
+					{"id": 1, "name": "All Managed iPads", "is_smart": true},
+					{"id": 4, "name": "Loaners", "is_smart": false}
+				]
+			}`)
+		case fmt.Sprintf("%s/id/1", MOBILE_DEVICE_GROUPS_API_BASE_ENDPOINT):
+			fmt.Fprint(w, `{
+				"mobile_device_group": {
+					"id": 1,
+					"name": "All Managed iPads",
+					"is_smart": true,
+					"site": {"id": -1, "name": "None"},
+					"criteria": [
+						{"name": "Model", "priority": 0, "and_or": "and", "search_type": "like", "value": "iPad", "opening_paren": false, "closing_paren": false}
+					],
+					"mobile_devices": [
+						{"id": 14, "name": "Loaner iPad 1", "udid": "00008101-000A1B2C3D4E", "wifi_mac_address": "A0:B1:C2:D3:E4:F5", "serial_number": "DMPXK1ABCDEF"}
+					]
+				}
+			}`)
+		case fmt.Sprintf("%s/id/-1", MOBILE_DEVICE_GROUPS_API_BASE_ENDPOINT), fmt.Sprintf("%s/id/4", MOBILE_DEVICE_GROUPS_API_BASE_ENDPOINT), fmt.Sprintf("%s/name/Loaners", MOBILE_DEVICE_GROUPS_API_BASE_ENDPOINT):
+			data, err := ioutil.ReadAll(r.Body)
+			assert.Nil(t, err)
+			*payloads = append(*payloads, r.Method+" "+string(data))
+			w.Header().Set("Content-Type", "application/xml")
+			fmt.Fprint(w, `<mobile_device_group><id>4</id></mobile_device_group>`)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+}
+
+func TestQueryMobileDeviceGroups(t *testing.T) {
+	testServer := mobileDeviceGroupsResponseMocks(t, &[]string{})
+	defer testServer.Close()
+	j, err := jamf.NewService(testServer.URL, "fake-username", "mock-password-cool", nil)
+	assert.Nil(t, err)
+
+	groups, _, err := j.MobileDeviceGroups()
+	assert.Nil(t, err)
+	assert.Equal(t, []jamf.BasicMobileDeviceGroupInfo{{ID: 1, Name: "All Managed iPads", IsSmart: true}, {ID: 4, Name: "Loaners"}}, groups)
+
+	all, err := j.Iterate().All(context.Background())
+	assert.Nil(t, err)
+	assert.Equal(t, groups, all)
+
+	group, _, err := j.MobileDeviceGroupDetails(1)
+	assert.Nil(t, err)
+	assert.True(t, *group.IsSmart)
+	assert.Equal(t, &jamf.Criterion{Name: "Model", AndOr: computergroups.And, SearchType: computergroups.Like, Value: "iPad"}, group.Criteria[0])
+	assert.Equal(t, "DMPXK1ABCDEF", group.MobileDevices[0].SerialNumber)
+
+	_, _, err = j.MobileDeviceGroupDetails(404)
+	assert.NotNil(t, err)
+}
+
+func TestEditMobileDeviceGroups(t *testing.T) {
+	payloads := []string{}
+	testServer := mobileDeviceGroupsResponseMocks(t, &payloads)
+	defer testServer.Close()
+	j, err := jamf.NewService(testServer.URL, "fake-username", "mock-password-cool", nil)
+	assert.Nil(t, err)
+
+	criteria, err := jamf.NewCriteriaBuilder().
+		Where("Model", computergroups.Like, "iPad").
+		And("OS Version", computergroups.LessThan, "17").
+		Build()
+	assert.Nil(t, err)
+	created, _, err := j.CreateMobileDeviceGroup(&jamf.MobileDeviceGroup{Name: "Outdated iPads", IsSmart: client.Bool(true), Criteria: criteria})
+	assert.Nil(t, err)
+	assert.Equal(t, 4, created.ID)
+
+	_, _, err = j.AddMobileDevices("Loaners", 14, 15)
+	assert.Nil(t, err)
+	_, _, err = j.RemoveMobileDevices(4, 16)
+	assert.Nil(t, err)
+	_, _, err = j.RemoveMobileDevices(4)
+	assert.NotNil(t, err)
+	// a smart group fetched from Jamf lists its members which are not sent back
+	smart, _, err := j.MobileDeviceGroupDetails(1)
+	assert.Nil(t, err)
+	smart.Name = ""
+	_, _, err = j.UpdateMobileDeviceGroup(4, smart)
+	assert.Nil(t, err)
+	_, _, err = j.DeleteMobileDeviceGroup(4)
+	assert.Nil(t, err)
+
+	// validation runs before anything is sent
+	_, _, err = j.UpdateMobileDeviceGroup(4, &jamf.MobileDeviceGroup{Name: "Loaners", IsSmart: client.Bool(false), Criteria: criteria})
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "static mobile device group Loaners can not have criteria")
+	_, _, err = j.CreateMobileDeviceGroup(&jamf.MobileDeviceGroup{Name: "Smart", IsSmart: client.Bool(true), MobileDevices: jamf.GroupMobileDevices{{ID: 14}}})
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "smart mobile device group Smart can not list mobile devices")
+
+	assert.Equal(t, []string{
+		"POST <mobile_device_group><name>Outdated iPads</name><is_smart>true</is_smart><criteria>" +
+			"<criterion><name>Model</name><priority>0</priority><and_or>and</and_or><search_type>like</search_type><value>iPad</value><opening_paren>false</opening_paren><closing_paren>false</closing_paren></criterion>" +
+			"<criterion><name>OS Version</name><priority>1</priority><and_or>and</and_or><search_type>less than</search_type><value>17</value><opening_paren>false</opening_paren><closing_paren>false</closing_paren></criterion>" +
+			"</criteria></mobile_device_group>",
+		"PUT <mobile_device_group><mobile_device_additions><mobile_device><id>14</id></mobile_device><mobile_device><id>15</id></mobile_device></mobile_device_additions></mobile_device_group>",
+		"PUT <mobile_device_group><mobile_device_deletions><mobile_device><id>16</id></mobile_device></mobile_device_deletions></mobile_device_group>",
+		"PUT <mobile_device_group><id>1</id><is_smart>true</is_smart><site><id>-1</id><name>None</name></site><criteria>" +
+			"<criterion><name>Model</name><priority>0</priority><and_or>and</and_or><search_type>like</search_type><value>iPad</value><opening_paren>false</opening_paren><closing_paren>false</closing_paren></criterion>" +
+			"</criteria></mobile_device_group>",
+		"DELETE ",
+	}, payloads)
+}
@@ -0,0 +1,30 @@
+// Unless explicitly stated otherwise all files in this repository are licensed under the Apache-2.0
+// This product includes software developed at Datadog (https://www.datadoghq.com/). Copyright 2020 Datadog, Inc.
+
+package mobiledevicegroups
+
+import "github.com/trustero/jamf-api-client-go/internal/groups"
+
+// kind describes mobile device groups to the shared group requests and validation
+var kind = &groups.Kind[MobileDeviceGroup]{
+	Group:   "mobile device group",
+	Members: "mobile devices",
+	Fields: func(g *MobileDeviceGroup) groups.Fields {
+		return groups.Fields{
+			Name:             g.Name,
+			IsSmart:          g.IsSmart,
+			Criteria:         len(g.Criteria),
+			Members:          len(g.MobileDevices),
+			ValidateCriteria: g.Criteria.Validate,
+		}
+	},
+	ClearMembers: func(g *MobileDeviceGroup) {
+		g.MobileDevices = nil
+	},
+}
+
+// ValidateMobileDeviceGroup orchestrates the validation of a mobile device group about to be created,
+// see UpdateMobileDeviceGroup for updates
+func ValidateMobileDeviceGroup(g *MobileDeviceGroup) error {
+	return kind.Validate(g)
+}
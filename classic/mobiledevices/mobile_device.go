@@ -0,0 +1,202 @@
+// Unless explicitly stated otherwise all files in this repository are licensed under the Apache-2.0
+// This product includes software developed at Datadog (https://www.datadoghq.com/). Copyright 2020 Datadog, Inc.
+
+package mobiledevices
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/trustero/jamf-api-client-go/classic/client"
+	"github.com/trustero/jamf-api-client-go/pager"
+)
+
+// Subset is a section of a mobile device which can be fetched on its own
+type Subset string
+
+// Subsets supported by Jamf
+const (
+	SubsetGeneral               Subset = "General"
+	SubsetLocation              Subset = "Location"
+	SubsetPurchasing            Subset = "Purchasing"
+	SubsetApplications          Subset = "Applications"
+	SubsetSecurity              Subset = "Security"
+	SubsetNetwork               Subset = "Network"
+	SubsetCertificates          Subset = "Certificates"
+	SubsetConfigurationProfiles Subset = "ConfigurationProfiles"
+	SubsetProvisioningProfiles  Subset = "ProvisioningProfiles"
+	SubsetMobileDeviceGroups    Subset = "MobileDeviceGroups"
+	SubsetExtensionAttributes   Subset = "ExtensionAttributes"
+)
+
+// Lookup identifies a mobile device by one of the keys Jamf accepts, see ByID, ByName,
+// BySerialNumber, ByUDID and ByMACAddress
+type Lookup struct {
+	key   string
+	value string
+}
+
+// ByID looks up a mobile device by its Id
+func ByID(id int) Lookup {
+	return Lookup{key: "id", value: strconv.Itoa(id)}
+}
+
+// ByName looks up a mobile device by its name
+func ByName(name string) Lookup {
+	return Lookup{key: "name", value: name}
+}
+
+// BySerialNumber looks up a mobile device by its serial number
+func BySerialNumber(serial string) Lookup {
+	return Lookup{key: "serialnumber", value: serial}
+}
+
+// ByUDID looks up a mobile device by its UDID
+func ByUDID(udid string) Lookup {
+	return Lookup{key: "udid", value: udid}
+}
+
+// ByMACAddress looks up a mobile device by its Wi-Fi MAC address
+func ByMACAddress(mac string) Lookup {
+	return Lookup{key: "macaddress", value: mac}
+}
+
+// String returns the key and value of the lookup, ex. serialnumber/DMPXXXXXXXXX
+func (l Lookup) String() string {
+	return l.key + "/" + l.value
+}
+
+// List returns all enrolled mobile devices
+func (j *Service) List() (devices []BasicMobileDeviceInfo, response *http.Response, err error) {
+	return j.list(context.Background())
+}
+
+// Iterate returns an iterator over all enrolled mobile devices
+func (j *Service) Iterate() *pager.Pager[BasicMobileDeviceInfo] {
+	return pager.Single(func(ctx context.Context) ([]BasicMobileDeviceInfo, error) {
+		devices, _, err := j.list(ctx)
+		return devices, err
+	})
+}
+
+func (j *Service) list(ctx context.Context) (devices []BasicMobileDeviceInfo, response *http.Response, err error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", j.client.Endpoint, nil)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "error building JAMF mobile device query request")
+	}
+
+	res := &MobileDevices{}
+	if response, err = client.MakeAPIrequest(j.client, req, res); err != nil {
+		err = errors.Wrapf(err, "unable to query enrolled mobile devices from %s", j.client.Endpoint)
+		return
+	}
+	devices = res.List
+	return
+}
+
+// GetById returns the details for a specific mobile device given its Id
+func (j *Service) GetById(identifier int) (result *MobileDevice, response *http.Response, err error) {
+	return j.Get(ByID(identifier))
+}
+
+// GetByName returns the details for a specific mobile device given its name
+func (j *Service) GetByName(name string) (result *MobileDevice, response *http.Response, err error) {
+	return j.Get(ByName(name))
+}
+
+// GetBySerialNumber returns the details for a specific mobile device given its serial number
+func (j *Service) GetBySerialNumber(serial string) (result *MobileDevice, response *http.Response, err error) {
+	return j.Get(BySerialNumber(serial))
+}
+
+// GetByUDID returns the details for a specific mobile device given its UDID
+func (j *Service) GetByUDID(udid string) (result *MobileDevice, response *http.Response, err error) {
+	return j.Get(ByUDID(udid))
+}
+
+// GetByMACAddress returns the details for a specific mobile device given its Wi-Fi MAC address
+func (j *Service) GetByMACAddress(mac string) (result *MobileDevice, response *http.Response, err error) {
+	return j.Get(ByMACAddress(mac))
+}
+
+// Get returns the details for a specific mobile device. When subsets are given only those
+// sections are fetched and the other sections are left nil
+func (j *Service) Get(lookup Lookup, subsets ...Subset) (result *MobileDevice, response *http.Response, err error) {
+	ep, err := j.endpoint(lookup)
+	if err != nil {
+		return
+	}
+
+	if len(subsets) > 0 {
+		names := make([]string, len(subsets))
+		for i, s := range subsets {
+			names[i] = string(s)
+		}
+		ep = fmt.Sprintf("%s/subset/%s", ep, strings.Join(names, "&"))
+	}
+
+	req, err := http.NewRequestWithContext(context.Background(), "GET", ep, nil)
+	if err != nil {
+		err = errors.Wrapf(err, "error building JAMF mobile device request for mobile device: %v (%s)", lookup, ep)
+		return
+	}
+
+	res := &MobileDeviceDetails{}
+	if response, err = client.MakeAPIrequest(j.client, req, res); err != nil {
+		err = errors.Wrapf(err, "unable to query enrolled mobile device for mobile device: %v (%s)", lookup, ep)
+		return
+	}
+	result = res.Device
+	return
+}
+
+// Update will validate and update a mobile device. Only the sections set on device are sent so
+// a device holding only a Location updates the user and location of the device
+func (j *Service) Update(lookup Lookup, device *MobileDevice) (result *MobileDevice, response *http.Response, err error) {
+	ep, err := j.endpoint(lookup)
+	if err != nil {
+		return
+	}
+
+	if device == nil {
+		err = errors.Wrapf(fmt.Errorf("Empty payload"), "unable to process JAMF update request for mobile device: %v (%s)", lookup, ep)
+		return
+	}
+
+	if err = ValidateMobileDevice(device); err != nil {
+		err = errors.Wrapf(err, "mobile device validation failed: %v", lookup)
+		return
+	}
+
+	result = &MobileDevice{}
+	if response, err = client.SendXML(j.client, "PUT", ep, device, result); err != nil {
+		err = errors.Wrapf(err, "unable to process JAMF update request for mobile device: %v (%s)", lookup, ep)
+	}
+	return
+}
+
+// Delete will delete a mobile device from Jamf
+func (j *Service) Delete(lookup Lookup) (result *MobileDevice, response *http.Response, err error) {
+	ep, err := j.endpoint(lookup)
+	if err != nil {
+		return
+	}
+
+	result = &MobileDevice{}
+	if response, err = client.SendXML(j.client, "DELETE", ep, nil, result); err != nil {
+		err = errors.Wrapf(err, "unable to process JAMF deletion request for mobile device: %v (%s)", lookup, ep)
+	}
+	return
+}
+
+// endpoint returns the endpoint of a mobile device lookup
+func (j *Service) endpoint(lookup Lookup) (string, error) {
+	if lookup.key == "" || strings.TrimSpace(lookup.value) == "" {
+		return "", fmt.Errorf("invalid mobile device lookup: %q please use ByID, ByName, BySerialNumber, ByUDID or ByMACAddress", lookup.String())
+	}
+	return fmt.Sprintf("%s/%s", j.client.Endpoint, lookup), nil
+}
@@ -0,0 +1,301 @@
+// Unless explicitly stated otherwise all files in this repository are licensed under the Apache-2.0
+// This product includes software developed at Datadog (https://www.datadoghq.com/). Copyright 2020 Datadog, Inc.
+
+package mobiledevices
+
+import "encoding/xml"
+
+// MobileDevices represents a list of mobile devices enrolled in Jamf
+type MobileDevices struct {
+	List []BasicMobileDeviceInfo `json:"mobile_devices" xml:"mobile_device"`
+}
+
+// BasicMobileDeviceInfo represents the information returned in a list of all mobile devices from Jamf
+type BasicMobileDeviceInfo struct {
+	ID              int    `json:"id" xml:"id"`
+	Name            string `json:"name" xml:"name"`
+	DeviceName      string `json:"device_name" xml:"device_name"`
+	UDID            string `json:"udid" xml:"udid"`
+	SerialNumber    string `json:"serial_number" xml:"serial_number"`
+	PhoneNumber     string `json:"phone_number" xml:"phone_number"`
+	WifiMacAddress  string `json:"wifi_mac_address" xml:"wifi_mac_address"`
+	Managed         bool   `json:"managed" xml:"managed"`
+	Supervised      bool   `json:"supervised" xml:"supervised"`
+	Model           string `json:"model" xml:"model"`
+	ModelIdentifier string `json:"model_identifier" xml:"model_identifier"`
+	ModelDisplay    string `json:"model_display" xml:"model_display"`
+	Username        string `json:"username" xml:"username"`
+}
+
+// MobileDeviceDetails holds the details of a single mobile device as returned by Jamf
+type MobileDeviceDetails struct {
+	Device *MobileDevice `json:"mobile_device"`
+}
+
+// UnmarshalXML reads the mobile_device root element Jamf sends in XML responses
+func (m *MobileDeviceDetails) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	m.Device = &MobileDevice{}
+	return d.DecodeElement(m.Device, &start)
+}
+
+// MobileDevice represents an individual iOS, iPadOS or tvOS device enrolled in Jamf. Sections are
+// nil when they were not requested, see Subset, and only the sections set are sent on update
+type MobileDevice struct {
+	XMLName xml.Name `json:"-" xml:"mobile_device,omitempty"`
+	// ID is only set in the responses to updates and deletions, see General for the device Id
+	ID                    int                   `json:"id,omitempty" xml:"id,omitempty"`
+	General               *General              `json:"general,omitempty" xml:"general,omitempty"`
+	Location              *Location             `json:"location,omitempty" xml:"location,omitempty"`
+	Network               *Network              `json:"network,omitempty" xml:"network,omitempty"`
+	Security              *Security             `json:"security,omitempty" xml:"security,omitempty"`
+	Applications          Applications          `json:"applications,omitempty" xml:"applications,omitempty"`
+	Certificates          Certificates          `json:"certificates,omitempty" xml:"certificates,omitempty"`
+	ConfigurationProfiles ConfigurationProfiles `json:"configuration_profiles,omitempty" xml:"configuration_profiles,omitempty"`
+	ExtensionAttributes   ExtensionAttributes   `json:"extension_attributes,omitempty" xml:"extension_attributes,omitempty"`
+}
+
+// General holds basic information associated with a mobile device
+type General struct {
+	ID                          int    `json:"id,omitempty" xml:"id,omitempty"`
+	DisplayName                 string `json:"display_name,omitempty" xml:"display_name,omitempty"`
+	DeviceName                  string `json:"device_name,omitempty" xml:"device_name,omitempty"`
+	Name                        string `json:"name,omitempty" xml:"name,omitempty"`
+	AssetTag                    string `json:"asset_tag,omitempty" xml:"asset_tag,omitempty"`
+	LastInventoryUpdate         string `json:"last_inventory_update,omitempty" xml:"last_inventory_update,omitempty"`
+	LastInventoryUpdateEpoch    int64  `json:"last_inventory_update_epoch,omitempty" xml:"last_inventory_update_epoch,omitempty"`
+	LastInventoryUpdateUTC      string `json:"last_inventory_update_utc,omitempty" xml:"last_inventory_update_utc,omitempty"`
+	CapacityMB                  int    `json:"capacity_mb,omitempty" xml:"capacity_mb,omitempty"`
+	AvailableMB                 int    `json:"available_mb,omitempty" xml:"available_mb,omitempty"`
+	PercentageUsed              int    `json:"percentage_used,omitempty" xml:"percentage_used,omitempty"`
+	OSType                      string `json:"os_type,omitempty" xml:"os_type,omitempty"`
+	OSVersion                   string `json:"os_version,omitempty" xml:"os_version,omitempty"`
+	OSBuild                     string `json:"os_build,omitempty" xml:"os_build,omitempty"`
+	SerialNumber                string `json:"serial_number,omitempty" xml:"serial_number,omitempty"`
+	UDID                        string `json:"udid,omitempty" xml:"udid,omitempty"`
+	InitialEntryDateEpoch       int64  `json:"initial_entry_date_epoch,omitempty" xml:"initial_entry_date_epoch,omitempty"`
+	InitialEntryDateUTC         string `json:"initial_entry_date_utc,omitempty" xml:"initial_entry_date_utc,omitempty"`
+	PhoneNumber                 string `json:"phone_number,omitempty" xml:"phone_number,omitempty"`
+	IPAddress                   string `json:"ip_address,omitempty" xml:"ip_address,omitempty"`
+	WifiMacAddress              string `json:"wifi_mac_address,omitempty" xml:"wifi_mac_address,omitempty"`
+	BluetoothMacAddress         string `json:"bluetooth_mac_address,omitempty" xml:"bluetooth_mac_address,omitempty"`
+	ModemFirmware               string `json:"modem_firmware,omitempty" xml:"modem_firmware,omitempty"`
+	Model                       string `json:"model,omitempty" xml:"model,omitempty"`
+	ModelIdentifier             string `json:"model_identifier,omitempty" xml:"model_identifier,omitempty"`
+	ModelNumber                 string `json:"model_number,omitempty" xml:"model_number,omitempty"`
+	ModelDisplay                string `json:"model_display,omitempty" xml:"model_display,omitempty"`
+	DeviceOwnershipLevel        string `json:"device_ownership_level,omitempty" xml:"device_ownership_level,omitempty"`
+	EnrollmentMethod            string `json:"enrollment_method,omitempty" xml:"enrollment_method,omitempty"`
+	LastEnrollmentEpoch         int64  `json:"last_enrollment_epoch,omitempty" xml:"last_enrollment_epoch,omitempty"`
+	LastEnrollmentUTC           string `json:"last_enrollment_utc,omitempty" xml:"last_enrollment_utc,omitempty"`
+	Managed                     bool   `json:"managed" xml:"managed,omitempty"`
+	Supervised                  bool   `json:"supervised" xml:"supervised,omitempty"`
+	Shared                      string `json:"shared,omitempty" xml:"shared,omitempty"`
+	Tethered                    string `json:"tethered,omitempty" xml:"tethered,omitempty"`
+	BatteryLevel                int    `json:"battery_level,omitempty" xml:"battery_level,omitempty"`
+	DeviceLocatorServiceEnabled bool   `json:"device_locator_service_enabled" xml:"device_locator_service_enabled,omitempty"`
+	DoNotDisturbEnabled         bool   `json:"do_not_disturb_enabled" xml:"do_not_disturb_enabled,omitempty"`
+	CloudBackupEnabled          bool   `json:"cloud_backup_enabled" xml:"cloud_backup_enabled,omitempty"`
+	LastCloudBackupDateEpoch    int64  `json:"last_cloud_backup_date_epoch,omitempty" xml:"last_cloud_backup_date_epoch,omitempty"`
+	LocationServicesEnabled     bool   `json:"location_services_enabled" xml:"location_services_enabled,omitempty"`
+	ItunesStoreAccountIsActive  bool   `json:"itunes_store_account_is_active" xml:"itunes_store_account_is_active,omitempty"`
+	Site                        *Site  `json:"site,omitempty" xml:"site,omitempty"`
+}
+
+// Site is the Jamf site a mobile device belongs to
+type Site struct {
+	ID   int    `json:"id,omitempty" xml:"id,omitempty"`
+	Name string `json:"name,omitempty" xml:"name,omitempty"`
+}
+
+// Location holds the information in the User & Location section
+type Location struct {
+	Username     string `json:"username,omitempty" xml:"username,omitempty"`
+	RealName     string `json:"realname,omitempty" xml:"realname,omitempty"`
+	EmailAddress string `json:"email_address,omitempty" xml:"email_address,omitempty"`
+	Position     string `json:"position,omitempty" xml:"position,omitempty"`
+	PhoneNumber  string `json:"phone_number,omitempty" xml:"phone_number,omitempty"`
+	Department   string `json:"department,omitempty" xml:"department,omitempty"`
+	Building     string `json:"building,omitempty" xml:"building,omitempty"`
+	Room         string `json:"room,omitempty" xml:"room,omitempty"`
+}
+
+// Network holds the cellular information of a mobile device
+type Network struct {
+	HomeCarrierNetwork       string `json:"home_carrier_network,omitempty" xml:"home_carrier_network,omitempty"`
+	CellularTechnology       string `json:"cellular_technology,omitempty" xml:"cellular_technology,omitempty"`
+	VoiceRoamingEnabled      string `json:"voice_roaming_enabled,omitempty" xml:"voice_roaming_enabled,omitempty"`
+	IMEI                     string `json:"imei,omitempty" xml:"imei,omitempty"`
+	ICCID                    string `json:"iccid,omitempty" xml:"iccid,omitempty"`
+	MEID                     string `json:"meid,omitempty" xml:"meid,omitempty"`
+	CurrentCarrierNetwork    string `json:"current_carrier_network,omitempty" xml:"current_carrier_network,omitempty"`
+	CarrierSettingsVersion   string `json:"carrier_settings_version,omitempty" xml:"carrier_settings_version,omitempty"`
+	CurrentMobileCountryCode string `json:"current_mobile_country_code,omitempty" xml:"current_mobile_country_code,omitempty"`
+	CurrentMobileNetworkCode string `json:"current_mobile_network_code,omitempty" xml:"current_mobile_network_code,omitempty"`
+	HomeMobileCountryCode    string `json:"home_mobile_country_code,omitempty" xml:"home_mobile_country_code,omitempty"`
+	HomeMobileNetworkCode    string `json:"home_mobile_network_code,omitempty" xml:"home_mobile_network_code,omitempty"`
+	DataRoamingEnabled       bool   `json:"data_roaming_enabled" xml:"data_roaming_enabled,omitempty"`
+	Roaming                  bool   `json:"roaming" xml:"roaming,omitempty"`
+	PhoneNumber              string `json:"phone_number,omitempty" xml:"phone_number,omitempty"`
+}
+
+// Security holds the passcode, encryption and lost mode state of a mobile device
+type Security struct {
+	DataProtection                 bool    `json:"data_protection" xml:"data_protection,omitempty"`
+	BlockLevelEncryptionCapable    bool    `json:"block_level_encryption_capable" xml:"block_level_encryption_capable,omitempty"`
+	FileLevelEncryptionCapable     bool    `json:"file_level_encryption_capable" xml:"file_level_encryption_capable,omitempty"`
+	PasscodePresent                bool    `json:"passcode_present" xml:"passcode_present,omitempty"`
+	PasscodeCompliant              bool    `json:"passcode_compliant" xml:"passcode_compliant,omitempty"`
+	PasscodeCompliantWithProfile   bool    `json:"passcode_compliant_with_profile" xml:"passcode_compliant_with_profile,omitempty"`
+	HardwareEncryption             int     `json:"hardware_encryption,omitempty" xml:"hardware_encryption,omitempty"`
+	ActivationLockEnabled          bool    `json:"activation_lock_enabled" xml:"activation_lock_enabled,omitempty"`
+	JailbreakDetected              string  `json:"jailbreak_detected,omitempty" xml:"jailbreak_detected,omitempty"`
+	LostModeEnabled                string  `json:"lost_mode_enabled,omitempty" xml:"lost_mode_enabled,omitempty"`
+	LostModeEnforced               bool    `json:"lost_mode_enforced" xml:"lost_mode_enforced,omitempty"`
+	LostModeEnableIssuedEpoch      int64   `json:"lost_mode_enable_issued_epoch,omitempty" xml:"lost_mode_enable_issued_epoch,omitempty"`
+	LostModeMessage                string  `json:"lost_mode_message,omitempty" xml:"lost_mode_message,omitempty"`
+	LostModePhone                  string  `json:"lost_mode_phone,omitempty" xml:"lost_mode_phone,omitempty"`
+	LostModeFootnote               string  `json:"lost_mode_footnote,omitempty" xml:"lost_mode_footnote,omitempty"`
+	LostLocationEpoch              int64   `json:"lost_location_epoch,omitempty" xml:"lost_location_epoch,omitempty"`
+	LostLocationLatitude           float64 `json:"lost_location_latitude,omitempty" xml:"lost_location_latitude,omitempty"`
+	LostLocationLongitude          float64 `json:"lost_location_longitude,omitempty" xml:"lost_location_longitude,omitempty"`
+	LostLocationAltitude           float64 `json:"lost_location_altitude,omitempty" xml:"lost_location_altitude,omitempty"`
+	LostLocationSpeed              float64 `json:"lost_location_speed,omitempty" xml:"lost_location_speed,omitempty"`
+	LostLocationCourse             float64 `json:"lost_location_course,omitempty" xml:"lost_location_course,omitempty"`
+	LostLocationHorizontalAccuracy float64 `json:"lost_location_horizontal_accuracy,omitempty" xml:"lost_location_horizontal_accuracy,omitempty"`
+	LostLocationVerticalAccuracy   float64 `json:"lost_location_vertical_accuracy,omitempty" xml:"lost_location_vertical_accuracy,omitempty"`
+}
+
+// Application is an app installed on a mobile device
+type Application struct {
+	Name         string `json:"application_name" xml:"application_name"`
+	Version      string `json:"application_version" xml:"application_version"`
+	ShortVersion string `json:"application_short_version,omitempty" xml:"application_short_version,omitempty"`
+	Identifier   string `json:"identifier" xml:"identifier"`
+}
+
+// Certificate is a certificate installed on a mobile device
+type Certificate struct {
+	CommonName   string `json:"common_name" xml:"common_name"`
+	Identity     bool   `json:"identity" xml:"identity"`
+	ExpiresEpoch int64  `json:"expires_epoch,omitempty" xml:"expires_epoch,omitempty"`
+	ExpiresUTC   string `json:"expires_utc,omitempty" xml:"expires_utc,omitempty"`
+}
+
+// ConfigurationProfile is a configuration profile installed on a mobile device
+type ConfigurationProfile struct {
+	ID          int    `json:"id,omitempty" xml:"id,omitempty"`
+	DisplayName string `json:"display_name" xml:"display_name"`
+	Version     string `json:"version,omitempty" xml:"version,omitempty"`
+	Identifier  string `json:"identifier" xml:"identifier"`
+	UUID        string `json:"uuid,omitempty" xml:"uuid,omitempty"`
+}
+
+// ExtensionAttribute holds an extension attribute value of a mobile device. Either the ID or the
+// Name must be set when the value is updated
+type ExtensionAttribute struct {
+	ID         int    `json:"id,omitempty" xml:"id,omitempty"`
+	Name       string `json:"name,omitempty" xml:"name,omitempty"`
+	Type       string `json:"type,omitempty" xml:"type,omitempty"`
+	MultiValue bool   `json:"multi_value,omitempty" xml:"multi_value,omitempty"`
+	Value      string `json:"value" xml:"value"`
+}
+
+// Applications holds the apps of a mobile device, written as application elements in XML
+type Applications []*Application
+
+// Certificates holds the certificates of a mobile device, written as certificate elements in XML
+type Certificates []*Certificate
+
+// ConfigurationProfiles holds the profiles of a mobile device, written as configuration_profile
+// elements in XML
+type ConfigurationProfiles []*ConfigurationProfile
+
+// ExtensionAttributes holds the extension attribute values of a mobile device, written as
+// extension_attribute elements in XML
+type ExtensionAttributes []*ExtensionAttribute
+
+// MarshalXML writes each app as an application element and omits the element when empty
+func (a Applications) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	return marshalList(e, start, "application", a)
+}
+
+// UnmarshalXML reads each application element
+func (a *Applications) UnmarshalXML(d *xml.Decoder, start xml.StartElement) (err error) {
+	*a, err = unmarshalList[*Application](d, "application")
+	return
+}
+
+// MarshalXML writes each certificate as a certificate element and omits the element when empty
+func (c Certificates) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	return marshalList(e, start, "certificate", c)
+}
+
+// UnmarshalXML reads each certificate element
+func (c *Certificates) UnmarshalXML(d *xml.Decoder, start xml.StartElement) (err error) {
+	*c, err = unmarshalList[*Certificate](d, "certificate")
+	return
+}
+
+// MarshalXML writes each profile as a configuration_profile element and omits the element when empty
+func (p ConfigurationProfiles) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	return marshalList(e, start, "configuration_profile", p)
+}
+
+// UnmarshalXML reads each configuration_profile element
+func (p *ConfigurationProfiles) UnmarshalXML(d *xml.Decoder, start xml.StartElement) (err error) {
+	*p, err = unmarshalList[*ConfigurationProfile](d, "configuration_profile")
+	return
+}
+
+// MarshalXML writes each value as an extension_attribute element and omits the element when empty
+func (a ExtensionAttributes) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	return marshalList(e, start, "extension_attribute", a)
+}
+
+// UnmarshalXML reads each extension_attribute element
+func (a *ExtensionAttributes) UnmarshalXML(d *xml.Decoder, start xml.StartElement) (err error) {
+	*a, err = unmarshalList[*ExtensionAttribute](d, "extension_attribute")
+	return
+}
+
+func marshalList[T any](e *xml.Encoder, start xml.StartElement, item string, list []T) error {
+	if len(list) == 0 {
+		return nil
+	}
+	if err := e.EncodeToken(start); err != nil {
+		return err
+	}
+	for _, v := range list {
+		if err := e.EncodeElement(v, xml.StartElement{Name: xml.Name{Local: item}}); err != nil {
+			return err
+		}
+	}
+	return e.EncodeToken(start.End())
+}
+
+// unmarshalList reads the item elements of a list skipping others such as the size element Jamf adds
+func unmarshalList[T any](d *xml.Decoder, item string) ([]T, error) {
+	var list []T
+	for {
+		token, err := d.Token()
+		if err != nil {
+			return nil, err
+		}
+		switch t := token.(type) {
+		case xml.StartElement:
+			if t.Name.Local != item {
+				if err := d.Skip(); err != nil {
+					return nil, err
+				}
+				continue
+			}
+			var v T
+			if err := d.DecodeElement(&v, &t); err != nil {
+				return nil, err
+			}
+			list = append(list, v)
+		case xml.EndElement:
+			return list, nil
+		}
+	}
+}
@@ -0,0 +1,181 @@
+// Unless explicitly stated otherwise all files in this repository are licensed under the Apache-2.0
+// This product includes software developed at Datadog (https://www.datadoghq.com/). Copyright 2020 Datadog, Inc.
+
+package mobiledevices_test
+
+import (
+	"context"
+	"encoding/xml"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	jamf "github.com/trustero/jamf-api-client-go/classic/mobiledevices"
+)
+
+var MOBILE_DEVICES_API_BASE_ENDPOINT = "/JSSResource/mobiledevices"
+
+func mobileDeviceResponseMocks(t *testing.T, payloads *[]string) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "PUT" || r.Method == "DELETE" {
+			data, err := ioutil.ReadAll(r.Body)
+			assert.Nil(t, err)
+			*payloads = append(*payloads, r.Method+" "+r.RequestURI+" "+string(data))
+			w.Header().Set("Content-Type", "application/xml")
+			fmt.Fprint(w, `<?xml version="1.0" encoding="UTF-8"?><mobile_device><id>14</id></mobile_device>`)
+			return
+		}
+
+		switch r.RequestURI {
+		case MOBILE_DEVICES_API_BASE_ENDPOINT:
+			fmt.Fprint(w, `{
+				"mobile_devices": [
+					{"id": 14, "name": "Loaner iPad 1", "udid": "00008101-000A1B2C3D4E", "serial_number": "DMPXK1ABCDEF", "wifi_mac_address": "A0:B1:C2:D3:E4:F5", "managed": true, "supervised": true, "model": "iPad Air (4th Generation)"},
+					{"id": 15, "name": "Lobby Apple TV", "serial_number": "C07XK1ABCDEF", "managed": true, "model": "Apple TV 4K"}
+				]
+			}`)
+		case fmt.Sprintf("%s/id/14", MOBILE_DEVICES_API_BASE_ENDPOINT):
+			fmt.Fprint(w, `{
+				"mobile_device": {
+					"general": {
+						"id": 14,
+						"name": "Loaner iPad 1",
+						"os_type": "iPadOS",
+						"os_version": "17.4",
+						"serial_number": "DMPXK1ABCDEF",
+						"udid": "00008101-000A1B2C3D4E",
+						"wifi_mac_address": "A0:B1:C2:D3:E4:F5",
+						"managed": true,
+						"supervised": true,
+						"site": {"id": -1, "name": "None"}
+					},
+					"location": {"username": "jdoe", "department": "Sales", "building": "HQ", "room": "2.14"},
+					"network": {"imei": "35 000000 000000 0", "roaming": false},
+					"security": {"passcode_present": true, "data_protection": true, "lost_mode_enabled": "Unsupervised Device"},
+					"applications": [{"application_name": "Slack", "application_version": "24.03.10", "identifier": "com.tinyspeck.chatlyio"}],
+					"certificates": [{"common_name": "Jamf Pro MDM", "identity": true, "expires_utc": "2026-03-01T10:00:00.000+0000"}],
+					"configuration_profiles": [{"display_name": "Wi-Fi", "identifier": "com.example.wifi", "uuid": "6E3C5C1D"}],
+					"extension_attributes": [{"id": 2, "name": "Asset Owner", "type": "String", "multi_value": false, "value": "Sales"}]
+				}
+			}`)
+		case fmt.Sprintf("%s/serialnumber/DMPXK1ABCDEF/subset/General&ExtensionAttributes", MOBILE_DEVICES_API_BASE_ENDPOINT):
+			w.Header().Set("Content-Type", "application/xml")
+			fmt.Fprint(w, `<?xml version="1.0" encoding="UTF-8"?>
+				<mobile_device>
+					<general><id>14</id><name>Loaner iPad 1</name><serial_number>DMPXK1ABCDEF</serial_number><managed>true</managed></general>
+					<extension_attributes>
+						<size>2</size>
+						<extension_attribute><id>2</id><name>Asset Owner</name><type>String</type><value>Sales</value></extension_attribute>
+						<extension_attribute><id>3</id><name>Cart</name><type>String</type><value>B</value></extension_attribute>
+					</extension_attributes>
+				</mobile_device>`)
+		default:
+			http.Error(w, fmt.Sprintf("bad Jamf mobile devices API call to %s", r.RequestURI), http.StatusInternalServerError)
+		}
+	}))
+}
+
+func TestQueryMobileDevices(t *testing.T) {
+	var payloads []string
+	testServer := mobileDeviceResponseMocks(t, &payloads)
+	defer testServer.Close()
+	j, err := jamf.NewService(testServer.URL, "fake-username", "mock-password-cool", nil)
+	assert.Nil(t, err)
+
+	devices, _, err := j.List()
+	assert.Nil(t, err)
+	assert.Len(t, devices, 2)
+	assert.Equal(t, "DMPXK1ABCDEF", devices[0].SerialNumber)
+	assert.True(t, devices[0].Supervised)
+
+	all, err := j.Iterate().All(context.Background())
+	assert.Nil(t, err)
+	assert.Equal(t, devices, all)
+}
+
+func TestQueryMobileDeviceDetails(t *testing.T) {
+	var payloads []string
+	testServer := mobileDeviceResponseMocks(t, &payloads)
+	defer testServer.Close()
+	j, err := jamf.NewService(testServer.URL, "fake-username", "mock-password-cool", nil)
+	assert.Nil(t, err)
+
+	device, _, err := j.GetById(14)
+	assert.Nil(t, err)
+	assert.Equal(t, "iPadOS", device.General.OSType)
+	assert.Equal(t, "None", device.General.Site.Name)
+	assert.Equal(t, "Sales", device.Location.Department)
+	assert.Equal(t, "35 000000 000000 0", device.Network.IMEI)
+	assert.True(t, device.Security.PasscodePresent)
+	assert.Equal(t, "com.tinyspeck.chatlyio", device.Applications[0].Identifier)
+	assert.True(t, device.Certificates[0].Identity)
+	assert.Equal(t, "com.example.wifi", device.ConfigurationProfiles[0].Identifier)
+	assert.Equal(t, "Asset Owner", device.ExtensionAttributes[0].Name)
+
+	subset, _, err := j.Get(jamf.BySerialNumber("DMPXK1ABCDEF"), jamf.SubsetGeneral, jamf.SubsetExtensionAttributes)
+	assert.Nil(t, err)
+	assert.Equal(t, 14, subset.General.ID)
+	assert.Nil(t, subset.Location)
+	assert.Len(t, subset.ExtensionAttributes, 2)
+	assert.Equal(t, "B", subset.ExtensionAttributes[1].Value)
+
+	_, _, err = j.GetByUDID("")
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "invalid mobile device lookup")
+
+	_, _, err = j.GetByName("Missing")
+	assert.NotNil(t, err)
+}
+
+func TestUpdateMobileDevice(t *testing.T) {
+	var payloads []string
+	testServer := mobileDeviceResponseMocks(t, &payloads)
+	defer testServer.Close()
+	j, err := jamf.NewService(testServer.URL, "fake-username", "mock-password-cool", nil)
+	assert.Nil(t, err)
+
+	result, _, err := j.Update(jamf.ByMACAddress("A0:B1:C2:D3:E4:F5"), &jamf.MobileDevice{
+		Location:            &jamf.Location{Username: "asmith", Department: "Support"},
+		ExtensionAttributes: jamf.ExtensionAttributes{{Name: "Cart", Value: "C"}},
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, 14, result.ID)
+	assert.Equal(t, "PUT /JSSResource/mobiledevices/macaddress/A0:B1:C2:D3:E4:F5 "+
+		"<mobile_device><location><username>asmith</username><department>Support</department></location>"+
+		"<extension_attributes><extension_attribute><name>Cart</name><value>C</value></extension_attribute></extension_attributes>"+
+		"</mobile_device>", payloads[0])
+
+	_, _, err = j.Update(jamf.ByID(14), &jamf.MobileDevice{})
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "mobile device update has no sections to update")
+
+	_, _, err = j.Update(jamf.ByID(14), &jamf.MobileDevice{ExtensionAttributes: jamf.ExtensionAttributes{{Value: "x"}}})
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "extension attribute 0 requires an id or a name")
+
+	_, _, err = j.Update(jamf.ByID(14), nil)
+	assert.NotNil(t, err)
+
+	result, _, err = j.Delete(jamf.ByID(14))
+	assert.Nil(t, err)
+	assert.Equal(t, 14, result.ID)
+	assert.Equal(t, "DELETE /JSSResource/mobiledevices/id/14 ", payloads[1])
+}
+
+func TestMobileDeviceXMLRoundTrip(t *testing.T) {
+	device := &jamf.MobileDevice{
+		General:      &jamf.General{ID: 14, AssetTag: "A-100"},
+		Applications: jamf.Applications{{Name: "Slack", Version: "24.03.10", Identifier: "com.tinyspeck.chatlyio"}},
+	}
+	data, err := xml.Marshal(device)
+	assert.Nil(t, err)
+
+	decoded := &jamf.MobileDevice{}
+	assert.Nil(t, xml.Unmarshal(data, decoded))
+	assert.Equal(t, "A-100", decoded.General.AssetTag)
+	assert.Equal(t, device.Applications, decoded.Applications)
+	assert.Nil(t, decoded.Certificates)
+}
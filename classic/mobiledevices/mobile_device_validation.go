@@ -0,0 +1,26 @@
+// Unless explicitly stated otherwise all files in this repository are licensed under the Apache-2.0
+// This product includes software developed at Datadog (https://www.datadoghq.com/). Copyright 2020 Datadog, Inc.
+
+package mobiledevices
+
+import "fmt"
+
+// ValidateMobileDevice orchestrates mobile device update validation
+func ValidateMobileDevice(d *MobileDevice) error {
+	if d.General == nil && d.Location == nil && d.Network == nil && d.Security == nil && len(d.Applications) == 0 &&
+		len(d.Certificates) == 0 && len(d.ConfigurationProfiles) == 0 && len(d.ExtensionAttributes) == 0 {
+		return fmt.Errorf("mobile device update has no sections to update")
+	}
+
+	return d.ExtensionAttributes.Validate()
+}
+
+// Validate will validate that every extension attribute value names its extension attribute
+func (a ExtensionAttributes) Validate() error {
+	for i, ea := range a {
+		if ea == nil || (ea.ID == 0 && ea.Name == "") {
+			return fmt.Errorf("extension attribute %d requires an id or a name", i)
+		}
+	}
+	return nil
+}
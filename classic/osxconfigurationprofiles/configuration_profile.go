@@ -0,0 +1,133 @@
+// Unless explicitly stated otherwise all files in this repository are licensed under the Apache-2.0
+// This product includes software developed at Datadog (https://www.datadoghq.com/). Copyright 2020 Datadog, Inc.
+
+package osxconfigurationprofiles
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/pkg/errors"
+	"github.com/trustero/jamf-api-client-go/classic/client"
+	"github.com/trustero/jamf-api-client-go/pager"
+)
+
+// ConfigurationProfiles returns a list of the configuration profiles available in Jamf
+func (j *Service) ConfigurationProfiles() (result []BasicConfigurationProfileInfo, response *http.Response, err error) {
+	return j.list(context.Background())
+}
+
+// Iterate returns an iterator over the configuration profiles available in Jamf
+func (j *Service) Iterate() *pager.Pager[BasicConfigurationProfileInfo] {
+	return pager.Single(func(ctx context.Context) ([]BasicConfigurationProfileInfo, error) {
+		profiles, _, err := j.list(ctx)
+		return profiles, err
+	})
+}
+
+func (j *Service) list(ctx context.Context) (result []BasicConfigurationProfileInfo, response *http.Response, err error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", j.client.Endpoint, nil)
+	if err != nil {
+		err = errors.Wrap(err, "error building JAMF configuration profiles query request")
+		return
+	}
+
+	res := &ConfigurationProfiles{}
+	if response, err = client.MakeAPIrequest(j.client, req, res); err != nil {
+		err = errors.Wrapf(err, "unable to query available configuration profiles from %s", j.client.Endpoint)
+		return
+	}
+	result = res.List
+	return
+}
+
+// ConfigurationProfileDetails returns the details for a specific configuration profile given its Id or Name
+func (j *Service) ConfigurationProfileDetails(identifier interface{}) (result *ConfigurationProfile, response *http.Response, err error) {
+	ep, err := j.client.IdentifierEndpoint(identifier)
+	if err != nil {
+		err = errors.Wrapf(err, "error building JAMF query request endpoint for configuration profile: %v", identifier)
+		return
+	}
+
+	req, err := http.NewRequestWithContext(context.Background(), "GET", ep, nil)
+	if err != nil {
+		err = errors.Wrapf(err, "error building JAMF query request for configuration profile: %v", identifier)
+		return
+	}
+
+	res := &ConfigurationProfileDetails{}
+	if response, err = client.MakeAPIrequest(j.client, req, res); err != nil {
+		err = errors.Wrapf(err, "unable to query configuration profile with identifier: %v from %s", identifier, ep)
+		return
+	}
+	result = res.Profile
+	return
+}
+
+// CreateConfigurationProfile will validate and create a configuration profile in Jamf, the general
+// section must at least hold the profile name
+func (j *Service) CreateConfigurationProfile(profile *ConfigurationProfile) (result *ConfigurationProfile, response *http.Response, err error) {
+	// -1 denotes the next available Id
+	ep := j.client.IdEndpoint(-1)
+
+	if profile == nil {
+		err = errors.Wrapf(fmt.Errorf("Empty payload"), "unable to process JAMF creation request for configuration profile: (%s)", ep)
+		return
+	}
+
+	if err = ValidateConfigurationProfile(profile); err != nil {
+		err = errors.Wrapf(err, "configuration profile validation failed: %v", profile.name())
+		return
+	}
+
+	result = &ConfigurationProfile{}
+	if response, err = client.SendXML(j.client, "POST", ep, profile, result); err != nil {
+		err = errors.Wrapf(err, "unable to process JAMF creation request for configuration profile: %v (%s)", profile.name(), ep)
+	}
+	return
+}
+
+// UpdateConfigurationProfile will validate and update a configuration profile in Jamf by either Id or Name.
+// Only the sections which are set are updated and the name may be left empty to keep it unchanged
+func (j *Service) UpdateConfigurationProfile(identifier interface{}, profile *ConfigurationProfile) (result *ConfigurationProfile, response *http.Response, err error) {
+	ep, err := j.client.IdentifierEndpoint(identifier)
+	if err != nil {
+		err = errors.Wrapf(err, "error building JAMF query request for configuration profile: %v", identifier)
+		return
+	}
+
+	if profile == nil {
+		err = errors.Wrapf(fmt.Errorf("Empty payload"), "unable to process JAMF update request for configuration profile: %v (%s)", identifier, ep)
+		return
+	}
+
+	if profile.General != nil {
+		err = profile.General.Validate()
+	}
+	if err != nil {
+		err = errors.Wrapf(err, "configuration profile validation failed: %v", identifier)
+		return
+	}
+
+	result = &ConfigurationProfile{}
+	if response, err = client.SendXML(j.client, "PUT", ep, profile, result); err != nil {
+		err = errors.Wrapf(err, "unable to process JAMF update request for configuration profile: %v (%s)", identifier, ep)
+	}
+	return
+}
+
+// DeleteConfigurationProfile will delete a configuration profile by either Id or Name
+func (j *Service) DeleteConfigurationProfile(identifier interface{}) (result *ConfigurationProfile, response *http.Response, err error) {
+	ep, err := j.client.IdentifierEndpoint(identifier)
+	if err != nil {
+		err = errors.Wrapf(err, "error building JAMF query request for configuration profile: %v", identifier)
+		return
+	}
+
+	result = &ConfigurationProfile{}
+	if response, err = client.SendXML(j.client, "DELETE", ep, nil, result); err != nil {
+		err = errors.Wrapf(err, "unable to process JAMF deletion request for configuration profile: %v (%s)", identifier, ep)
+	}
+	return
+}
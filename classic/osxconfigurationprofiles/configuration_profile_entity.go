@@ -0,0 +1,193 @@
+// Unless explicitly stated otherwise all files in this repository are licensed under the Apache-2.0
+// This product includes software developed at Datadog (https://www.datadoghq.com/). Copyright 2020 Datadog, Inc.
+
+package osxconfigurationprofiles
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"strings"
+
+	"github.com/trustero/jamf-api-client-go/classic/policies"
+	"github.com/trustero/jamf-api-client-go/plist"
+	"github.com/trustero/jamf-api-client-go/plist/mobileconfig"
+)
+
+// Level is the level a configuration profile is installed at
+type Level string
+
+const (
+	ComputerLevel Level = "computer"
+	UserLevel     Level = "user"
+)
+
+// DistributionMethod is how a configuration profile is delivered to the computers in its scope
+type DistributionMethod string
+
+const (
+	InstallAutomatically DistributionMethod = "Install Automatically"
+	SelfServiceInstall   DistributionMethod = "Make Available in Self Service"
+)
+
+// ConfigurationProfiles holds a list of all the macOS configuration profiles available in Jamf
+type ConfigurationProfiles struct {
+	List []BasicConfigurationProfileInfo `json:"os_x_configuration_profiles"`
+}
+
+// BasicConfigurationProfileInfo holds the most basic information about a macOS configuration profile in Jamf
+type BasicConfigurationProfileInfo struct {
+	ID   int    `json:"id,omitempty" xml:"id,omitempty"`
+	Name string `json:"name" xml:"name"`
+}
+
+// ConfigurationProfileDetails holds the details to a specific configuration profile queried by Id or Name
+type ConfigurationProfileDetails struct {
+	Profile *ConfigurationProfile `json:"os_x_configuration_profile"`
+}
+
+// UnmarshalXML reads the os_x_configuration_profile root element Jamf sends in XML responses
+func (c *ConfigurationProfileDetails) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	c.Profile = &ConfigurationProfile{}
+	return d.DecodeElement(c.Profile, &start)
+}
+
+// ConfigurationProfile represents a macOS configuration profile in Jamf
+type ConfigurationProfile struct {
+	XMLName xml.Name `json:"-" xml:"os_x_configuration_profile,omitempty"`
+	// ID is only set in the responses to creations, updates and deletions, see General for the profile Id
+	ID          int                   `json:"id,omitempty" xml:"id,omitempty"`
+	General     *General              `json:"general,omitempty" xml:"general,omitempty"`
+	Scope       *policies.Scope       `json:"scope,omitempty" xml:"scope,omitempty"`
+	SelfService *policies.SelfService `json:"self_service,omitempty" xml:"self_service,omitempty"`
+}
+
+// General holds the general settings and the payloads of a configuration profile
+type General struct {
+	ID                 int                `json:"id,omitempty" xml:"id,omitempty"`
+	Name               string             `json:"name" xml:"name,omitempty"`
+	Description        string             `json:"description,omitempty" xml:"description,omitempty"`
+	Site               *Site              `json:"site,omitempty" xml:"site,omitempty"`
+	Category           *Category          `json:"category,omitempty" xml:"category,omitempty"`
+	DistributionMethod DistributionMethod `json:"distribution_method,omitempty" xml:"distribution_method,omitempty"`
+	UserRemovable      *bool              `json:"user_removable,omitempty" xml:"user_removable,omitempty"`
+	Level              Level              `json:"level,omitempty" xml:"level,omitempty"`
+	UUID               string             `json:"uuid,omitempty" xml:"uuid,omitempty"`
+	RedeployOnUpdate   string             `json:"redeploy_on_update,omitempty" xml:"redeploy_on_update,omitempty"`
+	Payloads           Payloads           `json:"payloads,omitempty" xml:"payloads,omitempty"`
+}
+
+// Site is the site a configuration profile belongs to
+type Site struct {
+	ID   int    `json:"id" xml:"id"`
+	Name string `json:"name,omitempty" xml:"name,omitempty"`
+}
+
+// Category is the category a configuration profile belongs to
+type Category struct {
+	ID   int    `json:"id" xml:"id"`
+	Name string `json:"name,omitempty" xml:"name,omitempty"`
+}
+
+// name returns the name of the profile for error messages
+func (c *ConfigurationProfile) name() string {
+	if c.General == nil {
+		return ""
+	}
+	return c.General.Name
+}
+
+// Payloads is the mobileconfig property list of a configuration profile. Jamf sends it as a string
+// which is parsed so payloads can be read and edited, see plist.Dict, or read into typed structs using
+// Profile. Keys the caller does not touch are written back unchanged and in order
+type Payloads struct {
+	*plist.Dict
+}
+
+// Set adds or replaces the value of a key of the payloads, the property list is created when
+// the profile has no payloads yet
+func (p *Payloads) Set(key string, value interface{}) *plist.Dict {
+	p.Dict = p.Dict.Set(key, value)
+	return p.Dict
+}
+
+// Content returns the dictionaries of the PayloadContent array, one for each payload of the profile
+func (p Payloads) Content() []*plist.Dict {
+	return p.Dicts("PayloadContent")
+}
+
+// Profile reads the payloads into a mobileconfig profile with typed structs for common payloads
+func (p Payloads) Profile() (*mobileconfig.Profile, error) {
+	return mobileconfig.FromDict(p.Dict)
+}
+
+// NewPayloads writes a mobileconfig profile to the payloads of a configuration profile
+func NewPayloads(profile *mobileconfig.Profile) (Payloads, error) {
+	dict, err := profile.Dict()
+	if err != nil {
+		return Payloads{}, err
+	}
+	return Payloads{Dict: dict}, nil
+}
+
+func (p Payloads) encode() (string, error) {
+	if p.Dict == nil {
+		return "", nil
+	}
+	data, err := plist.Encode(p.Dict)
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
+
+func (p *Payloads) decode(s string) error {
+	if strings.TrimSpace(s) == "" {
+		p.Dict = nil
+		return nil
+	}
+	dict, err := plist.DecodeDict([]byte(s))
+	if err != nil {
+		return err
+	}
+	p.Dict = dict
+	return nil
+}
+
+// MarshalJSON writes the payloads as a property list string
+func (p Payloads) MarshalJSON() ([]byte, error) {
+	s, err := p.encode()
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(s)
+}
+
+// UnmarshalJSON parses the property list string of the payloads
+func (p *Payloads) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+	return p.decode(s)
+}
+
+// MarshalXML writes the payloads as an escaped property list and omits the payloads element when empty
+func (p Payloads) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	if p.Dict == nil {
+		return nil
+	}
+	s, err := p.encode()
+	if err != nil {
+		return err
+	}
+	return e.EncodeElement(s, start)
+}
+
+// UnmarshalXML parses the escaped property list of the payloads
+func (p *Payloads) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	var s string
+	if err := d.DecodeElement(&s, &start); err != nil {
+		return err
+	}
+	return p.decode(s)
+}
@@ -0,0 +1,246 @@
+// Unless explicitly stated otherwise all files in this repository are licensed under the Apache-2.0
+// This product includes software developed at Datadog (https://www.datadoghq.com/). Copyright 2020 Datadog, Inc.
+
+package osxconfigurationprofiles_test
+
+import (
+	"context"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	jamf "github.com/trustero/jamf-api-client-go/classic/osxconfigurationprofiles"
+	"github.com/trustero/jamf-api-client-go/classic/policies"
+	"github.com/trustero/jamf-api-client-go/plist"
+	"github.com/trustero/jamf-api-client-go/plist/mobileconfig"
+)
+
+var CONFIGURATION_PROFILES_API_BASE_ENDPOINT = "/JSSResource/osxconfigurationprofiles"
+
+const pppcPayloads = `<?xml version="1.0" encoding="UTF-8"?>
+<!DOCTYPE plist PUBLIC "-//Apple//DTD PLIST 1.0//EN" "http://www.apple.com/DTDs/PropertyList-1.0.dtd">
+<plist version="1.0">
+<dict>
+	<key>PayloadContent</key>
+	<array>
+		<dict>
+			<key>PayloadType</key>
+			<string>com.apple.TCC.configuration-profile-policy</string>
+			<key>PayloadUUID</key>
+			<string>6B1A5C3E-2F0B-4C4B-9C5E-1E2D3F4A5B6C</string>
+			<key>Services</key>
+			<dict>
+				<key>SystemPolicyAllFiles</key>
+				<array>
+					<dict>
+						<key>Identifier</key>
+						<string>com.example.agent</string>
+						<key>IdentifierType</key>
+						<string>bundleID</string>
+						<key>CodeRequirement</key>
+						<string>identifier "com.example.agent" and anchor apple generic</string>
+						<key>Allowed</key>
+						<true/>
+					</dict>
+				</array>
+			</dict>
+		</dict>
+	</array>
+	<key>PayloadDisplayName</key>
+	<string>Agent Full Disk Access</string>
+	<key>PayloadIdentifier</key>
+	<string>com.example.agent.pppc</string>
+	<key>PayloadType</key>
+	<string>Configuration</string>
+</dict>
+</plist>
+`
+
+func configurationProfilesResponseMocks(t *testing.T, payloads *[]string) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.RequestURI {
+		case CONFIGURATION_PROFILES_API_BASE_ENDPOINT:
+			fmt.Fprint(w, `{
+				"os_x_configuration_profiles": [
+					{"id": 3, "name": "Agent Full Disk Access"},
+					{"id": 8, "name": "Wi-Fi"}
+				]
+			}`)
+		case fmt.Sprintf("%s/id/3", CONFIGURATION_PROFILES_API_BASE_ENDPOINT):
+			if r.Method != "GET" {
+				data, err := ioutil.ReadAll(r.Body)
+				assert.Nil(t, err)
+				*payloads = append(*payloads, r.Method+" "+string(data))
+				w.Header().Set("Content-Type", "application/xml")
+				fmt.Fprint(w, `<os_x_configuration_profile><id>3</id></os_x_configuration_profile>`)
+				return
+			}
+			payloadsString, err := json.Marshal(pppcPayloads)
+			assert.Nil(t, err)
+			fmt.Fprintf(w, `{
+				"os_x_configuration_profile": {
+					"general": {
+						"id": 3,
+						"name": "Agent Full Disk Access",
+						"description": "",
+						"site": {"id": -1, "name": "None"},
+						"category": {"id": 2, "name": "Security"},
+						"distribution_method": "Install Automatically",
+						"user_removable": false,
+						"level": "computer",
+						"uuid": "0A6C2F4E-8B1D-4E3F-A5C7-9D0E1F2A3B4C",
+						"redeploy_on_update": "Newly Assigned",
+						"payloads": %s
+					},
+					"scope": {
+						"all_computers": false,
+						"computers": [],
+						"computer_groups": [{"id": 6, "name": "Agents"}],
+						"buildings": [],
+						"departments": []
+					},
+					"self_service": {"self_service_display_name": "Agent Full Disk Access"}
+				}
+			}`, payloadsString)
+		case fmt.Sprintf("%s/id/-1", CONFIGURATION_PROFILES_API_BASE_ENDPOINT):
+			data, err := ioutil.ReadAll(r.Body)
+			assert.Nil(t, err)
+			*payloads = append(*payloads, r.Method+" "+string(data))
+			w.Header().Set("Content-Type", "application/xml")
+			fmt.Fprint(w, `<os_x_configuration_profile><id>9</id></os_x_configuration_profile>`)
+		case fmt.Sprintf("%s/id/5", CONFIGURATION_PROFILES_API_BASE_ENDPOINT):
+			w.Header().Set("Content-Type", "application/xml")
+			fmt.Fprint(w, `<os_x_configuration_profile><general><id>5</id><name>Notifications</name><payloads>&lt;?xml version="1.0" encoding="UTF-8"?&gt;&lt;plist version="1"&gt;&lt;dict&gt;&lt;key&gt;PayloadContent&lt;/key&gt;&lt;array&gt;&lt;dict&gt;&lt;key&gt;PayloadType&lt;/key&gt;&lt;string&gt;com.apple.notificationsettings&lt;/string&gt;&lt;/dict&gt;&lt;/array&gt;&lt;/dict&gt;&lt;/plist&gt;</payloads></general></os_x_configuration_profile>`)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+}
+
+func TestQueryConfigurationProfiles(t *testing.T) {
+	testServer := configurationProfilesResponseMocks(t, &[]string{})
+	defer testServer.Close()
+	j, err := jamf.NewService(testServer.URL, "fake-username", "mock-password-cool", nil)
+	assert.Nil(t, err)
+
+	profiles, _, err := j.ConfigurationProfiles()
+	assert.Nil(t, err)
+	assert.Equal(t, []jamf.BasicConfigurationProfileInfo{{ID: 3, Name: "Agent Full Disk Access"}, {ID: 8, Name: "Wi-Fi"}}, profiles)
+
+	all, err := j.Iterate().All(context.Background())
+	assert.Nil(t, err)
+	assert.Equal(t, profiles, all)
+
+	profile, _, err := j.ConfigurationProfileDetails(3)
+	assert.Nil(t, err)
+	assert.Equal(t, jamf.ComputerLevel, profile.General.Level)
+	assert.Equal(t, jamf.InstallAutomatically, profile.General.DistributionMethod)
+	assert.Equal(t, "Security", profile.General.Category.Name)
+	assert.Equal(t, "Agents", profile.Scope.ComputerGroups[0].Name)
+	assert.Equal(t, "com.example.agent.pppc", profile.General.Payloads.String("PayloadIdentifier"))
+	content := profile.General.Payloads.Content()
+	assert.Len(t, content, 1)
+	assert.Equal(t, "com.apple.TCC.configuration-profile-policy", content[0].String("PayloadType"))
+	rule := content[0].Dict("Services").Dicts("SystemPolicyAllFiles")[0]
+	assert.True(t, rule.Bool("Allowed"))
+
+	typed, err := profile.General.Payloads.Profile()
+	assert.Nil(t, err)
+	pppc := mobileconfig.PayloadsOf[*mobileconfig.PPPC](typed)
+	assert.Equal(t, mobileconfig.BundleID, pppc[0].Services[mobileconfig.SystemPolicyAllFiles][0].IdentifierType)
+
+	// the payloads are written back as they were read
+	data, err := plist.Encode(profile.General.Payloads.Dict)
+	assert.Nil(t, err)
+	assert.Equal(t, pppcPayloads, string(data))
+
+	profile, _, err = j.ConfigurationProfileDetails(5)
+	assert.Nil(t, err)
+	assert.Equal(t, "com.apple.notificationsettings", profile.General.Payloads.Content()[0].String("PayloadType"))
+
+	_, _, err = j.ConfigurationProfileDetails(404)
+	assert.NotNil(t, err)
+}
+
+func TestEditConfigurationProfiles(t *testing.T) {
+	payloads := []string{}
+	testServer := configurationProfilesResponseMocks(t, &payloads)
+	defer testServer.Close()
+	j, err := jamf.NewService(testServer.URL, "fake-username", "mock-password-cool", nil)
+	assert.Nil(t, err)
+
+	profile, _, err := j.ConfigurationProfileDetails(3)
+	assert.Nil(t, err)
+	rule := profile.General.Payloads.Content()[0].Dict("Services").Dicts("SystemPolicyAllFiles")[0]
+	rule.Set("Allowed", false)
+	updated, _, err := j.UpdateConfigurationProfile(3, &jamf.ConfigurationProfile{General: &jamf.General{
+		Name:     profile.General.Name,
+		Payloads: profile.General.Payloads,
+	}})
+	assert.Nil(t, err)
+	assert.Equal(t, 3, updated.ID)
+
+	notifications := plist.NewDict().
+		Set("PayloadContent", []*plist.Dict{plist.NewDict().Set("PayloadType", "com.apple.notificationsettings")}).
+		Set("PayloadType", "Configuration")
+	created, _, err := j.CreateConfigurationProfile(&jamf.ConfigurationProfile{
+		General: &jamf.General{Name: "Notifications", Level: jamf.ComputerLevel, Payloads: jamf.Payloads{Dict: notifications}},
+		Scope:   &policies.Scope{AllComputers: true},
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, 9, created.ID)
+
+	// typed payloads are validated before anything is sent
+	invalid, err := jamf.NewPayloads(mobileconfig.NewProfile("com.example.loginitems", "Login Items").Add(
+		mobileconfig.NewLoginItems(&mobileconfig.LoginItemRule{RuleType: mobileconfig.TeamIdentifierRule}),
+	))
+	assert.Nil(t, err)
+	_, _, err = j.CreateConfigurationProfile(&jamf.ConfigurationProfile{General: &jamf.General{Name: "Login Items", Payloads: invalid}})
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "payload 0 (com.apple.servicemanagement): login item rule 0 requires a rule value")
+
+	_, _, err = j.DeleteConfigurationProfile(3)
+	assert.Nil(t, err)
+
+	// validation runs before anything is sent
+	_, _, err = j.CreateConfigurationProfile(&jamf.ConfigurationProfile{Scope: &policies.Scope{AllComputers: true}})
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "configuration profile name is required")
+	_, _, err = j.CreateConfigurationProfile(&jamf.ConfigurationProfile{General: &jamf.General{Name: "Bad", Level: "system"}})
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "system is not a valid level must be of type [ computer, user ]")
+	_, _, err = j.UpdateConfigurationProfile(3, &jamf.ConfigurationProfile{General: &jamf.General{
+		Name:     "Bad",
+		Payloads: jamf.Payloads{Dict: plist.NewDict().Set("PayloadContent", []*plist.Dict{plist.NewDict()})},
+	}})
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "payload 0 requires a PayloadType")
+
+	// only the scope is sent when the general section is left out
+	_, _, err = j.UpdateConfigurationProfile(3, &jamf.ConfigurationProfile{Scope: &policies.Scope{AllComputers: true}})
+	assert.Nil(t, err)
+
+	assert.Len(t, payloads, 4)
+	assert.Contains(t, payloads[0], "PUT <os_x_configuration_profile><general><name>Agent Full Disk Access</name><payloads>&lt;?xml version=")
+	sent := &jamf.ConfigurationProfile{}
+	assert.Nil(t, xml.Unmarshal([]byte(strings.TrimPrefix(payloads[0], "PUT ")), sent))
+	assert.False(t, sent.General.Payloads.Content()[0].Dict("Services").Dicts("SystemPolicyAllFiles")[0].Bool("Allowed"))
+	assert.Equal(t, profile.General.Payloads.Keys(), sent.General.Payloads.Keys())
+	assert.Contains(t, payloads[1], "POST <os_x_configuration_profile><general><name>Notifications</name><level>computer</level><payloads>")
+	assert.Contains(t, payloads[1], "</payloads></general><scope><all_computers>true</all_computers><computers></computers><computer_groups></computer_groups></scope></os_x_configuration_profile>")
+	assert.Equal(t, "DELETE ", payloads[2])
+	assert.Equal(t, "PUT <os_x_configuration_profile><scope><all_computers>true</all_computers><computers></computers><computer_groups></computer_groups></scope></os_x_configuration_profile>", payloads[3])
+}
+
+func TestSetPayloads(t *testing.T) {
+	// keys can be set on a profile which has no payloads yet
+	payloads := jamf.Payloads{}
+	payloads.Set("PayloadIdentifier", "com.example.profile").Set("PayloadType", "Configuration")
+	assert.Equal(t, []string{"PayloadIdentifier", "PayloadType"}, payloads.Keys())
+}
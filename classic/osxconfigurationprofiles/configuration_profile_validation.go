@@ -0,0 +1,69 @@
+// Unless explicitly stated otherwise all files in this repository are licensed under the Apache-2.0
+// This product includes software developed at Datadog (https://www.datadoghq.com/). Copyright 2020 Datadog, Inc.
+
+package osxconfigurationprofiles
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ValidateConfigurationProfile orchestrates the validation of a configuration profile about to be
+// created, see UpdateConfigurationProfile for updates
+func ValidateConfigurationProfile(p *ConfigurationProfile) error {
+	if p.General == nil || strings.TrimSpace(p.General.Name) == "" {
+		return fmt.Errorf("configuration profile name is required")
+	}
+	return p.General.Validate()
+}
+
+// Validate will validate the level, distribution method and payloads of a profile when they are set
+func (g *General) Validate() error {
+	if g.Level != "" {
+		if err := g.Level.Validate(); err != nil {
+			return err
+		}
+	}
+
+	if g.DistributionMethod != "" {
+		if err := g.DistributionMethod.Validate(); err != nil {
+			return err
+		}
+	}
+
+	return g.Payloads.Validate()
+}
+
+// Validate will validate that a level is supported by Jamf
+func (l Level) Validate() error {
+	switch l {
+	case ComputerLevel, UserLevel:
+		return nil
+	default:
+		return fmt.Errorf("%s is not a valid level must be of type [ %s, %s ]", l, ComputerLevel, UserLevel)
+	}
+}
+
+// Validate will validate that a distribution method is supported by Jamf
+func (m DistributionMethod) Validate() error {
+	switch m {
+	case InstallAutomatically, SelfServiceInstall:
+		return nil
+	default:
+		return fmt.Errorf("%s is not a valid distribution method must be of type [ %s, %s ]", m, InstallAutomatically, SelfServiceInstall)
+	}
+}
+
+// Validate will validate that every payload of the profile names its payload type and that the
+// payloads read into typed structs are valid, see mobileconfig.Profile.Validate
+func (p Payloads) Validate() error {
+	if p.Dict == nil {
+		return nil
+	}
+
+	profile, err := p.Profile()
+	if err != nil {
+		return err
+	}
+	return profile.Validate()
+}
@@ -0,0 +1,129 @@
+// Unless explicitly stated otherwise all files in this repository are licensed under the Apache-2.0
+// This product includes software developed at Datadog (https://www.datadoghq.com/). Copyright 2020 Datadog, Inc.
+
+package packages
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/pkg/errors"
+	"github.com/trustero/jamf-api-client-go/classic/client"
+	"github.com/trustero/jamf-api-client-go/pager"
+)
+
+// Packages returns a list of the packages available in Jamf
+func (j *Service) Packages() (result []BasicPackageInfo, response *http.Response, err error) {
+	return j.list(context.Background())
+}
+
+// Iterate returns an iterator over the packages available in Jamf
+func (j *Service) Iterate() *pager.Pager[BasicPackageInfo] {
+	return pager.Single(func(ctx context.Context) ([]BasicPackageInfo, error) {
+		packages, _, err := j.list(ctx)
+		return packages, err
+	})
+}
+
+func (j *Service) list(ctx context.Context) (result []BasicPackageInfo, response *http.Response, err error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", j.client.Endpoint, nil)
+	if err != nil {
+		err = errors.Wrap(err, "error building JAMF packages query request")
+		return
+	}
+
+	res := &Packages{}
+	if response, err = client.MakeAPIrequest(j.client, req, res); err != nil {
+		err = errors.Wrapf(err, "unable to query available packages from %s", j.client.Endpoint)
+		return
+	}
+	result = res.List
+	return
+}
+
+// PackageDetails returns the details for a specific package given its Id or Name
+func (j *Service) PackageDetails(identifier interface{}) (result *Package, response *http.Response, err error) {
+	ep, err := j.client.IdentifierEndpoint(identifier)
+	if err != nil {
+		err = errors.Wrapf(err, "error building JAMF query request endpoint for package: %v", identifier)
+		return
+	}
+
+	req, err := http.NewRequestWithContext(context.Background(), "GET", ep, nil)
+	if err != nil {
+		err = errors.Wrapf(err, "error building JAMF query request for package: %v", identifier)
+		return
+	}
+
+	res := &PackageDetails{}
+	if response, err = client.MakeAPIrequest(j.client, req, res); err != nil {
+		err = errors.Wrapf(err, "unable to query package with identifier: %v from %s", identifier, ep)
+		return
+	}
+	result = res.Package
+	return
+}
+
+// CreatePackage will validate and create the metadata of a package in Jamf, the package file
+// must be uploaded to a distribution point using the same filename
+func (j *Service) CreatePackage(pkg *Package) (result *Package, response *http.Response, err error) {
+	// -1 denotes the next available Id
+	ep := j.client.IdEndpoint(-1)
+
+	if pkg == nil {
+		err = errors.Wrapf(fmt.Errorf("Empty payload"), "unable to process JAMF creation request for package: (%s)", ep)
+		return
+	}
+
+	if err = ValidatePackage(pkg); err != nil {
+		err = errors.Wrapf(err, "package validation failed: %v", pkg.Name)
+		return
+	}
+
+	result = &Package{}
+	if response, err = client.SendXML(j.client, "POST", ep, pkg, result); err != nil {
+		err = errors.Wrapf(err, "unable to process JAMF creation request for package: %v (%s)", pkg.Name, ep)
+	}
+	return
+}
+
+// UpdatePackage will validate and update the metadata of a package in Jamf by either Id or Name
+func (j *Service) UpdatePackage(identifier interface{}, pkg *Package) (result *Package, response *http.Response, err error) {
+	ep, err := j.client.IdentifierEndpoint(identifier)
+	if err != nil {
+		err = errors.Wrapf(err, "error building JAMF query request for package: %v", identifier)
+		return
+	}
+
+	if pkg == nil {
+		err = errors.Wrapf(fmt.Errorf("Empty payload"), "unable to process JAMF update request for package: %v (%s)", identifier, ep)
+		return
+	}
+
+	if err = ValidatePackage(pkg); err != nil {
+		err = errors.Wrapf(err, "package validation failed: %v", identifier)
+		return
+	}
+
+	result = &Package{}
+	if response, err = client.SendXML(j.client, "PUT", ep, pkg, result); err != nil {
+		err = errors.Wrapf(err, "unable to process JAMF update request for package: %v (%s)", identifier, ep)
+	}
+	return
+}
+
+// DeletePackage will delete a package by either Id or Name
+func (j *Service) DeletePackage(identifier interface{}) (result *Package, response *http.Response, err error) {
+	ep, err := j.client.IdentifierEndpoint(identifier)
+	if err != nil {
+		err = errors.Wrapf(err, "error building JAMF query request for package: %v", identifier)
+		return
+	}
+
+	result = &Package{}
+	if response, err = client.SendXML(j.client, "DELETE", ep, nil, result); err != nil {
+		err = errors.Wrapf(err, "unable to process JAMF deletion request for package: %v (%s)", identifier, ep)
+	}
+	return
+}
@@ -0,0 +1,64 @@
+// Unless explicitly stated otherwise all files in this repository are licensed under the Apache-2.0
+// This product includes software developed at Datadog (https://www.datadoghq.com/). Copyright 2020 Datadog, Inc.
+
+package packages
+
+import "encoding/xml"
+
+// HashType is the algorithm used for the hash of a package file
+type HashType string
+
+const (
+	MD5    HashType = "MD5"
+	SHA512 HashType = "SHA_512"
+)
+
+// NoCategory is the category Jamf reports for packages without a category
+const NoCategory = "No category assigned"
+
+// Packages holds a list of all the packages available in Jamf
+type Packages struct {
+	List []BasicPackageInfo `json:"packages"`
+}
+
+// BasicPackageInfo holds the most basic information about a package in Jamf
+type BasicPackageInfo struct {
+	ID   int    `json:"id,omitempty" xml:"id,omitempty"`
+	Name string `json:"name" xml:"name"`
+}
+
+// PackageDetails holds the details to a specific package queried by Id or Name
+type PackageDetails struct {
+	Package *Package `json:"package"`
+}
+
+// UnmarshalXML reads the package root element Jamf sends in XML responses
+func (p *PackageDetails) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	p.Package = &Package{}
+	return d.DecodeElement(p.Package, &start)
+}
+
+// Package represents the metadata of a package in Jamf, the package file itself is uploaded to a
+// distribution point separately
+type Package struct {
+	XMLName xml.Name `json:"-" xml:"package,omitempty"`
+	ID      int      `json:"id,omitempty" xml:"id,omitempty"`
+	Name    string   `json:"name" xml:"name,omitempty"`
+	// Category is the name of the category of the package, see NoCategory
+	Category          string   `json:"category,omitempty" xml:"category,omitempty"`
+	Filename          string   `json:"filename" xml:"filename,omitempty"`
+	Info              string   `json:"info,omitempty" xml:"info,omitempty"`
+	Notes             string   `json:"notes,omitempty" xml:"notes,omitempty"`
+	Priority          int      `json:"priority,omitempty" xml:"priority,omitempty"`
+	RebootRequired    *bool    `json:"reboot_required,omitempty" xml:"reboot_required,omitempty"`
+	FillUserTemplate  *bool    `json:"fill_user_template,omitempty" xml:"fill_user_template,omitempty"`
+	FillExistingUsers *bool    `json:"fill_existing_users,omitempty" xml:"fill_existing_users,omitempty"`
+	AllowUninstalled  *bool    `json:"allow_uninstalled,omitempty" xml:"allow_uninstalled,omitempty"`
+	OSRequirements    string   `json:"os_requirements,omitempty" xml:"os_requirements,omitempty"`
+	RequiredProcessor string   `json:"required_processor,omitempty" xml:"required_processor,omitempty"`
+	SwitchWithPackage string   `json:"switch_with_package,omitempty" xml:"switch_with_package,omitempty"`
+	ReinstallOption   string   `json:"reinstall_option,omitempty" xml:"reinstall_option,omitempty"`
+	SendNotification  *bool    `json:"send_notification,omitempty" xml:"send_notification,omitempty"`
+	HashType          HashType `json:"hash_type,omitempty" xml:"hash_type,omitempty"`
+	HashValue         string   `json:"hash_value,omitempty" xml:"hash_value,omitempty"`
+}
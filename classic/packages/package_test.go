@@ -0,0 +1,156 @@
+// Unless explicitly stated otherwise all files in this repository are licensed under the Apache-2.0
+// This product includes software developed at Datadog (https://www.datadoghq.com/). Copyright 2020 Datadog, Inc.
+
+package packages_test
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/trustero/jamf-api-client-go/classic/client"
+	jamf "github.com/trustero/jamf-api-client-go/classic/packages"
+)
+
+var PACKAGES_API_BASE_ENDPOINT = "/JSSResource/packages"
+
+func packagesResponseMocks(t *testing.T, payloads *[]string) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.RequestURI {
+		case PACKAGES_API_BASE_ENDPOINT:
+			*payloads = append(*payloads, "LIST")
+			fmt.Fprint(w, `{
+				"packages": [
+					{"id": 1, "name": "Agent-2.4.1.pkg"},
+					{"id": 2, "name": "Firefox-120.0.pkg"}
+				]
+			}`)
+		case fmt.Sprintf("%s/id/1", PACKAGES_API_BASE_ENDPOINT):
+			if r.Method != "GET" {
+				data, err := ioutil.ReadAll(r.Body)
+				assert.Nil(t, err)
+				*payloads = append(*payloads, r.Method+" "+string(data))
+				w.Header().Set("Content-Type", "application/xml")
+				fmt.Fprint(w, `<package><id>1</id></package>`)
+				return
+			}
+			fmt.Fprint(w, `{
+				"package": {
+					"id": 1,
+					"name": "Agent-2.4.1.pkg",
+					"category": "Security",
+					"filename": "Agent-2.4.1.pkg",
+					"info": "Endpoint agent",
+					"notes": "Built by CI",
+					"priority": 5,
+					"reboot_required": true,
+					"fill_user_template": false,
+					"fill_existing_users": false,
+					"allow_uninstalled": false,
+					"os_requirements": "13.x, 14.x",
+					"required_processor": "None",
+					"switch_with_package": "Do Not Install",
+					"reinstall_option": "Do Not Reinstall",
+					"send_notification": false,
+					"hash_type": "SHA_512",
+					"hash_value": "cf83e1357eefb8bdf1542850d66d8007d620e4050b5715dc83f4a921d36ce9ce47d0d13c5d85f2b0ff8318d2877eec2f63b931bd47417a81a538327af927da3e"
+				}
+			}`)
+		case fmt.Sprintf("%s/name/Firefox-120.0.pkg", PACKAGES_API_BASE_ENDPOINT):
+			w.Header().Set("Content-Type", "application/xml")
+			fmt.Fprint(w, `<package><id>2</id><name>Firefox-120.0.pkg</name><category>No category assigned</category><filename>Firefox-120.0.pkg</filename><priority>10</priority><fill_user_template>true</fill_user_template><hash_type>MD5</hash_type><hash_value>d41d8cd98f00b204e9800998ecf8427e</hash_value></package>`)
+		case fmt.Sprintf("%s/id/-1", PACKAGES_API_BASE_ENDPOINT):
+			data, err := ioutil.ReadAll(r.Body)
+			assert.Nil(t, err)
+			*payloads = append(*payloads, r.Method+" "+string(data))
+			w.Header().Set("Content-Type", "application/xml")
+			fmt.Fprint(w, `<package><id>3</id></package>`)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+}
+
+func TestQueryPackages(t *testing.T) {
+	testServer := packagesResponseMocks(t, &[]string{})
+	defer testServer.Close()
+	j, err := jamf.NewService(testServer.URL, "fake-username", "mock-password-cool", nil)
+	assert.Nil(t, err)
+
+	packages, _, err := j.Packages()
+	assert.Nil(t, err)
+	assert.Equal(t, []jamf.BasicPackageInfo{{ID: 1, Name: "Agent-2.4.1.pkg"}, {ID: 2, Name: "Firefox-120.0.pkg"}}, packages)
+
+	all, err := j.Iterate().All(context.Background())
+	assert.Nil(t, err)
+	assert.Equal(t, packages, all)
+
+	pkg, _, err := j.PackageDetails(1)
+	assert.Nil(t, err)
+	assert.Equal(t, "Security", pkg.Category)
+	assert.Equal(t, 5, pkg.Priority)
+	assert.True(t, *pkg.RebootRequired)
+	assert.Equal(t, "13.x, 14.x", pkg.OSRequirements)
+	assert.Equal(t, jamf.SHA512, pkg.HashType)
+	assert.Nil(t, jamf.ValidatePackage(pkg))
+
+	pkg, _, err = j.PackageDetails("Firefox-120.0.pkg")
+	assert.Nil(t, err)
+	assert.Equal(t, jamf.NoCategory, pkg.Category)
+	assert.True(t, *pkg.FillUserTemplate)
+	assert.Nil(t, pkg.SendNotification)
+	assert.Equal(t, jamf.MD5, pkg.HashType)
+
+	_, _, err = j.PackageDetails(404)
+	assert.NotNil(t, err)
+}
+
+func TestEditPackages(t *testing.T) {
+	payloads := []string{}
+	testServer := packagesResponseMocks(t, &payloads)
+	defer testServer.Close()
+	j, err := jamf.NewService(testServer.URL, "fake-username", "mock-password-cool", nil)
+	assert.Nil(t, err)
+
+	created, _, err := j.CreatePackage(&jamf.Package{
+		Name:           "Zoom-5.17.pkg",
+		Category:       "Communication",
+		Filename:       "Zoom-5.17.pkg",
+		Priority:       10,
+		RebootRequired: client.Bool(true),
+		HashType:       jamf.MD5,
+		HashValue:      "d41d8cd98f00b204e9800998ecf8427e",
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, 3, created.ID)
+
+	_, _, err = j.UpdatePackage(1, &jamf.Package{Name: "Agent-2.4.1.pkg", Filename: "Agent-2.4.1.pkg", Notes: "Signed"})
+	assert.Nil(t, err)
+	_, _, err = j.DeletePackage(1)
+	assert.Nil(t, err)
+
+	// validation runs before anything is sent
+	testCases := map[string]*jamf.Package{
+		"package name is required":                                            {Filename: "a.pkg"},
+		"package Agent requires a filename":                                   {Name: "Agent"},
+		"30 is not a valid priority must be between 1 and 20":                 {Name: "Agent", Filename: "a.pkg", Priority: 30},
+		"SHA_256 is not a valid hash type must be of type [ MD5, SHA_512 ]":   {Name: "Agent", Filename: "a.pkg", HashType: "SHA_256", HashValue: "00"},
+		"xyz is not a valid MD5 hash value must be 32 hexadecimal characters": {Name: "Agent", Filename: "a.pkg", HashType: jamf.MD5, HashValue: "xyz"},
+	}
+	for expected, pkg := range testCases {
+		_, _, err = j.UpdatePackage(1, pkg)
+		assert.NotNil(t, err)
+		assert.Contains(t, err.Error(), expected)
+	}
+
+	assert.Equal(t, []string{
+		"POST <package><name>Zoom-5.17.pkg</name><category>Communication</category><filename>Zoom-5.17.pkg</filename><priority>10</priority>" +
+			"<reboot_required>true</reboot_required><hash_type>MD5</hash_type><hash_value>d41d8cd98f00b204e9800998ecf8427e</hash_value></package>",
+		"PUT <package><name>Agent-2.4.1.pkg</name><filename>Agent-2.4.1.pkg</filename><notes>Signed</notes></package>",
+		"DELETE ",
+	}, payloads)
+}
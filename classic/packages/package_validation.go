@@ -0,0 +1,51 @@
+// Unless explicitly stated otherwise all files in this repository are licensed under the Apache-2.0
+// This product includes software developed at Datadog (https://www.datadoghq.com/). Copyright 2020 Datadog, Inc.
+
+package packages
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+var hexPattern = regexp.MustCompile(`^[0-9a-fA-F]+$`)
+
+// ValidatePackage orchestrates package validation
+func ValidatePackage(p *Package) error {
+	if strings.TrimSpace(p.Name) == "" {
+		return fmt.Errorf("package name is required")
+	}
+
+	if strings.TrimSpace(p.Filename) == "" {
+		return fmt.Errorf("package %s requires a filename", p.Name)
+	}
+
+	// Jamf defaults the priority to 10 when it is not set
+	if p.Priority != 0 && (p.Priority < 1 || p.Priority > 20) {
+		return fmt.Errorf("%d is not a valid priority must be between 1 and 20", p.Priority)
+	}
+
+	if p.HashType == "" && p.HashValue == "" {
+		return nil
+	}
+	return p.HashType.Validate(p.HashValue)
+}
+
+// Validate will validate that a hash type is supported by Jamf and that the hash value matches it
+func (h HashType) Validate(value string) error {
+	var length int
+	switch h {
+	case MD5:
+		length = 32
+	case SHA512:
+		length = 128
+	default:
+		return fmt.Errorf("%s is not a valid hash type must be of type [ %s, %s ]", h, MD5, SHA512)
+	}
+
+	if len(value) != length || !hexPattern.MatchString(value) {
+		return fmt.Errorf("%s is not a valid %s hash value must be %d hexadecimal characters", value, h, length)
+	}
+	return nil
+}
@@ -9,6 +9,7 @@ import (
 	"encoding/xml"
 	"fmt"
 	"github.com/trustero/jamf-api-client-go/classic/client"
+	"github.com/trustero/jamf-api-client-go/pager"
 	"net/http"
 
 	"github.com/pkg/errors"
@@ -16,7 +17,16 @@ import (
 
 // Policies returns a list of policies available in the jamf client
 func (j *Service) Policies() ([]BasicPolicyInformation, error) {
-	req, err := http.NewRequestWithContext(context.Background(), "GET", j.client.Endpoint, nil)
+	return j.policies(context.Background())
+}
+
+// IteratePolicies returns an iterator over the policies available in the jamf client
+func (j *Service) IteratePolicies() *pager.Pager[BasicPolicyInformation] {
+	return pager.Single(j.policies)
+}
+
+func (j *Service) policies(ctx context.Context) ([]BasicPolicyInformation, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", j.client.Endpoint, nil)
 	if err != nil {
 		return nil, errors.Wrap(err, "error building Jamf policies query request")
 	}
@@ -27,19 +37,24 @@ func (j *Service) Policies() ([]BasicPolicyInformation, error) {
 	return res.List, nil
 }
 
-//// PolicyDetails returns the details for a specific policy given its Id or Name
-//func (j *Service) PolicyDetails(identifier interface{}) (*Policy, error) {
-//	req, err := http.NewRequestWithContext(context.Background(), "GET", j.client.Endpoint, nil)
-//	if err != nil {
-//		return nil, errors.Wrapf(err, "error building JAMF query request for policy: %v", identifier)
-//	}
-//
-//	res := Policy{}
-//	if err := client.MakeAPIrequest(j.client, req, &res); err != nil {
-//		return nil, errors.Wrapf(err, "unable to query policy with Id: %d from %s", identifier, j.client.Endpoint)
-//	}
-//	return &res, nil
-//}
+// PolicyDetails returns the details for a specific policy given its Id or Name
+func (j *Service) PolicyDetails(identifier interface{}) (*Policy, error) {
+	ep, err := j.client.IdentifierEndpoint(identifier)
+	if err != nil {
+		return nil, errors.Wrapf(err, "error building JAMF query request endpoint for policy: %v", identifier)
+	}
+
+	req, err := http.NewRequestWithContext(context.Background(), "GET", ep, nil)
+	if err != nil {
+		return nil, errors.Wrapf(err, "error building JAMF query request for policy: %v", identifier)
+	}
+
+	res := Policy{}
+	if _, err := client.MakeAPIrequest(j.client, req, &res); err != nil {
+		return nil, errors.Wrapf(err, "unable to query policy with identifier: %v from %s", identifier, ep)
+	}
+	return &res, nil
+}
 
 // UpdatePolicy will update a policy in Jamf by either Id or Name
 //func (j *Service) UpdatePolicy(identifier interface{}, policy *PolicyContents) (*PolicyContents, error) {
@@ -77,8 +92,13 @@ func (j *Service) Policies() ([]BasicPolicyInformation, error) {
 //	return &res, nil
 //}
 
-// CreatePolicy will create a policy in Jamf
+// CreatePolicy will create a policy in Jamf. The packages of the policy are resolved first and the
+// policy is not created when one of them does not exist, see ResolvePackages
 func (j *Service) CreatePolicy(content *PolicyContents) (*PolicyContents, error) {
+	return j.createPolicy(content, newReferenceResolver(j.client))
+}
+
+func (j *Service) createPolicy(content *PolicyContents, resolver *referenceResolver) (*PolicyContents, error) {
 	// -1 denotes the next available Id
 	ep := j.client.IdEndpoint(-1)
 
@@ -96,6 +116,14 @@ func (j *Service) CreatePolicy(content *PolicyContents) (*PolicyContents, error)
 		}
 	}
 
+	if err := ValidatePolicy(content); err != nil {
+		return nil, errors.Wrapf(err, "policy validation failed: %v", content.General.Name)
+	}
+
+	if _, err := resolver.checkPackages(content); err != nil {
+		return nil, errors.Wrapf(err, "unable to create policy: %v", content.General.Name)
+	}
+
 	bodyContent, err := xml.Marshal(content)
 	if err != nil {
 		return nil, errors.Wrapf(err, "error building JAMF creation payload for policy: %v", content.General.Name)
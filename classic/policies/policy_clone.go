@@ -0,0 +1,411 @@
+// Unless explicitly stated otherwise all files in this repository are licensed under the Apache-2.0
+// This product includes software developed at Datadog (https://www.datadoghq.com/). Copyright 2020 Datadog, Inc.
+
+package policies
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/pkg/errors"
+	"github.com/trustero/jamf-api-client-go/classic/client"
+	"github.com/trustero/jamf-api-client-go/classic/computers"
+)
+
+// Reference kinds that are remapped by name when a policy is cloned to another Jamf instance
+const (
+	ReferenceScript        = "scripts"
+	ReferencePackage       = "packages"
+	ReferenceCategory      = "categories"
+	ReferenceComputerGroup = "computergroups"
+	ReferenceSite          = "sites"
+	ReferenceComputer      = "computers"
+	ReferenceBuilding      = "buildings"
+	ReferenceDepartment    = "departments"
+	ReferenceDockItem      = "dockitems"
+)
+
+// CloneOptions holds the settings applied to a policy when it is cloned
+type CloneOptions struct {
+	// Name overrides the policy name on the target instance
+	Name string
+	// Site moves the policy to the named site on the target instance
+	Site string
+	// DryRun resolves all references without creating the policy
+	DryRun bool
+}
+
+// UnresolvedReference describes an object referenced by a policy that does not exist on the target instance
+type UnresolvedReference struct {
+	Kind  string
+	Name  string
+	Field string
+}
+
+func (r *UnresolvedReference) String() string {
+	return fmt.Sprintf("%s: %s %q not found", r.Field, r.Kind, r.Name)
+}
+
+// PolicyClone holds the outcome of cloning a policy to another Jamf instance
+type PolicyClone struct {
+	Template   *PolicyContents
+	Created    *PolicyContents
+	Unresolved []*UnresolvedReference
+}
+
+// ExportPolicy returns the contents of a policy given its Id or Name with all
+// server assigned Ids removed so it can be used as a template on any Jamf instance
+func (j *Service) ExportPolicy(identifier interface{}) (*PolicyContents, error) {
+	policy, err := j.PolicyDetails(identifier)
+	if err != nil {
+		return nil, errors.Wrapf(err, "unable to export policy: %v", identifier)
+	}
+
+	if policy.Content == nil || policy.Content.General == nil {
+		return nil, fmt.Errorf("unable to export policy: %v no policy contents returned", identifier)
+	}
+
+	StripPolicyIds(policy.Content)
+	return policy.Content, nil
+}
+
+// ClonePolicy exports a policy given its Id or Name and creates it on the target instance.
+// Scripts, packages, categories, sites, dock items and the computers, computer groups, buildings
+// and departments in scope are remapped by name on the target.
+// When any reference can not be resolved the policy is not created and every unresolved
+// reference is returned in the PolicyClone alongside the error
+func (j *Service) ClonePolicy(identifier interface{}, target *Service, opts *CloneOptions) (*PolicyClone, error) {
+	template, err := j.ExportPolicy(identifier)
+	if err != nil {
+		return nil, err
+	}
+	return target.CreatePolicyFromTemplate(template, opts)
+}
+
+// CreatePolicyFromTemplate resolves all named references of an exported policy against
+// this Jamf instance and creates the policy. The template is left unchanged, the resolved
+// copy is returned as the Template of the PolicyClone
+func (j *Service) CreatePolicyFromTemplate(template *PolicyContents, opts *CloneOptions) (*PolicyClone, error) {
+	if template == nil || template.General == nil {
+		return nil, fmt.Errorf("policy template must include general policy information")
+	}
+
+	template, err := copyPolicy(template)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to copy policy template")
+	}
+
+	if opts == nil {
+		opts = &CloneOptions{}
+	}
+
+	if opts.Name != "" {
+		template.General.Name = opts.Name
+	}
+
+	if opts.Site != "" {
+		template.General.Site = &PolicySite{Name: opts.Site}
+	}
+
+	clone := &PolicyClone{Template: template}
+	resolver := newReferenceResolver(j.client)
+	unresolved, err := resolver.resolvePolicy(template)
+	if err != nil {
+		return clone, errors.Wrapf(err, "unable to resolve references for policy: %v", template.General.Name)
+	}
+
+	clone.Unresolved = unresolved
+	if len(unresolved) > 0 {
+		return clone, fmt.Errorf("unable to clone policy %v %d reference(s) could not be resolved: %s", template.General.Name, len(unresolved), joinReferences(unresolved))
+	}
+
+	if opts.DryRun {
+		return clone, nil
+	}
+
+	created, err := j.createPolicy(template, resolver)
+	if err != nil {
+		return clone, err
+	}
+	clone.Created = created
+	return clone, nil
+}
+
+// copyPolicy returns a deep copy of the policy contents
+func copyPolicy(p *PolicyContents) (*PolicyContents, error) {
+	data, err := json.Marshal(p)
+	if err != nil {
+		return nil, err
+	}
+	c := &PolicyContents{ScriptCount: p.ScriptCount}
+	if err := json.Unmarshal(data, c); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// StripPolicyIds removes every server assigned Id which CreatePolicyFromTemplate resolves by
+// name from the policy contents leaving only named references behind. Self service icons can
+// not be looked up by name so their Id is kept
+func StripPolicyIds(p *PolicyContents) {
+	if p.General != nil {
+		p.General.ID = 0
+		if p.General.Category != nil {
+			p.General.Category.ID = 0
+		}
+		if p.General.Site != nil {
+			p.General.Site.ID = 0
+		}
+	}
+
+	for _, s := range p.Scripts {
+		if s != nil {
+			s.ID = 0
+		}
+	}
+
+	if p.PackageConfiguration != nil {
+		for _, pkg := range p.PackageConfiguration.List {
+			if pkg != nil {
+				pkg.ID = 0
+			}
+		}
+	}
+
+	for _, d := range p.DockItems {
+		if d.Details != nil {
+			d.Details.ID = 0
+		}
+	}
+
+	if p.SelfServices != nil {
+		for _, c := range p.SelfServices.Categories {
+			c.Category.ID = 0
+		}
+	}
+
+	if p.Scope != nil {
+		for _, c := range p.Scope.Computers {
+			c.Id = 0
+		}
+		for _, g := range p.Scope.ComputerGroups {
+			g.ID = 0
+		}
+		for _, b := range p.Scope.Buildings {
+			b.ID = 0
+		}
+		for _, d := range p.Scope.Departments {
+			d.ID = 0
+		}
+		if p.Scope.Exclusions != nil {
+			for _, c := range p.Scope.Exclusions.Computers {
+				c.Id = 0
+			}
+			for _, g := range p.Scope.Exclusions.ComputerGroups {
+				g.ID = 0
+			}
+			for _, b := range p.Scope.Exclusions.Buildings {
+				b.ID = 0
+			}
+			for _, d := range p.Scope.Exclusions.Departments {
+				d.ID = 0
+			}
+		}
+	}
+}
+
+type namedReference struct {
+	ID   int    `json:"id" xml:"id"`
+	Name string `json:"name" xml:"name"`
+}
+
+// referenceLists holds the lists returned by Jamf for every reference kind, only the
+// list of the kind queried is filled in
+type referenceLists struct {
+	Scripts        []namedReference `json:"scripts" xml:"script"`
+	Packages       []namedReference `json:"packages" xml:"package"`
+	Categories     []namedReference `json:"categories" xml:"category"`
+	ComputerGroups []namedReference `json:"computer_groups" xml:"computer_group"`
+	Sites          []namedReference `json:"sites" xml:"site"`
+	Computers      []namedReference `json:"computers" xml:"computer"`
+	Buildings      []namedReference `json:"buildings" xml:"building"`
+	Departments    []namedReference `json:"departments" xml:"department"`
+	DockItems      []namedReference `json:"dock_items" xml:"dock_item"`
+}
+
+func (l *referenceLists) of(kind string) []namedReference {
+	switch kind {
+	case ReferenceScript:
+		return l.Scripts
+	case ReferencePackage:
+		return l.Packages
+	case ReferenceCategory:
+		return l.Categories
+	case ReferenceComputerGroup:
+		return l.ComputerGroups
+	case ReferenceSite:
+		return l.Sites
+	case ReferenceComputer:
+		return l.Computers
+	case ReferenceBuilding:
+		return l.Buildings
+	case ReferenceDepartment:
+		return l.Departments
+	case ReferenceDockItem:
+		return l.DockItems
+	default:
+		return nil
+	}
+}
+
+// referenceResolver looks up named objects on a Jamf instance caching each list it fetches
+type referenceResolver struct {
+	client     *client.Client
+	cache      map[string]map[string]int
+	unresolved []*UnresolvedReference
+}
+
+func newReferenceResolver(c *client.Client) *referenceResolver {
+	return &referenceResolver{
+		client: c,
+		cache:  map[string]map[string]int{},
+	}
+}
+
+func (r *referenceResolver) list(kind string) (map[string]int, error) {
+	if ids, ok := r.cache[kind]; ok {
+		return ids, nil
+	}
+
+	c, err := client.NewDomainClient(r.client.Domain, kind, r.client.Username, r.client.Password, r.client.Api)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(context.Background(), "GET", c.Endpoint, nil)
+	if err != nil {
+		return nil, errors.Wrapf(err, "error building JAMF %s query request", kind)
+	}
+
+	res := referenceLists{}
+	if _, err := client.MakeAPIrequest(c, req, &res); err != nil {
+		return nil, errors.Wrapf(err, "unable to query %s from %s", kind, c.Endpoint)
+	}
+
+	ids := map[string]int{}
+	for _, ref := range res.of(kind) {
+		ids[ref.Name] = ref.ID
+	}
+	r.cache[kind] = ids
+	return ids, nil
+}
+
+// resolve returns the Id of the named object on the target or records it as unresolved
+func (r *referenceResolver) resolve(kind string, name string, field string) (int, error) {
+	ids, err := r.list(kind)
+	if err != nil {
+		return 0, err
+	}
+
+	id, ok := ids[name]
+	if !ok {
+		r.unresolved = append(r.unresolved, &UnresolvedReference{Kind: kind, Name: name, Field: field})
+		return 0, nil
+	}
+	return id, nil
+}
+
+// resolveNamed sets the Id of a named reference, references without a name are left as they are
+func (r *referenceResolver) resolveNamed(kind string, field string, id *int, name string) (err error) {
+	if name == "" {
+		return nil
+	}
+	*id, err = r.resolve(kind, name, field)
+	return err
+}
+
+func (r *referenceResolver) resolvePolicy(p *PolicyContents) ([]*UnresolvedReference, error) {
+	var err error
+	if c := p.General.Category; c != nil && c.Name != "" && c.Name != "No category assigned" {
+		if c.ID, err = r.resolve(ReferenceCategory, c.Name, "general.category"); err != nil {
+			return nil, err
+		}
+	}
+
+	if s := p.General.Site; s != nil && s.Name != "" && s.Name != "None" {
+		if s.ID, err = r.resolve(ReferenceSite, s.Name, "general.site"); err != nil {
+			return nil, err
+		}
+	}
+
+	for i, s := range p.Scripts {
+		if s == nil {
+			continue
+		}
+		if s.ID, err = r.resolve(ReferenceScript, s.Name, fmt.Sprintf("scripts[%d]", i)); err != nil {
+			return nil, err
+		}
+	}
+
+	packages, err := r.resolvePackages(p)
+	if err != nil {
+		return nil, err
+	}
+	r.unresolved = append(r.unresolved, packages...)
+
+	for i, d := range p.DockItems {
+		if d.Details != nil {
+			if err := r.resolveNamed(ReferenceDockItem, fmt.Sprintf("dock_items[%d]", i), &d.Details.ID, d.Details.Name); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	if p.SelfServices != nil {
+		for i, c := range p.SelfServices.Categories {
+			if err := r.resolveNamed(ReferenceCategory, fmt.Sprintf("self_service.self_service_categories[%d]", i), &c.Category.ID, c.Category.Name); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	if p.Scope != nil {
+		if err := r.resolveScope("scope", p.Scope.Computers, p.Scope.ComputerGroups, p.Scope.Buildings, p.Scope.Departments); err != nil {
+			return nil, err
+		}
+		if e := p.Scope.Exclusions; e != nil {
+			if err := r.resolveScope("scope.exclusions", e.Computers, e.ComputerGroups, e.Buildings, e.Departments); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return r.unresolved, nil
+}
+
+// resolveScope resolves the computers, computer groups, buildings and departments of a scope or its exclusions
+func (r *referenceResolver) resolveScope(field string, computerList []*computers.BasicComputerInfo, groups []*computers.ComputerGroup, buildings []*Building, departments []*Department) error {
+	for i, g := range groups {
+		if err := r.resolveNamed(ReferenceComputerGroup, fmt.Sprintf("%s.computer_groups[%d]", field, i), &g.ID, g.Name); err != nil {
+			return err
+		}
+	}
+	for i, c := range computerList {
+		if err := r.resolveNamed(ReferenceComputer, fmt.Sprintf("%s.computers[%d]", field, i), &c.Id, c.Name); err != nil {
+			return err
+		}
+	}
+	for i, b := range buildings {
+		if err := r.resolveNamed(ReferenceBuilding, fmt.Sprintf("%s.buildings[%d]", field, i), &b.ID, b.Name); err != nil {
+			return err
+		}
+	}
+	for i, d := range departments {
+		if err := r.resolveNamed(ReferenceDepartment, fmt.Sprintf("%s.departments[%d]", field, i), &d.ID, d.Name); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,197 @@
+// Unless explicitly stated otherwise all files in this repository are licensed under the Apache-2.0
+// This product includes software developed at Datadog (https://www.datadoghq.com/). Copyright 2020 Datadog, Inc.
+
+package policies_test
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/trustero/jamf-api-client-go/classic/computers"
+	jamf "github.com/trustero/jamf-api-client-go/classic/policies"
+)
+
+func sourcePolicyMocks(t *testing.T) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.RequestURI {
+		case fmt.Sprintf("%s/id/72", POLICIES_API_BASE_ENDPOINT):
+			mockPolicy := &jamf.Policy{
+				Content: &jamf.PolicyContents{
+					General: &jamf.PolicyGeneral{
+						ID:        72,
+						Name:      "Test Policy",
+						Frequency: "Once per computer",
+						Category:  &jamf.PolicyCategory{ID: 4, Name: "Security"},
+						Site:      &jamf.PolicySite{ID: -1, Name: "None"},
+					},
+					Scope: &jamf.Scope{
+						ComputerGroups: []*computers.ComputerGroup{{ID: 12, Name: "Staging Macs"}},
+						Computers:      []*computers.BasicComputerInfo{{GeneralInformation: computers.GeneralInformation{Id: 31, Name: "STAGING-MBP"}}},
+						Buildings:      []*jamf.Building{{ID: 6, Name: "HQ"}},
+						Exclusions: &jamf.Exclusions{
+							Departments: []*jamf.Department{{ID: 7, Name: "Finance"}},
+						},
+					},
+					DockItems: []*jamf.DockItem{{Details: &jamf.DockItemDetails{ID: 14, Name: "Agent", Action: "Add To End"}}},
+					SelfServices: &jamf.SelfService{
+						Icon:       &jamf.SelfServiceIcon{ID: 88, Filename: "agent.png"},
+						Categories: []*jamf.SelfServiceCategory{{}},
+					},
+					PackageConfiguration: &jamf.Packages{
+						List: []*jamf.Package{{ID: 8, Name: "agent.pkg", Action: "Install"}},
+					},
+					Scripts: []*jamf.PolicyScriptAssignment{
+						{ID: 3, Name: "Configure Agent", Priority: "After"},
+						{ID: 5, Name: "Staging Only Script", Priority: "Before"},
+					},
+				},
+			}
+			mockPolicy.Content.SelfServices.Categories[0].Category.ID = 4
+			mockPolicy.Content.SelfServices.Categories[0].Category.Name = "Security"
+			data, err := json.Marshal(mockPolicy)
+			assert.Nil(t, err)
+			fmt.Fprint(w, string(data))
+		default:
+			http.Error(w, fmt.Sprintf("bad Jamf API %s call to %s", r.Method, r.URL), http.StatusInternalServerError)
+		}
+	}))
+}
+
+func targetPolicyMocks(t *testing.T) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.RequestURI {
+		case "/JSSResource/scripts":
+			fmt.Fprint(w, `{"scripts": [{"id": 301, "name": "Configure Agent"}]}`)
+		case "/JSSResource/packages":
+			fmt.Fprint(w, `{"packages": [{"id": 208, "name": "agent.pkg"}]}`)
+		case "/JSSResource/categories":
+			fmt.Fprint(w, `{"categories": [{"id": 9, "name": "Security"}]}`)
+		case "/JSSResource/computergroups":
+			fmt.Fprint(w, `{"computer_groups": [{"id": 44, "name": "Staging Macs"}]}`)
+		case "/JSSResource/sites":
+			fmt.Fprint(w, `{"sites": [{"id": 2, "name": "Production"}]}`)
+		case "/JSSResource/computers":
+			fmt.Fprint(w, `{"computers": [{"id": 131, "name": "STAGING-MBP"}]}`)
+		case "/JSSResource/buildings":
+			w.Header().Add("Content-Type", "application/xml")
+			fmt.Fprint(w, `<buildings><size>1</size><building><id>106</id><name>HQ</name></building></buildings>`)
+		case "/JSSResource/departments":
+			fmt.Fprint(w, `{"departments": [{"id": 107, "name": "Finance"}]}`)
+		case "/JSSResource/dockitems":
+			fmt.Fprint(w, `{"dock_items": [{"id": 114, "name": "Agent"}]}`)
+		case fmt.Sprintf("%s/id/-1", POLICIES_API_BASE_ENDPOINT):
+			w.Header().Add("Content-Type", "application/xml")
+			data, err := ioutil.ReadAll(r.Body)
+			assert.Nil(t, err)
+			policyContents := &jamf.PolicyContents{}
+			assert.Nil(t, xml.Unmarshal(data, policyContents))
+			policyData, err := xml.Marshal(policyContents)
+			assert.Nil(t, err)
+			fmt.Fprint(w, string(policyData))
+		default:
+			http.Error(w, fmt.Sprintf("bad Jamf API %s call to %s", r.Method, r.URL), http.StatusInternalServerError)
+		}
+	}))
+}
+
+func TestExportPolicyStripsIds(t *testing.T) {
+	sourceServer := sourcePolicyMocks(t)
+	defer sourceServer.Close()
+	source, err := jamf.NewService(sourceServer.URL, "fake-username", "mock-password-cool", nil)
+	assert.Nil(t, err)
+
+	template, err := source.ExportPolicy(72)
+	assert.Nil(t, err)
+	assert.Equal(t, 0, template.General.ID)
+	assert.Equal(t, 0, template.General.Category.ID)
+	assert.Equal(t, "Security", template.General.Category.Name)
+	assert.Equal(t, 0, template.Scope.ComputerGroups[0].ID)
+	assert.Equal(t, 0, template.PackageConfiguration.List[0].ID)
+	assert.Equal(t, 0, template.Scripts[0].ID)
+	assert.Equal(t, 0, template.Scope.Computers[0].Id)
+	assert.Equal(t, 0, template.Scope.Buildings[0].ID)
+	assert.Equal(t, 0, template.Scope.Exclusions.Departments[0].ID)
+	assert.Equal(t, 0, template.DockItems[0].Details.ID)
+	assert.Equal(t, 0, template.SelfServices.Categories[0].Category.ID)
+	// icons can not be resolved by name so they are left as they are
+	assert.Equal(t, 88, template.SelfServices.Icon.ID)
+}
+
+func TestStripPolicyIdsSkipsNilEntries(t *testing.T) {
+	policy := &jamf.PolicyContents{
+		Scripts:              []*jamf.PolicyScriptAssignment{nil, {ID: 4, Name: "Cleanup"}},
+		PackageConfiguration: &jamf.Packages{List: []*jamf.Package{nil, {ID: 2}}},
+	}
+	jamf.StripPolicyIds(policy)
+	assert.Equal(t, 0, policy.Scripts[1].ID)
+	assert.Equal(t, 0, policy.PackageConfiguration.List[1].ID)
+}
+
+func TestClonePolicyReportsUnresolvedReferences(t *testing.T) {
+	sourceServer := sourcePolicyMocks(t)
+	defer sourceServer.Close()
+	targetServer := targetPolicyMocks(t)
+	defer targetServer.Close()
+
+	source, err := jamf.NewService(sourceServer.URL, "fake-username", "mock-password-cool", nil)
+	assert.Nil(t, err)
+	target, err := jamf.NewService(targetServer.URL, "fake-username", "mock-password-cool", nil)
+	assert.Nil(t, err)
+
+	clone, err := source.ClonePolicy(72, target, &jamf.CloneOptions{Site: "Staging"})
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "2 reference(s) could not be resolved")
+	assert.Nil(t, clone.Created)
+	assert.Len(t, clone.Unresolved, 2)
+	assert.Equal(t, "general.site", clone.Unresolved[0].Field)
+	assert.Equal(t, jamf.ReferenceSite, clone.Unresolved[0].Kind)
+	assert.Equal(t, "scripts[1]", clone.Unresolved[1].Field)
+	assert.Equal(t, "Staging Only Script", clone.Unresolved[1].Name)
+}
+
+func TestClonePolicy(t *testing.T) {
+	sourceServer := sourcePolicyMocks(t)
+	defer sourceServer.Close()
+	targetServer := targetPolicyMocks(t)
+	defer targetServer.Close()
+
+	source, err := jamf.NewService(sourceServer.URL, "fake-username", "mock-password-cool", nil)
+	assert.Nil(t, err)
+	target, err := jamf.NewService(targetServer.URL, "fake-username", "mock-password-cool", nil)
+	assert.Nil(t, err)
+
+	template, err := source.ExportPolicy(72)
+	assert.Nil(t, err)
+	template.Scripts = template.Scripts[:1]
+
+	clone, err := target.CreatePolicyFromTemplate(template, &jamf.CloneOptions{Name: "Test Policy (Production)", Site: "Production"})
+	assert.Nil(t, err)
+	assert.Empty(t, clone.Unresolved)
+	assert.NotNil(t, clone.Created)
+	assert.Equal(t, "Test Policy (Production)", clone.Created.General.Name)
+	assert.Equal(t, 9, clone.Created.General.Category.ID)
+	assert.Equal(t, 2, clone.Created.General.Site.ID)
+	assert.Equal(t, 44, clone.Created.Scope.ComputerGroups[0].ID)
+	assert.Equal(t, 208, clone.Created.PackageConfiguration.List[0].ID)
+	assert.Equal(t, 301, clone.Created.Scripts[0].ID)
+	assert.Equal(t, 131, clone.Created.Scope.Computers[0].Id)
+
+	// every kind of reference which was stripped is resolved on the copy
+	assert.Equal(t, 131, clone.Template.Scope.Computers[0].Id)
+	assert.Equal(t, 106, clone.Template.Scope.Buildings[0].ID)
+	assert.Equal(t, 107, clone.Template.Scope.Exclusions.Departments[0].ID)
+	assert.Equal(t, 114, clone.Template.DockItems[0].Details.ID)
+	assert.Equal(t, 9, clone.Template.SelfServices.Categories[0].Category.ID)
+
+	// the template passed in is not changed
+	assert.Equal(t, "Test Policy", template.General.Name)
+	assert.Equal(t, "None", template.General.Site.Name)
+	assert.Equal(t, 0, template.Scripts[0].ID)
+	assert.Equal(t, 0, template.Scope.Computers[0].Id)
+}
@@ -0,0 +1,90 @@
+// Unless explicitly stated otherwise all files in this repository are licensed under the Apache-2.0
+// This product includes software developed at Datadog (https://www.datadoghq.com/). Copyright 2020 Datadog, Inc.
+
+package policies
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// ResolvePackages makes sure every package in the package configuration of a policy exists in Jamf.
+// Packages referenced by Id get their name filled in and packages referenced by name get their Id,
+// a package referenced by both must match on both. Every package which can not be found is returned
+// alongside the error. CreatePolicy runs it before a policy is created
+func (j *Service) ResolvePackages(p *PolicyContents) ([]*UnresolvedReference, error) {
+	return newReferenceResolver(j.client).checkPackages(p)
+}
+
+// checkPackages resolves the packages of a policy returning an error when any is missing
+func (r *referenceResolver) checkPackages(p *PolicyContents) ([]*UnresolvedReference, error) {
+	unresolved, err := r.resolvePackages(p)
+	if err != nil {
+		return nil, err
+	}
+	if len(unresolved) > 0 {
+		return unresolved, fmt.Errorf("%d package reference(s) could not be resolved: %s", len(unresolved), joinReferences(unresolved))
+	}
+	return nil, nil
+}
+
+// resolvePackages resolves the packages of a policy and returns the packages found missing. An
+// error is only returned when the packages can not be checked or a package is invalid
+func (r *referenceResolver) resolvePackages(p *PolicyContents) ([]*UnresolvedReference, error) {
+	if p == nil || p.PackageConfiguration == nil || len(p.PackageConfiguration.List) == 0 {
+		return nil, nil
+	}
+
+	ids, err := r.list(ReferencePackage)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to resolve the packages of the policy")
+	}
+
+	var unresolved []*UnresolvedReference
+	for i, pkg := range p.PackageConfiguration.List {
+		field := fmt.Sprintf("package_configuration.packages[%d]", i)
+		switch {
+		case pkg == nil || (pkg.ID == 0 && pkg.Name == ""):
+			return nil, fmt.Errorf("%s requires a package id or name", field)
+		case pkg.ID != 0:
+			name, ok := nameOf(ids, pkg.ID)
+			if !ok {
+				unresolved = append(unresolved, &UnresolvedReference{Kind: ReferencePackage, Name: fmt.Sprintf("id %d", pkg.ID), Field: field})
+				continue
+			}
+			if pkg.Name != "" && pkg.Name != name {
+				return nil, fmt.Errorf("%s: package id %d is named %q not %q", field, pkg.ID, name, pkg.Name)
+			}
+			pkg.Name = name
+		default:
+			id, ok := ids[pkg.Name]
+			if !ok {
+				unresolved = append(unresolved, &UnresolvedReference{Kind: ReferencePackage, Name: pkg.Name, Field: field})
+				continue
+			}
+			pkg.ID = id
+		}
+	}
+
+	return unresolved, nil
+}
+
+// nameOf returns the name of the object with the given Id in a list of Ids keyed by name
+func nameOf(ids map[string]int, id int) (string, bool) {
+	for name, i := range ids {
+		if i == id {
+			return name, true
+		}
+	}
+	return "", false
+}
+
+func joinReferences(refs []*UnresolvedReference) string {
+	names := make([]string, 0, len(refs))
+	for _, ref := range refs {
+		names = append(names, ref.String())
+	}
+	return strings.Join(names, "; ")
+}
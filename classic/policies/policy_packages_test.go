@@ -0,0 +1,118 @@
+// Unless explicitly stated otherwise all files in this repository are licensed under the Apache-2.0
+// This product includes software developed at Datadog (https://www.datadoghq.com/). Copyright 2020 Datadog, Inc.
+
+package policies_test
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	jamf "github.com/trustero/jamf-api-client-go/classic/policies"
+)
+
+// policyPackagesResponseMocks records the package list queries and the policies created
+func policyPackagesResponseMocks(t *testing.T, requests *[]string) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.RequestURI {
+		case "/JSSResource/packages":
+			*requests = append(*requests, "LIST")
+			fmt.Fprint(w, `{
+				"packages": [
+					{"id": 1, "name": "Agent-2.4.1.pkg"},
+					{"id": 2, "name": "Firefox-120.0.pkg"}
+				]
+			}`)
+		case fmt.Sprintf("%s/id/-1", POLICIES_API_BASE_ENDPOINT):
+			data, err := ioutil.ReadAll(r.Body)
+			assert.Nil(t, err)
+			*requests = append(*requests, "POLICY "+string(data))
+			w.Header().Set("Content-Type", "application/xml")
+			fmt.Fprint(w, `<policy><general><id>12</id></general></policy>`)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+}
+
+func TestResolvePolicyPackages(t *testing.T) {
+	requests := []string{}
+	testServer := policyPackagesResponseMocks(t, &requests)
+	defer testServer.Close()
+	j, err := jamf.NewService(testServer.URL, "fake-username", "mock-password-cool", nil)
+	assert.Nil(t, err)
+
+	policy := &jamf.PolicyContents{
+		General: &jamf.PolicyGeneral{Name: "Install Agent"},
+		PackageConfiguration: &jamf.Packages{List: []*jamf.Package{
+			{Name: "Agent-2.4.1.pkg", Action: "Install"},
+			{ID: 2, Action: "Cache"},
+		}},
+	}
+	unresolved, err := j.ResolvePackages(policy)
+	assert.Nil(t, err)
+	assert.Empty(t, unresolved)
+	assert.Equal(t, 1, policy.PackageConfiguration.List[0].ID)
+	assert.Equal(t, "Firefox-120.0.pkg", policy.PackageConfiguration.List[1].Name)
+
+	missing := &jamf.PolicyContents{
+		General: &jamf.PolicyGeneral{Name: "Install Missing"},
+		PackageConfiguration: &jamf.Packages{List: []*jamf.Package{
+			{Name: "Agent-2.4.1.pkg"},
+			{Name: "Missing.pkg"},
+			{ID: 9},
+		}},
+	}
+	unresolved, err = j.ResolvePackages(missing)
+	assert.NotNil(t, err)
+	assert.Equal(t, `2 package reference(s) could not be resolved: package_configuration.packages[1]: packages "Missing.pkg" not found; package_configuration.packages[2]: packages "id 9" not found`, err.Error())
+	assert.Len(t, unresolved, 2)
+
+	_, err = j.ResolvePackages(&jamf.PolicyContents{PackageConfiguration: &jamf.Packages{List: []*jamf.Package{{ID: 1, Name: "Agent-2.5.0.pkg"}}}})
+	assert.NotNil(t, err)
+	assert.Equal(t, `package_configuration.packages[0]: package id 1 is named "Agent-2.4.1.pkg" not "Agent-2.5.0.pkg"`, err.Error())
+
+	// CreatePolicy resolves the packages and does not create a policy when one is missing
+	requests = requests[:0]
+	created, err := j.CreatePolicy(&jamf.PolicyContents{
+		General:              &jamf.PolicyGeneral{Name: "Install Firefox"},
+		PackageConfiguration: &jamf.Packages{List: []*jamf.Package{{ID: 2, Action: "Install"}}},
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, 12, created.General.ID)
+
+	_, err = j.CreatePolicy(missing)
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "unable to create policy: Install Missing")
+
+	assert.Equal(t, []string{
+		"LIST",
+		"POLICY <policy><general><name>Install Firefox</name></general><package_configuration><packages>" +
+			"<package><id>2</id><name>Firefox-120.0.pkg</name><action>Install</action></package></packages></package_configuration><scripts></scripts></policy>",
+		"LIST",
+	}, requests)
+}
+
+func TestCreatePolicyFromTemplatePackageErrors(t *testing.T) {
+	requests := []string{}
+	testServer := policyPackagesResponseMocks(t, &requests)
+	defer testServer.Close()
+	j, err := jamf.NewService(testServer.URL, "fake-username", "mock-password-cool", nil)
+	assert.Nil(t, err)
+
+	// an invalid package is reported even when another package is missing
+	clone, err := j.CreatePolicyFromTemplate(&jamf.PolicyContents{
+		General: &jamf.PolicyGeneral{Name: "Install Agent"},
+		PackageConfiguration: &jamf.Packages{List: []*jamf.Package{
+			{Name: "Missing.pkg"},
+			{ID: 1, Name: "Agent-2.5.0.pkg"},
+		}},
+	}, nil)
+	assert.NotNil(t, err)
+	assert.Nil(t, clone.Created)
+	assert.Contains(t, err.Error(), `package_configuration.packages[1]: package id 1 is named "Agent-2.4.1.pkg" not "Agent-2.5.0.pkg"`)
+	assert.Equal(t, []string{"LIST"}, requests)
+}
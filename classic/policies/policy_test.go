@@ -4,6 +4,7 @@
 package policies_test
 
 import (
+	"context"
 	"encoding/json"
 	"encoding/xml"
 	"fmt"
@@ -83,6 +84,8 @@ func policiesResponseMocks(t *testing.T) *httptest.Server {
 				}
 				fmt.Fprintf(w, string(policyData))
 			}
+		case "/JSSResource/packages":
+			fmt.Fprint(w, `{"packages": [{"id": 5, "name": "test_macos_installer.pkg"}]}`)
 		default:
 			http.Error(w, fmt.Sprintf("bad Jamf API %s call to %s", r.Method, r.URL), http.StatusInternalServerError)
 			return
@@ -105,27 +108,27 @@ func TestGetAllPolicies(t *testing.T) {
 	assert.Equal(t, "Test Policy", res[2].Name)
 }
 
-//func TestGetSpecificPolicyByID(t *testing.T) {
-//	testServer := policiesResponseMocks(t)
-//	defer testServer.Close()
-//	j, err := jamf.NewService(testServer.URL, "fake-username", "mock-password-cool", nil)
-//	assert.Nil(t, err)
-//	policy, err := j.PolicyDetails(72)
-//	assert.Nil(t, err)
-//	assert.Equal(t, 72, policy.Content.General.Id)
-//	assert.Equal(t, "Test Policy", policy.Content.General.Name)
-//}
+func TestGetSpecificPolicyByID(t *testing.T) {
+	testServer := policiesResponseMocks(t)
+	defer testServer.Close()
+	j, err := jamf.NewService(testServer.URL, "fake-username", "mock-password-cool", nil)
+	assert.Nil(t, err)
+	policy, err := j.PolicyDetails(72)
+	assert.Nil(t, err)
+	assert.Equal(t, 72, policy.Content.General.ID)
+	assert.Equal(t, "Test Policy", policy.Content.General.Name)
+}
 
-//func TestGetSpecificPolicyByName(t *testing.T) {
-//	testServer := policiesResponseMocks(t)
-//	defer testServer.Close()
-//	j, err := jamf.NewService(testServer.URL, "fake-username", "mock-password-cool", nil)
-//	assert.Nil(t, err)
-//	policy, err := j.PolicyDetails("Test Policy")
-//	assert.Nil(t, err)
-//	assert.Equal(t, 72, policy.Content.General.Id)
-//	assert.Equal(t, "Test Policy", policy.Content.General.Name)
-//}
+func TestGetSpecificPolicyByName(t *testing.T) {
+	testServer := policiesResponseMocks(t)
+	defer testServer.Close()
+	j, err := jamf.NewService(testServer.URL, "fake-username", "mock-password-cool", nil)
+	assert.Nil(t, err)
+	policy, err := j.PolicyDetails("Test Policy")
+	assert.Nil(t, err)
+	assert.Equal(t, 72, policy.Content.General.ID)
+	assert.Equal(t, "Test Policy", policy.Content.General.Name)
+}
 
 //func TestUpdatePolicy(t *testing.T) {
 //	testServer := policiesResponseMocks(t)
@@ -220,6 +223,7 @@ func TestCreatePolicy(t *testing.T) {
 	assert.Equal(t, "TEST-BOX", policy.Scope.Computers[0].GeneralInformation.Name)
 	assert.NotNil(t, policy.PackageConfiguration)
 	assert.Equal(t, "test_macos_installer.pkg", policy.PackageConfiguration.List[0].Name)
+	assert.Equal(t, 5, policy.PackageConfiguration.List[0].ID)
 	assert.Equal(t, 1, len(policy.Scripts))
 	assert.Equal(t, "Test Echo", policy.Scripts[0].Name)
 	assert.Equal(t, "Walter", policy.Scripts[0].Parameter4)
@@ -235,3 +239,15 @@ func TestCreatePolicy(t *testing.T) {
 //	assert.Nil(t, err)
 //	assert.Equal(t, 72, removed.Id)
 //}
+
+func TestIteratePolicies(t *testing.T) {
+	testServer := policiesResponseMocks(t)
+	defer testServer.Close()
+	j, err := jamf.NewService(testServer.URL, "fake-username", "mock-password-cool", nil)
+	assert.Nil(t, err)
+
+	all, err := j.IteratePolicies().All(context.Background())
+	assert.Nil(t, err)
+	assert.Len(t, all, 5)
+	assert.Equal(t, "Test Policy", all[2].Name)
+}
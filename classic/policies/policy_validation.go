@@ -0,0 +1,230 @@
+// Unless explicitly stated otherwise all files in this repository are licensed under the Apache-2.0
+// This product includes software developed at Datadog (https://www.datadoghq.com/). Copyright 2020 Datadog, Inc.
+
+package policies
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// policyDateLayout is the layout Jamf uses for the non UTC policy date fields
+const policyDateLayout = "2006-01-02 15:04:05"
+
+// PolicyValidationError describes a single invalid setting found in a policy
+type PolicyValidationError struct {
+	Field   string
+	Message string
+}
+
+func (e *PolicyValidationError) Error() string {
+	return fmt.Sprintf("%s: %s", e.Field, e.Message)
+}
+
+// PolicyValidationErrors holds every problem found while validating a policy
+type PolicyValidationErrors []*PolicyValidationError
+
+func (e PolicyValidationErrors) Error() string {
+	msgs := make([]string, 0, len(e))
+	for _, fe := range e {
+		msgs = append(msgs, fe.Error())
+	}
+	return fmt.Sprintf("policy validation failed with %d error(s): %s", len(e), strings.Join(msgs, "; "))
+}
+
+func (e *PolicyValidationErrors) add(field string, format string, args ...interface{}) {
+	*e = append(*e, &PolicyValidationError{Field: field, Message: fmt.Sprintf(format, args...)})
+}
+
+func (e *PolicyValidationErrors) addErr(field string, err error) {
+	*e = append(*e, &PolicyValidationError{Field: field, Message: err.Error()})
+}
+
+// ValidatePolicy orchestrates policy content validation. All problems found are
+// returned together as PolicyValidationErrors so they can be fixed in one pass.
+// It is run by CreatePolicy, policies can not be updated through this client yet
+func ValidatePolicy(p *PolicyContents) error {
+	errs := PolicyValidationErrors{}
+	if p == nil {
+		errs.add("policy", "policy contents must be provided")
+		return errs
+	}
+
+	if p.General != nil {
+		p.General.validate(&errs)
+	}
+
+	for i, s := range p.Scripts {
+		if s == nil {
+			continue
+		}
+		if err := s.ValidatePriority(); err != nil {
+			errs.addErr(fmt.Sprintf("scripts[%d].priority", i), err)
+		}
+	}
+
+	if p.PackageConfiguration != nil {
+		for i, pkg := range p.PackageConfiguration.List {
+			if pkg == nil {
+				continue
+			}
+			if err := pkg.ValidateAction(); err != nil {
+				errs.addErr(fmt.Sprintf("package_configuration.packages[%d].action", i), err)
+			}
+		}
+	}
+
+	if p.DiskEncryption != nil {
+		if err := p.DiskEncryption.ValidateAction(); err != nil {
+			errs.addErr("disk_encryption.action", err)
+		}
+	}
+
+	if p.Scope != nil && p.Scope.AllComputers {
+		if len(p.Scope.Computers) > 0 {
+			errs.add("scope.computers", "explicit computers can not be scoped when all_computers is enabled")
+		}
+		if len(p.Scope.ComputerGroups) > 0 {
+			errs.add("scope.computer_groups", "explicit computer groups can not be scoped when all_computers is enabled")
+		}
+	}
+
+	if len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
+
+func (g *PolicyGeneral) validate(errs *PolicyValidationErrors) {
+	if err := g.ValidateFrequency(); err != nil {
+		errs.addErr("general.frequency", err)
+	}
+
+	if err := g.ValidateTrigger(); err != nil {
+		errs.addErr("general.trigger", err)
+	}
+
+	if err := g.ValidateRetryEvent(); err != nil {
+		errs.addErr("general.retry_event", err)
+	}
+
+	if err := g.ValidateNetworkRequirements(); err != nil {
+		errs.addErr("general.network_requirements", err)
+	}
+
+	// Jamf only supports retrying policies that run once per computer
+	retrying := g.RetryAttempts > 0 || (g.RetryEvent != "" && !strings.EqualFold(g.RetryEvent, "none"))
+	if retrying && g.Frequency != "" && !strings.EqualFold(g.Frequency, "Once per computer") {
+		errs.add("general.retry_attempts", "retries are only supported with a frequency of Once per computer not %s", g.Frequency)
+	}
+
+	if g.RetryAttempts < -1 || g.RetryAttempts > 10 {
+		errs.add("general.retry_attempts", "%d is not a valid number of retry attempts must be between 0 and 10 or -1 to disable retries", g.RetryAttempts)
+	}
+
+	if g.DateTimeLimitations != nil {
+		if err := g.DateTimeLimitations.ValidateOrdering(); err != nil {
+			errs.addErr("general.date_time_limitations", err)
+		}
+	}
+}
+
+// ValidateFrequency will validate that a policy's execution frequency is valid
+func (g *PolicyGeneral) ValidateFrequency() error {
+	switch strings.ToLower(g.Frequency) {
+	case "", "once per computer", "once per user per computer", "once per user", "once every day", "once every week", "once every month", "ongoing":
+		return nil
+	default:
+		return fmt.Errorf("%s is not a valid policy frequency must be of type [ Once per computer, Once per user per computer, Once per user, Once every day, Once every week, Once every month, Ongoing ]", g.Frequency)
+	}
+}
+
+// ValidateTrigger will validate that a policy's trigger is valid
+func (g *PolicyGeneral) ValidateTrigger() error {
+	switch strings.ToUpper(g.Trigger) {
+	case "", "EVENT", "USER_INITIATED":
+		return nil
+	default:
+		return fmt.Errorf("%s is not a valid policy trigger must be of type [ EVENT, USER_INITIATED ]", g.Trigger)
+	}
+}
+
+// ValidateRetryEvent will validate that a policy's retry event is valid
+func (g *PolicyGeneral) ValidateRetryEvent() error {
+	switch strings.ToLower(g.RetryEvent) {
+	case "", "none", "trigger", "check-in":
+		return nil
+	default:
+		return fmt.Errorf("%s is not a valid policy retry event must be of type [ none, trigger, check-in ]", g.RetryEvent)
+	}
+}
+
+// ValidateNetworkRequirements will validate that a policy's network requirements are valid
+func (g *PolicyGeneral) ValidateNetworkRequirements() error {
+	switch strings.ToLower(g.NetworkRequirements) {
+	case "", "any", "ethernet":
+		return nil
+	default:
+		return fmt.Errorf("%s is not a valid policy network requirement must be of type [ Any, Ethernet ]", g.NetworkRequirements)
+	}
+}
+
+// ValidatePriority will validate that a script assigned to a policy has a valid priority
+func (s *PolicyScriptAssignment) ValidatePriority() error {
+	switch strings.ToLower(s.Priority) {
+	case "", "before", "after":
+		return nil
+	default:
+		return fmt.Errorf("%s is not a valid policy script priority must be of type [ Before, After ]", s.Priority)
+	}
+}
+
+// ValidateAction will validate that a package assigned to a policy has a valid action
+func (p *Package) ValidateAction() error {
+	switch strings.ToLower(p.Action) {
+	case "", "install", "cache", "install cached", "uninstall":
+		return nil
+	default:
+		return fmt.Errorf("%s is not a valid policy package action must be of type [ Install, Cache, Install Cached, Uninstall ]", p.Action)
+	}
+}
+
+// ValidateAction will validate that a policy's disk encryption action is valid
+func (d *PolicyDiskEncryption) ValidateAction() error {
+	switch strings.ToLower(d.Action) {
+	case "", "none", "apply", "remediate":
+		return nil
+	default:
+		return fmt.Errorf("%s is not a valid policy disk encryption action must be of type [ none, apply, remediate ]", d.Action)
+	}
+}
+
+// ValidateOrdering will validate that a policy's activation date comes before its expiration date
+func (d *PolicyDateLimitations) ValidateOrdering() error {
+	if d.ActivationDateEPOCH > 0 && d.ExpirationDateEPOCH > 0 {
+		if d.ActivationDateEPOCH >= d.ExpirationDateEPOCH {
+			return fmt.Errorf("activation date %d must be before expiration date %d", d.ActivationDateEPOCH, d.ExpirationDateEPOCH)
+		}
+		return nil
+	}
+
+	if d.ActivationDate == "" || d.ExpirationDate == "" {
+		return nil
+	}
+
+	activation, err := time.Parse(policyDateLayout, d.ActivationDate)
+	if err != nil {
+		return fmt.Errorf("%s is not a valid activation date must be in the format %s", d.ActivationDate, policyDateLayout)
+	}
+
+	expiration, err := time.Parse(policyDateLayout, d.ExpirationDate)
+	if err != nil {
+		return fmt.Errorf("%s is not a valid expiration date must be in the format %s", d.ExpirationDate, policyDateLayout)
+	}
+
+	if !activation.Before(expiration) {
+		return fmt.Errorf("activation date %s must be before expiration date %s", d.ActivationDate, d.ExpirationDate)
+	}
+	return nil
+}
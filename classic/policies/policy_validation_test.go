@@ -0,0 +1,149 @@
+// Unless explicitly stated otherwise all files in this repository are licensed under the Apache-2.0
+// This product includes software developed at Datadog (https://www.datadoghq.com/). Copyright 2020 Datadog, Inc.
+
+package policies_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/trustero/jamf-api-client-go/classic/computers"
+	jamf "github.com/trustero/jamf-api-client-go/classic/policies"
+)
+
+func TestValidatePolicyPass(t *testing.T) {
+	p := &jamf.PolicyContents{
+		General: &jamf.PolicyGeneral{
+			Name:                "Test Policy",
+			Trigger:             "EVENT",
+			Frequency:           "Once per computer",
+			RetryEvent:          "check-in",
+			RetryAttempts:       3,
+			NetworkRequirements: "Any",
+			DateTimeLimitations: &jamf.PolicyDateLimitations{
+				ActivationDate: "2020-09-11 23:06:00",
+				ExpirationDate: "2020-10-11 23:06:00",
+			},
+		},
+		Scope: &jamf.Scope{
+			AllComputers: true,
+		},
+		PackageConfiguration: &jamf.Packages{
+			List: []*jamf.Package{{Name: "test.pkg", Action: "Install Cached"}},
+		},
+		Scripts: []*jamf.PolicyScriptAssignment{
+			{Name: "Test Echo", Priority: "Before"},
+		},
+		DiskEncryption: &jamf.PolicyDiskEncryption{
+			Action: "apply",
+		},
+	}
+	assert.Nil(t, jamf.ValidatePolicy(p))
+}
+
+func TestValidatePolicyAggregatesErrors(t *testing.T) {
+	p := &jamf.PolicyContents{
+		General: &jamf.PolicyGeneral{
+			Name:                "Test Policy",
+			Trigger:             "SOMETIMES",
+			Frequency:           "Ongoing",
+			RetryEvent:          "trigger",
+			RetryAttempts:       3,
+			NetworkRequirements: "Wi-Fi",
+			DateTimeLimitations: &jamf.PolicyDateLimitations{
+				ActivationDateEPOCH: 1600000000000,
+				ExpirationDateEPOCH: 1500000000000,
+			},
+		},
+		Scope: &jamf.Scope{
+			AllComputers: true,
+			Computers: []*computers.BasicComputerInfo{
+				{GeneralInformation: computers.GeneralInformation{Name: "TEST-BOX"}},
+			},
+		},
+		PackageConfiguration: &jamf.Packages{
+			List: []*jamf.Package{{Name: "test.pkg", Action: "Explode"}},
+		},
+		Scripts: []*jamf.PolicyScriptAssignment{
+			{Name: "Test Echo", Priority: "After"},
+			{Name: "Test Echo 2", Priority: "During"},
+		},
+		DiskEncryption: &jamf.PolicyDiskEncryption{
+			Action: "encrypt",
+		},
+	}
+
+	err := jamf.ValidatePolicy(p)
+	assert.NotNil(t, err)
+
+	errs, ok := err.(jamf.PolicyValidationErrors)
+	assert.True(t, ok)
+
+	fields := []string{}
+	for _, fe := range errs {
+		fields = append(fields, fe.Field)
+	}
+	assert.ElementsMatch(t, []string{
+		"general.trigger",
+		"general.network_requirements",
+		"general.retry_attempts",
+		"general.date_time_limitations",
+		"scripts[1].priority",
+		"package_configuration.packages[0].action",
+		"disk_encryption.action",
+		"scope.computers",
+	}, fields)
+	assert.Contains(t, err.Error(), fmt.Sprintf("policy validation failed with %d error(s)", len(fields)))
+}
+
+func TestValidatePolicyFrequencyFail(t *testing.T) {
+	g := &jamf.PolicyGeneral{}
+	for _, f := range []string{"Twice per computer", "hourly"} {
+		g.Frequency = f
+		err := g.ValidateFrequency()
+		assert.NotNil(t, err)
+		assert.Equal(t, fmt.Sprintf("%s is not a valid policy frequency must be of type [ Once per computer, Once per user per computer, Once per user, Once every day, Once every week, Once every month, Ongoing ]", f), err.Error())
+	}
+}
+
+func TestValidatePolicyRetryAttempts(t *testing.T) {
+	for _, attempts := range []int{-1, 0, 10} {
+		assert.Nil(t, jamf.ValidatePolicy(&jamf.PolicyContents{General: &jamf.PolicyGeneral{RetryAttempts: attempts}}))
+	}
+
+	err := jamf.ValidatePolicy(&jamf.PolicyContents{General: &jamf.PolicyGeneral{RetryAttempts: 11}})
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "11 is not a valid number of retry attempts must be between 0 and 10 or -1 to disable retries")
+}
+
+func TestValidatePolicyDateOrdering(t *testing.T) {
+	d := &jamf.PolicyDateLimitations{
+		ActivationDate: "2020-10-11 23:06:00",
+		ExpirationDate: "2020-09-11 23:06:00",
+	}
+	err := d.ValidateOrdering()
+	assert.NotNil(t, err)
+	assert.Equal(t, "activation date 2020-10-11 23:06:00 must be before expiration date 2020-09-11 23:06:00", err.Error())
+
+	d.ExpirationDate = "not a date"
+	err = d.ValidateOrdering()
+	assert.NotNil(t, err)
+	assert.Equal(t, "not a date is not a valid expiration date must be in the format 2006-01-02 15:04:05", err.Error())
+}
+
+func TestCreatePolicyValidationFailure(t *testing.T) {
+	testServer := policiesResponseMocks(t)
+	defer testServer.Close()
+	j, err := jamf.NewService(testServer.URL, "fake-username", "mock-password-cool", nil)
+	assert.Nil(t, err)
+
+	_, err = j.CreatePolicy(&jamf.PolicyContents{
+		General: &jamf.PolicyGeneral{
+			Name:      "Test Policy",
+			Frequency: "Whenever",
+		},
+	})
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "general.frequency")
+}
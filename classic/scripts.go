@@ -4,143 +4,84 @@
 package classic
 
 import (
-	"bytes"
 	"context"
-	"encoding/xml"
-	"fmt"
-	"net/http"
 
-	"github.com/pkg/errors"
+	"github.com/trustero/jamf-api-client-go/classic/scripts"
+	"github.com/trustero/jamf-api-client-go/pager"
 )
 
+// scriptService returns a scripts domain service sharing the credentials and HTTP client of the legacy service
+func (j *Service) scriptService() (*scripts.Service, error) {
+	return scripts.NewService(j.Domain, j.Username, j.Password, j.Api)
+}
+
 // Scripts returns a list of scripts available in the jamf client
+//
+// Deprecated: use scripts.Service.Scripts
 func (j *Service) Scripts() ([]BasicScriptInfo, error) {
-	ep := fmt.Sprintf("%s/%s", j.Endpoint, scriptsContext)
-	req, err := http.NewRequestWithContext(context.Background(), "GET", ep, nil)
+	s, err := j.scriptService()
 	if err != nil {
-		return nil, errors.Wrap(err, "error building JAMF scripts query request")
-	}
-	res := Scripts{}
-	if err := j.makeAPIrequest(req, &res); err != nil {
-		return nil, errors.Wrapf(err, "unable to query available scripts from %s", ep)
+		return nil, err
 	}
-	return res.List, nil
+	list, _, err := s.Scripts()
+	return list, err
 }
 
-// ScriptDetails returns the details for a specific script given its Id or Name
-func (j *Service) ScriptDetails(identifier interface{}) (*Script, error) {
-	ep, err := EndpointBuilder(j.Endpoint, scriptsContext, identifier)
+// IterateScripts returns an iterator over the scripts available in the jamf client
+//
+// Deprecated: use scripts.Service.Iterate
+func (j *Service) IterateScripts() *pager.Pager[BasicScriptInfo] {
+	s, err := j.scriptService()
 	if err != nil {
-		return nil, errors.Wrapf(err, "error building JAMF query request endpoint for script: %v", identifier)
+		return pager.Single(func(ctx context.Context) ([]BasicScriptInfo, error) { return nil, err })
 	}
+	return s.Iterate()
+}
 
-	req, err := http.NewRequestWithContext(context.Background(), "GET", ep, nil)
+// ScriptDetails returns the details for a specific script given its Id or Name
+//
+// Deprecated: use scripts.Service.ScriptDetails
+func (j *Service) ScriptDetails(identifier interface{}) (*Script, error) {
+	s, err := j.scriptService()
 	if err != nil {
-		return nil, errors.Wrapf(err, "error building JAMF query request for script: %v", identifier)
-	}
-
-	res := Script{}
-	if err := j.makeAPIrequest(req, &res); err != nil {
-		return nil, errors.Wrapf(err, "unable to query script with Id: %d from %s", identifier, ep)
-	}
-
-	// default to map for script parameters
-	if res.Content.Parameters == nil {
-		res.Content.Parameters = &ParametersList{}
+		return nil, err
 	}
-
-	return &res, nil
+	script, _, err := s.ScriptDetails(identifier)
+	return script, err
 }
 
 // UpdateScript will update a script in Jamf by either Id or Name
+//
+// Deprecated: use scripts.Service.UpdateScript
 func (j *Service) UpdateScript(identifier interface{}, script *ScriptContents) (*ScriptContents, error) {
-	ep, err := EndpointBuilder(j.Endpoint, scriptsContext, identifier)
-	if err != nil {
-		return nil, errors.Wrapf(err, "error building JAMF query request for script: %v", identifier)
-	}
-
-	// TODO: Fix hack
-	// handle empty parameters since they can come in as
-	// map[string]interface{} which can not be handled by xml/encoding
-	switch script.Parameters.(type) {
-	case map[string]interface{}:
-		script.Parameters = &ParametersList{}
-	}
-
-	bodyContent, err := xml.Marshal(script)
+	s, err := j.scriptService()
 	if err != nil {
-		return nil, errors.Wrapf(err, "error building JAMF update payload for script: %v", identifier)
-	}
-
-	body := bytes.NewReader(bodyContent)
-	req, err := http.NewRequestWithContext(context.Background(), "PUT", ep, body)
-	if err != nil {
-		return nil, errors.Wrapf(err, "error building JAMF update request for script: %v (%s)", identifier, ep)
-	}
-
-	res := ScriptContents{}
-	if err := j.makeAPIrequest(req, &res); err != nil {
-		return nil, errors.Wrapf(err, "unable to process JAMF update request for script: %v (%s)", identifier, ep)
+		return nil, err
 	}
-
-	return &res, nil
+	updated, _, err := s.UpdateScript(identifier, script)
+	return updated, err
 }
 
 // CreateScript will create a script in Jamf
+//
+// Deprecated: use scripts.Service.CreateScript
 func (j *Service) CreateScript(content *ScriptContents) (*ScriptContents, error) {
-	// -1 denotes the next available Id
-	ep, err := EndpointBuilder(j.Endpoint, scriptsContext, -1)
-	if err != nil {
-		return nil, errors.Wrapf(err, "error building JAMF query request for new script")
-	}
-
-	if content.Name == "" {
-		return nil, errors.Wrapf(fmt.Errorf("Name required for new script"), "unable to process JAMF creation request for script: (%s)", ep)
-	}
-
-	if content.Contents == "" {
-		return nil, errors.Wrapf(fmt.Errorf("Script contents required"), "unable to process JAMF creation request for script: (%s)", ep)
-	}
-
-	if content.Filename == "" {
-		content.Filename = content.Name
-	}
-
-	bodyContent, err := xml.Marshal(content)
-	if err != nil {
-		return nil, errors.Wrapf(err, "error building JAMF creation payload for script: %v", content.Name)
-	}
-
-	body := bytes.NewReader(bodyContent)
-	req, err := http.NewRequestWithContext(context.Background(), "POST", ep, body)
+	s, err := j.scriptService()
 	if err != nil {
-		return nil, errors.Wrapf(err, "error building JAMF creation request for script: %v (%s)", content.Name, ep)
-	}
-	res := ScriptContents{}
-
-	if err := j.makeAPIrequest(req, &res); err != nil {
-		return nil, errors.Wrapf(err, "unable to process JAMF creation request for script: %v (%s)", content.Name, ep)
+		return nil, err
 	}
-
-	return &res, nil
+	created, _, err := s.CreateScript(content)
+	return created, err
 }
 
 // DeleteScript will delete a script by either Id or Name
+//
+// Deprecated: use scripts.Service.DeleteScript
 func (j *Service) DeleteScript(identifier interface{}) (*ScriptContents, error) {
-	ep, err := EndpointBuilder(j.Endpoint, scriptsContext, identifier)
+	s, err := j.scriptService()
 	if err != nil {
-		return nil, errors.Wrapf(err, "error building JAMF query request for script: %v", identifier)
+		return nil, err
 	}
-
-	req, err := http.NewRequestWithContext(context.Background(), "DELETE", ep, nil)
-	if err != nil {
-		return nil, errors.Wrapf(err, "error building JAMF deletion request for script: %v (%s)", identifier, ep)
-	}
-
-	res := ScriptContents{}
-	if err := j.makeAPIrequest(req, &res); err != nil {
-		return nil, errors.Wrapf(err, "unable to process JAMF deletion request for script: %v (%s)", identifier, ep)
-	}
-
-	return &res, nil
+	deleted, _, err := s.DeleteScript(identifier)
+	return deleted, err
 }
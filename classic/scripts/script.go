@@ -0,0 +1,206 @@
+// Unless explicitly stated otherwise all files in this repository are licensed under the Apache-2.0
+// This product includes software developed at Datadog (https://www.datadoghq.com/). Copyright 2020 Datadog, Inc.
+
+package scripts
+
+import (
+	"bytes"
+	"context"
+	"encoding/xml"
+	"fmt"
+	"net/http"
+
+	"github.com/pkg/errors"
+	"github.com/trustero/jamf-api-client-go/classic/client"
+	"github.com/trustero/jamf-api-client-go/pager"
+)
+
+// Scripts returns a list of scripts available in the jamf client
+func (j *Service) Scripts() (result []BasicScriptInfo, response *http.Response, err error) {
+	return j.list(context.Background())
+}
+
+// Iterate returns an iterator over the scripts available in the jamf client
+func (j *Service) Iterate() *pager.Pager[BasicScriptInfo] {
+	return pager.Single(func(ctx context.Context) ([]BasicScriptInfo, error) {
+		scripts, _, err := j.list(ctx)
+		return scripts, err
+	})
+}
+
+func (j *Service) list(ctx context.Context) (result []BasicScriptInfo, response *http.Response, err error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", j.client.Endpoint, nil)
+	if err != nil {
+		err = errors.Wrap(err, "error building JAMF scripts query request")
+		return
+	}
+
+	res := &Scripts{}
+	if response, err = client.MakeAPIrequest(j.client, req, res); err != nil {
+		err = errors.Wrapf(err, "unable to query available scripts from %s", j.client.Endpoint)
+		return
+	}
+	result = res.List
+	return
+}
+
+// ScriptDetails returns the details for a specific script given its Id or Name
+func (j *Service) ScriptDetails(identifier interface{}) (result *Script, response *http.Response, err error) {
+	ep, err := j.client.IdentifierEndpoint(identifier)
+	if err != nil {
+		err = errors.Wrapf(err, "error building JAMF query request endpoint for script: %v", identifier)
+		return
+	}
+
+	req, err := http.NewRequestWithContext(context.Background(), "GET", ep, nil)
+	if err != nil {
+		err = errors.Wrapf(err, "error building JAMF query request for script: %v", identifier)
+		return
+	}
+
+	res := &Script{}
+	if response, err = client.MakeAPIrequest(j.client, req, res); err != nil {
+		err = errors.Wrapf(err, "unable to query script with identifier: %v from %s", identifier, ep)
+		return
+	}
+
+	if res.Content != nil {
+		// default to an empty list when Jamf omits the script parameters
+		if res.Content.Parameters == nil {
+			res.Content.Parameters = &ParametersList{}
+		}
+
+		if err = res.Content.decodeContents(); err != nil {
+			err = errors.Wrapf(err, "unable to query script with identifier: %v from %s", identifier, ep)
+			return
+		}
+	}
+
+	result = res
+	return
+}
+
+// UpdateScript will update a script in Jamf by either Id or Name. When Parameters is nil the
+// parameter labels are left untouched in Jamf otherwise every label is replaced
+func (j *Service) UpdateScript(identifier interface{}, script *ScriptContents) (result *ScriptContents, response *http.Response, err error) {
+	ep, err := j.client.IdentifierEndpoint(identifier)
+	if err != nil {
+		err = errors.Wrapf(err, "error building JAMF query request for script: %v", identifier)
+		return
+	}
+
+	if script == nil {
+		err = errors.Wrapf(fmt.Errorf("Empty payload"), "unable to process JAMF update request for script: %v (%s)", identifier, ep)
+		return
+	}
+
+	if err = ValidateScript(script); err != nil {
+		err = errors.Wrapf(err, "script validation failed: %v", identifier)
+		return
+	}
+
+	payload, err := script.uploadPayload()
+	if err != nil {
+		err = errors.Wrapf(err, "error building JAMF update payload for script: %v", identifier)
+		return
+	}
+
+	bodyContent, err := xml.Marshal(payload)
+	if err != nil {
+		err = errors.Wrapf(err, "error building JAMF update payload for script: %v", identifier)
+		return
+	}
+
+	req, err := http.NewRequestWithContext(context.Background(), "PUT", ep, bytes.NewReader(bodyContent))
+	if err != nil {
+		err = errors.Wrapf(err, "error building JAMF update request for script: %v (%s)", identifier, ep)
+		return
+	}
+
+	res := &ScriptContents{}
+	if response, err = client.MakeAPIrequest(j.client, req, res); err != nil {
+		err = errors.Wrapf(err, "unable to process JAMF update request for script: %v (%s)", identifier, ep)
+		return
+	}
+	result = res
+	return
+}
+
+// CreateScript will create a script in Jamf
+func (j *Service) CreateScript(content *ScriptContents) (result *ScriptContents, response *http.Response, err error) {
+	// -1 denotes the next available Id
+	ep := j.client.IdEndpoint(-1)
+
+	if content == nil {
+		err = errors.Wrapf(fmt.Errorf("Empty payload"), "unable to process JAMF creation request for script: (%s)", ep)
+		return
+	}
+
+	if content.Name == "" {
+		err = errors.Wrapf(fmt.Errorf("Name required for new script"), "unable to process JAMF creation request for script: (%s)", ep)
+		return
+	}
+
+	if content.Contents == "" && content.EncodedContents == "" {
+		err = errors.Wrapf(fmt.Errorf("Script contents required"), "unable to process JAMF creation request for script: (%s)", ep)
+		return
+	}
+
+	if content.Filename == "" {
+		content.Filename = content.Name
+	}
+
+	if err = ValidateScript(content); err != nil {
+		err = errors.Wrapf(err, "script validation failed: %v", content.Name)
+		return
+	}
+
+	payload, err := content.uploadPayload()
+	if err != nil {
+		err = errors.Wrapf(err, "error building JAMF creation payload for script: %v", content.Name)
+		return
+	}
+
+	bodyContent, err := xml.Marshal(payload)
+	if err != nil {
+		err = errors.Wrapf(err, "error building JAMF creation payload for script: %v", content.Name)
+		return
+	}
+
+	req, err := http.NewRequestWithContext(context.Background(), "POST", ep, bytes.NewReader(bodyContent))
+	if err != nil {
+		err = errors.Wrapf(err, "error building JAMF creation request for script: %v (%s)", content.Name, ep)
+		return
+	}
+
+	res := &ScriptContents{}
+	if response, err = client.MakeAPIrequest(j.client, req, res); err != nil {
+		err = errors.Wrapf(err, "unable to process JAMF creation request for script: %v (%s)", content.Name, ep)
+		return
+	}
+	result = res
+	return
+}
+
+// DeleteScript will delete a script by either Id or Name
+func (j *Service) DeleteScript(identifier interface{}) (result *ScriptContents, response *http.Response, err error) {
+	ep, err := j.client.IdentifierEndpoint(identifier)
+	if err != nil {
+		err = errors.Wrapf(err, "error building JAMF query request for script: %v", identifier)
+		return
+	}
+
+	req, err := http.NewRequestWithContext(context.Background(), "DELETE", ep, nil)
+	if err != nil {
+		err = errors.Wrapf(err, "error building JAMF deletion request for script: %v (%s)", identifier, ep)
+		return
+	}
+
+	res := &ScriptContents{}
+	if response, err = client.MakeAPIrequest(j.client, req, res); err != nil {
+		err = errors.Wrapf(err, "unable to process JAMF deletion request for script: %v (%s)", identifier, ep)
+		return
+	}
+	result = res
+	return
+}
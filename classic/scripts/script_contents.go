@@ -0,0 +1,107 @@
+// Unless explicitly stated otherwise all files in this repository are licensed under the Apache-2.0
+// This product includes software developed at Datadog (https://www.datadoghq.com/). Copyright 2020 Datadog, Inc.
+
+package scripts
+
+import (
+	"encoding/base64"
+	"unicode/utf8"
+
+	"github.com/pkg/errors"
+	"github.com/trustero/jamf-api-client-go/internal/shebang"
+)
+
+// Script returns the body of the script decoding script_contents_encoded when Jamf provided it
+func (s *ScriptContents) Script() (string, error) {
+	if s.EncodedContents != "" {
+		decoded, err := base64.StdEncoding.DecodeString(s.EncodedContents)
+		if err != nil {
+			return "", errors.Wrapf(err, "unable to decode contents of script: %v", s.Name)
+		}
+		return string(decoded), nil
+	}
+	return s.Contents, nil
+}
+
+// SetScript sets the body of the script. The encoded form is used when the body contains
+// characters that can not be sent in an XML payload
+func (s *ScriptContents) SetScript(body string) {
+	if NeedsEncoding(body) {
+		s.Contents = ""
+		s.EncodedContents = base64.StdEncoding.EncodeToString([]byte(body))
+		return
+	}
+	s.Contents = body
+	s.EncodedContents = ""
+}
+
+// Interpreter returns the interpreter named in the script shebang i.e bash for #!/bin/bash
+// or python3 for #!/usr/bin/env python3
+func (s *ScriptContents) Interpreter() (string, bool) {
+	body, err := s.Script()
+	if err != nil {
+		return "", false
+	}
+	return Interpreter(body)
+}
+
+// Interpreter returns the interpreter named in the shebang of a script body
+func Interpreter(body string) (string, bool) {
+	s, ok := shebang.Parse(body)
+	if !ok {
+		return "", false
+	}
+	interpreter := s.Interpreter()
+	return interpreter, interpreter != ""
+}
+
+// NeedsEncoding reports whether a script body contains characters that are not valid in XML 1.0
+// and so must be uploaded as script_contents_encoded
+func NeedsEncoding(body string) bool {
+	if !utf8.ValidString(body) {
+		return true
+	}
+
+	for _, r := range body {
+		switch {
+		case r == '\t' || r == '\n' || r == '\r':
+		case r < 0x20, r == 0xFFFE, r == 0xFFFF, r >= 0xD800 && r <= 0xDFFF:
+			return true
+		}
+	}
+	return false
+}
+
+// decodeContents fills in Contents from the encoded form when Jamf only returned the encoded form
+func (s *ScriptContents) decodeContents() error {
+	if s.Contents != "" || s.EncodedContents == "" {
+		return nil
+	}
+
+	body, err := s.Script()
+	if err != nil {
+		return err
+	}
+	s.Contents = body
+	return nil
+}
+
+// uploadPayload returns a copy of the script with exactly one of Contents or EncodedContents
+// set so Jamf never receives two different bodies
+func (s *ScriptContents) uploadPayload() (*ScriptContents, error) {
+	payload := *s
+	if s.Contents == "" && s.EncodedContents == "" {
+		return &payload, nil
+	}
+
+	body := s.Contents
+	if body == "" {
+		decoded, err := s.Script()
+		if err != nil {
+			return nil, err
+		}
+		body = decoded
+	}
+	payload.SetScript(body)
+	return &payload, nil
+}
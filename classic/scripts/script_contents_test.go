@@ -0,0 +1,113 @@
+// Unless explicitly stated otherwise all files in this repository are licensed under the Apache-2.0
+// This product includes software developed at Datadog (https://www.datadoghq.com/). Copyright 2020 Datadog, Inc.
+
+package scripts_test
+
+import (
+	"encoding/base64"
+	"encoding/xml"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	jamf "github.com/trustero/jamf-api-client-go/classic/scripts"
+)
+
+func TestScriptDecodesEncodedContents(t *testing.T) {
+	body := "#!/bin/bash\necho \"hello\"\n"
+	s := &jamf.ScriptContents{EncodedContents: base64.StdEncoding.EncodeToString([]byte(body))}
+	decoded, err := s.Script()
+	assert.Nil(t, err)
+	assert.Equal(t, body, decoded)
+
+	s = &jamf.ScriptContents{Contents: body}
+	decoded, err = s.Script()
+	assert.Nil(t, err)
+	assert.Equal(t, body, decoded)
+
+	s = &jamf.ScriptContents{Name: "Broken", EncodedContents: "not base64!"}
+	_, err = s.Script()
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "unable to decode contents of script: Broken")
+}
+
+func TestSetScriptChoosesEncoding(t *testing.T) {
+	s := &jamf.ScriptContents{}
+	s.SetScript("#!/bin/bash\necho \"<hello & goodbye>\"\r\n")
+	assert.Equal(t, "#!/bin/bash\necho \"<hello & goodbye>\"\r\n", s.Contents)
+	assert.Empty(t, s.EncodedContents)
+
+	// the escape character used for terminal colours is not valid in XML
+	body := "#!/bin/bash\necho -e \"\x1b[31mred\x1b[0m\"\n"
+	s.SetScript(body)
+	assert.Empty(t, s.Contents)
+	assert.Equal(t, base64.StdEncoding.EncodeToString([]byte(body)), s.EncodedContents)
+
+	assert.True(t, jamf.NeedsEncoding("\xff\xfe"))
+	assert.False(t, jamf.NeedsEncoding("échappé\ttab"))
+}
+
+func TestInterpreter(t *testing.T) {
+	for body, expected := range map[string]string{
+		"#!/bin/bash\necho hi":                  "bash",
+		"#!/bin/zsh -f\necho hi":                "zsh",
+		"#! /usr/bin/env python3\nprint('hi')":  "python3",
+		"#!/usr/bin/env -S perl -w\nprint 'hi'": "perl",
+	} {
+		interpreter, ok := jamf.Interpreter(body)
+		assert.True(t, ok)
+		assert.Equal(t, expected, interpreter)
+	}
+
+	_, ok := jamf.Interpreter("echo hi")
+	assert.False(t, ok)
+
+	s := &jamf.ScriptContents{EncodedContents: base64.StdEncoding.EncodeToString([]byte("#!/bin/sh\necho hi"))}
+	interpreter, ok := s.Interpreter()
+	assert.True(t, ok)
+	assert.Equal(t, "sh", interpreter)
+}
+
+func TestCreateScriptUploadsEncodedContents(t *testing.T) {
+	var uploaded *jamf.ScriptContents
+	testServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		data, _ := ioutil.ReadAll(r.Body)
+		uploaded = &jamf.ScriptContents{}
+		assert.Nil(t, xml.Unmarshal(data, uploaded))
+		fmt.Fprintf(w, `{"id": 34}`)
+	}))
+	defer testServer.Close()
+	j, err := jamf.NewService(testServer.URL, "fake-username", "mock-password-cool", nil)
+	assert.Nil(t, err)
+
+	body := "#!/bin/bash\nprintf '\x07'\n"
+	_, _, err = j.CreateScript(&jamf.ScriptContents{Name: "Bell", Contents: body})
+	assert.Nil(t, err)
+	assert.Empty(t, uploaded.Contents)
+	decoded, err := uploaded.Script()
+	assert.Nil(t, err)
+	assert.Equal(t, body, decoded)
+
+	// plain scripts are sent as is and a stale encoded body is never sent alongside them
+	_, _, err = j.UpdateScript(34, &jamf.ScriptContents{Contents: "#!/bin/bash\necho hi\n", EncodedContents: "IyEvYmluL2Jhc2gKI0dlQ=="})
+	assert.Nil(t, err)
+	assert.Equal(t, "#!/bin/bash\necho hi\n", uploaded.Contents)
+	assert.Empty(t, uploaded.EncodedContents)
+}
+
+func TestScriptDetailsDecodesContents(t *testing.T) {
+	body := "#!/bin/bash\necho hi\n"
+	testServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `{"script": {"id": 34, "name": "Hi", "script_contents": "", "script_contents_encoded": "%s"}}`, base64.StdEncoding.EncodeToString([]byte(body)))
+	}))
+	defer testServer.Close()
+	j, err := jamf.NewService(testServer.URL, "fake-username", "mock-password-cool", nil)
+	assert.Nil(t, err)
+
+	script, _, err := j.ScriptDetails(34)
+	assert.Nil(t, err)
+	assert.Equal(t, body, script.Content.Contents)
+}
@@ -0,0 +1,131 @@
+// Unless explicitly stated otherwise all files in this repository are licensed under the Apache-2.0
+// This product includes software developed at Datadog (https://www.datadoghq.com/). Copyright 2020 Datadog, Inc.
+
+package scripts
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+)
+
+// Scripts holds a list of all the scripts available in Jamf
+type Scripts struct {
+	List []BasicScriptInfo `json:"scripts"`
+}
+
+// BasicScriptInfo holds the most basic information about the scripts available in Jamf
+type BasicScriptInfo struct {
+	ID   int    `json:"id,omitempty" xml:"id,omitempty"`
+	Name string `json:"name"`
+}
+
+// Script holds the details to a specific script queried by Id
+type Script struct {
+	Content *ScriptContents `json:"script" xml:"script,omitempty"`
+}
+
+// ScriptContents holds the inner content of a script in Jamf
+type ScriptContents struct {
+	XMLName         xml.Name        `json:"-" xml:"script,omitempty"`
+	ID              int             `json:"id,omitempty" xml:"id,omitempty"`
+	Name            string          `json:"name" xml:"name,omitempty"`
+	Category        string          `json:"category" xml:"category,omitempty"`
+	Filename        string          `json:"filename" xml:"filename,omitempty"`
+	Info            string          `json:"info" xml:"info,omitempty"`
+	Notes           string          `json:"notes" xml:"notes,omitempty"`
+	Priority        string          `json:"priority" xml:"priority,omitempty"`
+	Parameters      *ParametersList `json:"parameters,omitempty" xml:"parameters,omitempty"`
+	Requirements    string          `json:"os_requirements" xml:"os_requirements,omitempty"`
+	Contents        string          `json:"script_contents" xml:"script_contents,omitempty"`
+	EncodedContents string          `json:"script_contents_encoded" xml:"script_contents_encoded,omitempty"`
+}
+
+// ParametersList holds the potential parameters that can be specified for a script in Jamf
+type ParametersList struct {
+	Parameter4  string `json:"parameter4" xml:"parameter4"`
+	Parameter5  string `json:"parameter5" xml:"parameter5"`
+	Parameter6  string `json:"parameter6" xml:"parameter6"`
+	Parameter7  string `json:"parameter7" xml:"parameter7"`
+	Parameter8  string `json:"parameter8" xml:"parameter8"`
+	Parameter9  string `json:"parameter9" xml:"parameter9"`
+	Parameter10 string `json:"parameter10" xml:"parameter10"`
+	Parameter11 string `json:"parameter11" xml:"parameter11"`
+}
+
+// Script parameters 1 to 3 are reserved by Jamf so labels can only be set on parameters 4 to 11
+const (
+	FirstParameter = 4
+	LastParameter  = 11
+)
+
+// parametersList is used to decode a parameters object without recursing into UnmarshalJSON
+type parametersList ParametersList
+
+// UnmarshalJSON decodes the parameter labels. Jamf sends an empty string rather than
+// an object when a script has no parameter labels
+func (p *ParametersList) UnmarshalJSON(data []byte) error {
+	trimmed := bytes.TrimSpace(data)
+	if bytes.Equal(trimmed, []byte(`""`)) || bytes.Equal(trimmed, []byte("null")) {
+		*p = ParametersList{}
+		return nil
+	}
+
+	list := parametersList{}
+	if err := json.Unmarshal(trimmed, &list); err != nil {
+		return err
+	}
+	*p = ParametersList(list)
+	return nil
+}
+
+// IsEmpty reports whether no parameter has a label
+func (p *ParametersList) IsEmpty() bool {
+	return p == nil || *p == ParametersList{}
+}
+
+// Label returns the label of the parameter given its number (4 to 11)
+func (p *ParametersList) Label(n int) string {
+	if field := p.field(n); field != nil {
+		return *field
+	}
+	return ""
+}
+
+// SetLabel sets the label of the parameter given its number (4 to 11)
+func (p *ParametersList) SetLabel(n int, label string) error {
+	field := p.field(n)
+	if field == nil {
+		return fmt.Errorf("%d is not a valid script parameter must be between %d and %d", n, FirstParameter, LastParameter)
+	}
+	*field = label
+	return nil
+}
+
+func (p *ParametersList) field(n int) *string {
+	if p == nil {
+		return nil
+	}
+
+	switch n {
+	case 4:
+		return &p.Parameter4
+	case 5:
+		return &p.Parameter5
+	case 6:
+		return &p.Parameter6
+	case 7:
+		return &p.Parameter7
+	case 8:
+		return &p.Parameter8
+	case 9:
+		return &p.Parameter9
+	case 10:
+		return &p.Parameter10
+	case 11:
+		return &p.Parameter11
+	default:
+		return nil
+	}
+}
@@ -0,0 +1,550 @@
+// Unless explicitly stated otherwise all files in this repository are licensed under the Apache-2.0
+// This product includes software developed at Datadog (https://www.datadoghq.com/). Copyright 2020 Datadog, Inc.
+
+package scripts
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/trustero/jamf-api-client-go/internal/filesync"
+	"github.com/trustero/jamf-api-client-go/internal/shebang"
+	"gopkg.in/yaml.v3"
+)
+
+// noCategory is the category Jamf reports for scripts without a category
+const noCategory = "No category assigned"
+
+// frontMatterDelimiter opens and closes the front matter comment block of a script file
+var frontMatterDelimiter = regexp.MustCompile(`^#\s*---\s*$`)
+
+// FrontMatter holds the script settings stored as YAML in a comment block at the top of a
+// script file, directly after the shebang:
+//
+//	#!/bin/bash
+//	# ---
+//	# name: Zoom Update
+//	# category: Apps
+//	# priority: After
+//	# parameters:
+//	#   4: Target Version
+//	# ---
+//
+// Settings left out of the front matter are not managed and never reported as drift
+type FrontMatter struct {
+	Name       string         `yaml:"name,omitempty"`
+	Category   string         `yaml:"category,omitempty"`
+	Info       string         `yaml:"info,omitempty"`
+	Notes      string         `yaml:"notes,omitempty"`
+	Priority   string         `yaml:"priority,omitempty"`
+	Parameters map[int]string `yaml:"parameters,omitempty"`
+}
+
+// ScriptFile is a single script read from disk
+type ScriptFile struct {
+	Path        string
+	FrontMatter *FrontMatter
+	// Body is the script as uploaded to Jamf with the front matter removed
+	Body string
+}
+
+// Name returns the Jamf script name which defaults to the file name without its extension
+func (f *ScriptFile) Name() string {
+	if f.FrontMatter != nil && f.FrontMatter.Name != "" {
+		return f.FrontMatter.Name
+	}
+	return strings.TrimSuffix(filepath.Base(f.Path), filepath.Ext(f.Path))
+}
+
+// Hash returns the content hash of the script body
+func (f *ScriptFile) Hash() string {
+	return ContentHash(f.Body)
+}
+
+// Contents returns the script as it would be created in Jamf
+func (f *ScriptFile) Contents() *ScriptContents {
+	s := &ScriptContents{Name: f.Name()}
+	if fm := f.FrontMatter; fm != nil {
+		s.Category = fm.Category
+		s.Info = fm.Info
+		s.Notes = fm.Notes
+		s.Priority = fm.Priority
+		if fm.Parameters != nil {
+			s.Parameters = &ParametersList{}
+			for n, label := range fm.Parameters {
+				// invalid parameter numbers are reported by LoadScriptDirectory
+				_ = s.Parameters.SetLabel(n, label)
+			}
+		}
+	}
+	s.SetScript(f.Body)
+	return s
+}
+
+// ContentHash returns a hash of a script body ignoring line ending differences
+func ContentHash(body string) string {
+	sum := sha256.Sum256([]byte(filesync.Normalize(body)))
+	return hex.EncodeToString(sum[:])
+}
+
+// ParseScriptFile splits a script file into its front matter and body. Files without a front
+// matter block are returned with a nil FrontMatter
+func ParseScriptFile(path string, data []byte) (*ScriptFile, error) {
+	lines := strings.SplitAfter(strings.ReplaceAll(string(data), "\r\n", "\n"), "\n")
+	file := &ScriptFile{Path: path, Body: string(data)}
+
+	start := 0
+	if len(lines) > 0 && strings.HasPrefix(lines[0], "#!") {
+		start = 1
+	}
+	if start >= len(lines) || !frontMatterDelimiter.MatchString(strings.TrimRight(lines[start], "\n")) {
+		return file, nil
+	}
+
+	var yamlLines []string
+	end := -1
+	for i := start + 1; i < len(lines); i++ {
+		line := strings.TrimRight(lines[i], "\n")
+		if line == "" && i == len(lines)-1 {
+			break
+		}
+		if frontMatterDelimiter.MatchString(line) {
+			end = i
+			break
+		}
+		if !strings.HasPrefix(line, "#") {
+			return nil, fmt.Errorf("%s: front matter line %d must be a comment", path, i+1)
+		}
+		line = strings.TrimPrefix(line, "#")
+		line = strings.TrimPrefix(line, " ")
+		yamlLines = append(yamlLines, line)
+	}
+	if end == -1 {
+		return nil, fmt.Errorf("%s: front matter is not closed with # ---", path)
+	}
+
+	fm := &FrontMatter{}
+	if err := yaml.Unmarshal([]byte(strings.Join(yamlLines, "\n")), fm); err != nil {
+		return nil, errors.Wrapf(err, "%s: unable to parse front matter", path)
+	}
+	file.FrontMatter = fm
+	file.Body = strings.Join(append(lines[:start:start], lines[end+1:]...), "")
+	return file, nil
+}
+
+// FormatScriptFile renders a script with its front matter inserted after the shebang
+func FormatScriptFile(fm *FrontMatter, body string) ([]byte, error) {
+	meta, err := yaml.Marshal(fm)
+	if err != nil {
+		return nil, err
+	}
+
+	var b strings.Builder
+	lines := strings.SplitAfter(filesync.Normalize(body), "\n")
+	if strings.HasPrefix(lines[0], "#!") {
+		b.WriteString(lines[0])
+		lines = lines[1:]
+	}
+
+	b.WriteString("# ---\n")
+	for _, line := range strings.Split(strings.TrimRight(string(meta), "\n"), "\n") {
+		b.WriteString(strings.TrimRight("# "+line, " ") + "\n")
+	}
+	b.WriteString("# ---\n")
+	b.WriteString(strings.Join(lines, ""))
+	return []byte(b.String()), nil
+}
+
+// LoadScriptDirectory reads every script in a directory. Hidden files and markdown files are skipped
+func LoadScriptDirectory(dir string) ([]*ScriptFile, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, errors.Wrapf(err, "unable to read script directory: %s", dir)
+	}
+
+	var files []*ScriptFile
+	var problems []string
+	seen := map[string]string{}
+	for _, e := range entries {
+		if e.IsDir() || strings.HasPrefix(e.Name(), ".") || strings.EqualFold(filepath.Ext(e.Name()), ".md") {
+			continue
+		}
+
+		path := filepath.Join(dir, e.Name())
+		data, err := os.ReadFile(path)
+		if err != nil {
+			problems = append(problems, errors.Wrapf(err, "unable to read script: %s", path).Error())
+			continue
+		}
+
+		file, err := ParseScriptFile(path, data)
+		if err != nil {
+			problems = append(problems, err.Error())
+			continue
+		}
+
+		if other, ok := seen[file.Name()]; ok {
+			problems = append(problems, fmt.Sprintf("%s: duplicate script name %q also used by %s", path, file.Name(), other))
+			continue
+		}
+		seen[file.Name()] = path
+
+		if file.FrontMatter != nil {
+			for n := range file.FrontMatter.Parameters {
+				if n < FirstParameter || n > LastParameter {
+					problems = append(problems, fmt.Sprintf("%s: %d is not a valid script parameter must be between %d and %d", path, n, FirstParameter, LastParameter))
+				}
+			}
+		}
+
+		if err := ValidateScript(file.Contents()); err != nil {
+			problems = append(problems, fmt.Sprintf("%s: %s", path, err.Error()))
+			continue
+		}
+		files = append(files, file)
+	}
+
+	if len(problems) > 0 {
+		return nil, fmt.Errorf("invalid scripts in %s: %s", dir, strings.Join(problems, "; "))
+	}
+
+	sort.Slice(files, func(a, b int) bool { return files[a].Name() < files[b].Name() })
+	return files, nil
+}
+
+// SyncAction describes what a sync will do to a single script
+type SyncAction = filesync.Action
+
+// Actions a sync plan can take
+const (
+	SyncCreate    = filesync.Create
+	SyncUpdate    = filesync.Update
+	SyncRename    = filesync.Rename
+	SyncDelete    = filesync.Delete
+	SyncOrphan    = filesync.Orphan
+	SyncUnchanged = filesync.Unchanged
+)
+
+// Drift kinds reported on a sync change
+const (
+	DriftContent    = "content"
+	DriftName       = "name"
+	DriftCategory   = "category"
+	DriftInfo       = "info"
+	DriftNotes      = "notes"
+	DriftPriority   = "priority"
+	DriftParameters = "parameters"
+)
+
+// SyncOptions holds the settings used when syncing a script directory with Jamf. Without Prune
+// scripts in Jamf that have no file on disk are only reported as orphans
+type SyncOptions = filesync.Options
+
+// SyncChange is a single planned change to a script
+type SyncChange struct {
+	filesync.Change
+	File    *ScriptFile
+	Current *ScriptContents
+}
+
+// SyncPlan holds every change needed to bring Jamf in line with a script directory
+type SyncPlan = filesync.Plan[*SyncChange]
+
+// PlanScriptSync compares a directory of scripts with Jamf and returns the changes needed to make
+// Jamf match the directory. Scripts are matched by name, a Jamf script with no file that has the
+// same content hash as a new file is reported as renamed. Nothing is modified in Jamf
+func (j *Service) PlanScriptSync(dir string, opts *SyncOptions) (*SyncPlan, error) {
+	if opts == nil {
+		opts = &SyncOptions{}
+	}
+
+	files, err := LoadScriptDirectory(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	list, _, err := j.Scripts()
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to plan script sync")
+	}
+
+	byName := map[string]*ScriptContents{}
+	var existing []*ScriptContents
+	for _, s := range list {
+		details, _, err := j.ScriptDetails(s.ID)
+		if err != nil {
+			return nil, errors.Wrap(err, "unable to plan script sync")
+		}
+		if details.Content == nil {
+			return nil, fmt.Errorf("unable to plan script sync: no contents returned for script: %d", s.ID)
+		}
+		existing = append(existing, details.Content)
+		byName[details.Content.Name] = details.Content
+	}
+
+	wanted := map[string]bool{}
+	for _, f := range files {
+		wanted[f.Name()] = true
+	}
+
+	// Jamf scripts without a file on disk may have been renamed
+	claimed := map[int]bool{}
+	plan := filesync.NewPlan[*SyncChange]("script")
+	for _, f := range files {
+		current, ok := byName[f.Name()]
+		if !ok {
+			if renamed := findRenamed(existing, wanted, claimed, f.Hash()); renamed != nil {
+				claimed[renamed.ID] = true
+				plan.Changes = append(plan.Changes, &SyncChange{
+					Change: filesync.Change{
+						Action:   SyncRename,
+						Name:     f.Name(),
+						Previous: renamed.Name,
+						ID:       renamed.ID,
+						Drift:    append([]string{DriftName}, drift(renamed, f)...),
+						Diff:     diffScripts(renamed, f),
+					},
+					File:    f,
+					Current: renamed,
+				})
+				continue
+			}
+
+			plan.Changes = append(plan.Changes, &SyncChange{
+				Change: filesync.Change{Action: SyncCreate, Name: f.Name(), Diff: diffScripts(nil, f)},
+				File:   f,
+			})
+			continue
+		}
+
+		change := &SyncChange{Change: filesync.Change{Action: SyncUnchanged, Name: f.Name(), ID: current.ID}, File: f, Current: current}
+		if change.Drift = drift(current, f); len(change.Drift) > 0 {
+			change.Action = SyncUpdate
+			change.Diff = diffScripts(current, f)
+		}
+		plan.Changes = append(plan.Changes, change)
+	}
+
+	for _, s := range existing {
+		if wanted[s.Name] || claimed[s.ID] {
+			continue
+		}
+
+		change := &SyncChange{Change: filesync.Change{Action: SyncOrphan, Name: s.Name, ID: s.ID}, Current: s}
+		if opts.Prune {
+			change.Action = SyncDelete
+			change.Diff = diffScripts(s, nil)
+		}
+		plan.Changes = append(plan.Changes, change)
+	}
+
+	return plan, nil
+}
+
+func findRenamed(existing []*ScriptContents, wanted map[string]bool, claimed map[int]bool, hash string) *ScriptContents {
+	for _, s := range existing {
+		if wanted[s.Name] || claimed[s.ID] {
+			continue
+		}
+		if body, err := s.Script(); err == nil && ContentHash(body) == hash {
+			return s
+		}
+	}
+	return nil
+}
+
+// drift lists the managed settings that differ between Jamf and disk
+func drift(current *ScriptContents, f *ScriptFile) []string {
+	var kinds []string
+	body, err := current.Script()
+	if err != nil || ContentHash(body) != f.Hash() {
+		kinds = append(kinds, DriftContent)
+	}
+
+	fm := f.FrontMatter
+	if fm == nil {
+		return kinds
+	}
+
+	if fm.Category != "" && !strings.EqualFold(fm.Category, category(current)) {
+		kinds = append(kinds, DriftCategory)
+	}
+	if fm.Info != "" && fm.Info != current.Info {
+		kinds = append(kinds, DriftInfo)
+	}
+	if fm.Notes != "" && fm.Notes != current.Notes {
+		kinds = append(kinds, DriftNotes)
+	}
+	if fm.Priority != "" && !strings.EqualFold(fm.Priority, current.Priority) {
+		kinds = append(kinds, DriftPriority)
+	}
+	if fm.Parameters != nil && !sameParameters(fm.Parameters, current.Parameters) {
+		kinds = append(kinds, DriftParameters)
+	}
+	return kinds
+}
+
+func sameParameters(labels map[int]string, params *ParametersList) bool {
+	for n := FirstParameter; n <= LastParameter; n++ {
+		if labels[n] != params.Label(n) {
+			return false
+		}
+	}
+	return true
+}
+
+func category(s *ScriptContents) string {
+	if s.Category == noCategory {
+		return ""
+	}
+	return s.Category
+}
+
+// ApplyScriptSync applies every pending change in a plan. All changes are attempted and the
+// outcome of each is recorded on the change, an error is returned if any change failed
+func (j *Service) ApplyScriptSync(plan *SyncPlan) error {
+	return plan.Apply(j.applyChange)
+}
+
+// SyncScripts plans the changes needed to make Jamf match a directory of scripts, writes the
+// plan to the Output provided and applies it unless DryRun is set
+func (j *Service) SyncScripts(dir string, opts *SyncOptions) (*SyncPlan, error) {
+	plan, err := j.PlanScriptSync(dir, opts)
+	if err != nil {
+		return nil, err
+	}
+	return plan, plan.Run(opts, j.applyChange)
+}
+
+func (j *Service) applyChange(c *SyncChange) (err error) {
+	switch c.Action {
+	case SyncCreate:
+		_, _, err = j.CreateScript(c.File.Contents())
+	case SyncUpdate, SyncRename:
+		_, _, err = j.UpdateScript(c.ID, c.File.Contents())
+	case SyncDelete:
+		_, _, err = j.DeleteScript(c.ID)
+	}
+	return err
+}
+
+// ExportScripts writes every script in Jamf to a directory with its settings as front matter
+// using the layout read by SyncScripts and returns the paths of the files written
+func (j *Service) ExportScripts(dir string) ([]string, error) {
+	list, _, err := j.Scripts()
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to export scripts")
+	}
+
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, errors.Wrapf(err, "unable to create script directory: %s", dir)
+	}
+
+	var written []string
+	for _, s := range list {
+		details, _, err := j.ScriptDetails(s.ID)
+		if err != nil {
+			return written, errors.Wrap(err, "unable to export scripts")
+		}
+		script := details.Content
+		if script == nil {
+			continue
+		}
+
+		body, err := script.Script()
+		if err != nil {
+			return written, errors.Wrap(err, "unable to export scripts")
+		}
+
+		data, err := FormatScriptFile(frontMatter(script), body)
+		if err != nil {
+			return written, errors.Wrapf(err, "unable to build front matter for script: %s", script.Name)
+		}
+
+		path := filepath.Join(dir, ScriptFileName(script.Name)+shebang.Extension(body))
+		if err := os.WriteFile(path, data, 0o755); err != nil {
+			return written, errors.Wrapf(err, "unable to write script: %s", path)
+		}
+		written = append(written, path)
+	}
+	return written, nil
+}
+
+// ScriptFileName returns the file name without extension used to store a script on disk
+func ScriptFileName(name string) string {
+	return filesync.FileName(name)
+}
+
+func frontMatter(s *ScriptContents) *FrontMatter {
+	fm := &FrontMatter{
+		Name:     s.Name,
+		Category: category(s),
+		Info:     s.Info,
+		Notes:    s.Notes,
+		Priority: s.Priority,
+	}
+
+	for n := FirstParameter; n <= LastParameter; n++ {
+		if label := s.Parameters.Label(n); label != "" {
+			if fm.Parameters == nil {
+				fm.Parameters = map[int]string{}
+			}
+			fm.Parameters[n] = label
+		}
+	}
+	return fm
+}
+
+// diffScripts returns a unified diff between the script in Jamf and the file on disk.
+// Either side may be nil for a create or delete
+func diffScripts(current *ScriptContents, f *ScriptFile) string {
+	var from, to []string
+	if current != nil {
+		body, _ := current.Script()
+		from = scriptLines(frontMatter(current), body)
+	}
+	if f != nil {
+		fm := f.FrontMatter
+		if fm == nil {
+			fm = &FrontMatter{}
+		}
+		fm = &FrontMatter{Name: f.Name(), Category: fm.Category, Info: fm.Info, Notes: fm.Notes, Priority: fm.Priority, Parameters: fm.Parameters}
+		// unmanaged settings are shown as they are in Jamf so they do not appear as changes
+		if current != nil {
+			jamf := frontMatter(current)
+			if fm.Category == "" {
+				fm.Category = jamf.Category
+			}
+			if fm.Info == "" {
+				fm.Info = jamf.Info
+			}
+			if fm.Notes == "" {
+				fm.Notes = jamf.Notes
+			}
+			if fm.Priority == "" {
+				fm.Priority = jamf.Priority
+			}
+			if fm.Parameters == nil {
+				fm.Parameters = jamf.Parameters
+			}
+		}
+		to = scriptLines(fm, f.Body)
+	}
+
+	return filesync.Diff(from, to)
+}
+
+func scriptLines(fm *FrontMatter, body string) []string {
+	data, err := FormatScriptFile(fm, body)
+	if err != nil {
+		return filesync.SplitLines(filesync.Normalize(body))
+	}
+	return filesync.SplitLines(string(data))
+}
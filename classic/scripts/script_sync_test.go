@@ -0,0 +1,227 @@
+// Unless explicitly stated otherwise all files in this repository are licensed under the Apache-2.0
+// This product includes software developed at Datadog (https://www.datadoghq.com/). Copyright 2020 Datadog, Inc.
+
+package scripts_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	jamf "github.com/trustero/jamf-api-client-go/classic/scripts"
+)
+
+type scriptSyncMock struct {
+	mu      sync.Mutex
+	scripts map[int]*jamf.ScriptContents
+	calls   []string
+}
+
+func newScriptSyncMock() *scriptSyncMock {
+	return &scriptSyncMock{scripts: map[int]*jamf.ScriptContents{
+		1: {
+			ID:       1,
+			Name:     "Cache macOS Updates",
+			Category: "Maintenance",
+			Priority: "After",
+			Contents: "#!/bin/bash\r\nsoftwareupdate --download --all\r\n",
+		},
+		33: {
+			ID:         33,
+			Name:       "Zoom Script 2",
+			Category:   "No category assigned",
+			Priority:   "After",
+			Parameters: &jamf.ParametersList{Parameter4: "Target Version"},
+			Contents:   "#!/bin/bash\nZoom_Target_Version=\"$4\"\necho $Zoom_Target_Version\n",
+		},
+		52: {
+			ID:       52,
+			Name:     "Admin to Standard",
+			Priority: "Before",
+			Contents: "#!/bin/bash\ndseditgroup -o edit -d \"$3\" -t user admin\n",
+		},
+		60: {
+			ID:       60,
+			Name:     "Old Cleanup",
+			Priority: "After",
+			Contents: "#!/bin/sh\nrm -rf /tmp/old\n",
+		},
+	}}
+}
+
+func (m *scriptSyncMock) server() *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		m.mu.Lock()
+		defer m.mu.Unlock()
+
+		if r.RequestURI == SCRIPTS_API_BASE_ENDPOINT {
+			list := &jamf.Scripts{}
+			for _, id := range []int{1, 33, 52, 60} {
+				list.List = append(list.List, jamf.BasicScriptInfo{ID: id, Name: m.scripts[id].Name})
+			}
+			json.NewEncoder(w).Encode(list)
+			return
+		}
+
+		var id int
+		fmt.Sscanf(r.RequestURI, SCRIPTS_API_BASE_ENDPOINT+"/id/%d", &id)
+		if r.Method != "GET" {
+			m.calls = append(m.calls, fmt.Sprintf("%s %d", r.Method, id))
+			fmt.Fprintf(w, `{"id": %d}`, id)
+			return
+		}
+
+		script, ok := m.scripts[id]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		json.NewEncoder(w).Encode(&jamf.Script{Content: script})
+	}))
+}
+
+func writeScriptFile(t *testing.T, dir string, name string, contents string) {
+	assert.Nil(t, os.WriteFile(filepath.Join(dir, name), []byte(contents), 0o755))
+}
+
+func TestParseScriptFile(t *testing.T) {
+	file, err := jamf.ParseScriptFile("zoom.sh", []byte("#!/bin/bash\r\n# ---\r\n# name: Zoom Script 2\r\n# category: Apps\r\n# parameters:\r\n#   4: Target Version\r\n# ---\r\necho hi\r\n"))
+	assert.Nil(t, err)
+	assert.Equal(t, "Zoom Script 2", file.Name())
+	assert.Equal(t, "Apps", file.FrontMatter.Category)
+	assert.Equal(t, map[int]string{4: "Target Version"}, file.FrontMatter.Parameters)
+	assert.Equal(t, "#!/bin/bash\necho hi\n", file.Body)
+
+	// files without front matter are uploaded as is and named after the file
+	file, err = jamf.ParseScriptFile("dir/cleanup.sh", []byte("#!/bin/sh\n# just a comment\nrm -rf /tmp/old\n"))
+	assert.Nil(t, err)
+	assert.Nil(t, file.FrontMatter)
+	assert.Equal(t, "cleanup", file.Name())
+	assert.Equal(t, "#!/bin/sh\n# just a comment\nrm -rf /tmp/old\n", file.Body)
+
+	_, err = jamf.ParseScriptFile("broken.sh", []byte("#!/bin/sh\n# ---\n# name: Broken\necho hi\n"))
+	assert.NotNil(t, err)
+	assert.Equal(t, "broken.sh: front matter line 4 must be a comment", err.Error())
+
+	_, err = jamf.ParseScriptFile("open.sh", []byte("#!/bin/sh\n# ---\n# name: Open\n"))
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "front matter is not closed")
+}
+
+func TestFormatScriptFileRoundTrip(t *testing.T) {
+	fm := &jamf.FrontMatter{Name: "Zoom Script 2", Priority: "After", Parameters: map[int]string{4: "Target Version"}}
+	data, err := jamf.FormatScriptFile(fm, "#!/bin/bash\necho hi")
+	assert.Nil(t, err)
+	assert.Equal(t, "#!/bin/bash\n# ---\n# name: Zoom Script 2\n# priority: After\n# parameters:\n#     4: Target Version\n# ---\necho hi\n", string(data))
+
+	file, err := jamf.ParseScriptFile("zoom.sh", data)
+	assert.Nil(t, err)
+	assert.Equal(t, fm, file.FrontMatter)
+	assert.Equal(t, "#!/bin/bash\necho hi\n", file.Body)
+}
+
+func TestLoadScriptDirectoryInvalid(t *testing.T) {
+	dir := t.TempDir()
+	writeScriptFile(t, dir, "a.sh", "#!/bin/sh\n# ---\n# name: Same\n# priority: Sometimes\n# ---\necho a\n")
+	writeScriptFile(t, dir, "b.sh", "#!/bin/sh\n# ---\n# name: Params\n# parameters:\n#   3: Reserved\n# ---\necho b\n")
+	writeScriptFile(t, dir, "README.md", "# not a script")
+
+	_, err := jamf.LoadScriptDirectory(dir)
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "Sometimes is not a valid script priority")
+	assert.Contains(t, err.Error(), "3 is not a valid script parameter must be between 4 and 11")
+}
+
+func TestPlanScriptSyncDrift(t *testing.T) {
+	mock := newScriptSyncMock()
+	testServer := mock.server()
+	defer testServer.Close()
+	j, err := jamf.NewService(testServer.URL, "fake-username", "mock-password-cool", nil)
+	assert.Nil(t, err)
+
+	dir := t.TempDir()
+	// identical apart from line endings and unmanaged settings
+	writeScriptFile(t, dir, "Cache_macOS_Updates.sh", "#!/bin/bash\n# ---\n# name: Cache macOS Updates\n# ---\nsoftwareupdate --download --all\n")
+	// content and parameter label drift
+	writeScriptFile(t, dir, "Zoom.sh", "#!/bin/bash\n# ---\n# name: Zoom Script 2\n# parameters:\n#   4: Zoom Version\n# ---\nZoom_Target_Version=\"$4\"\necho \"$Zoom_Target_Version\"\n")
+	// same content as Admin to Standard under a new name
+	writeScriptFile(t, dir, "Demote_Admins.sh", "#!/bin/bash\n# ---\n# name: Demote Admins\n# ---\ndseditgroup -o edit -d \"$3\" -t user admin\n")
+	writeScriptFile(t, dir, "New.py", "#!/usr/bin/env python3\nprint('new')\n")
+
+	out := &bytes.Buffer{}
+	plan, err := j.SyncScripts(dir, &jamf.SyncOptions{DryRun: true, Output: out})
+	assert.Nil(t, err)
+	assert.Empty(t, mock.calls)
+	assert.True(t, plan.HasDrift())
+
+	changes := map[string]*jamf.SyncChange{}
+	for _, c := range plan.Changes {
+		changes[c.Name] = c
+	}
+	assert.Equal(t, 5, len(changes))
+	assert.Equal(t, jamf.SyncUnchanged, changes["Cache macOS Updates"].Action)
+	assert.Equal(t, jamf.SyncUpdate, changes["Zoom Script 2"].Action)
+	assert.Equal(t, []string{jamf.DriftContent, jamf.DriftParameters}, changes["Zoom Script 2"].Drift)
+	assert.Equal(t, jamf.SyncRename, changes["Demote Admins"].Action)
+	assert.Equal(t, 52, changes["Demote Admins"].ID)
+	assert.Equal(t, jamf.SyncCreate, changes["New"].Action)
+	assert.Equal(t, jamf.SyncOrphan, changes["Old Cleanup"].Action)
+	assert.Equal(t, 3, len(plan.Pending()))
+
+	assert.Contains(t, out.String(), "update script \"Zoom Script 2\" (content, parameters)")
+	assert.Contains(t, out.String(), "-#     4: Target Version")
+	assert.Contains(t, out.String(), "+#     4: Zoom Version")
+	assert.Contains(t, out.String(), "+echo \"$Zoom_Target_Version\"")
+	assert.Contains(t, out.String(), "rename script \"Admin to Standard\" to \"Demote Admins\"")
+	assert.Contains(t, out.String(), "orphan script \"Old Cleanup\" (Id 60) has no file on disk")
+	assert.NotContains(t, out.String(), "Cache macOS Updates")
+}
+
+func TestSyncScriptsApply(t *testing.T) {
+	mock := newScriptSyncMock()
+	testServer := mock.server()
+	defer testServer.Close()
+	j, err := jamf.NewService(testServer.URL, "fake-username", "mock-password-cool", nil)
+	assert.Nil(t, err)
+
+	dir := t.TempDir()
+	writeScriptFile(t, dir, "Zoom.sh", "#!/bin/bash\n# ---\n# name: Zoom Script 2\n# ---\necho updated\n")
+	writeScriptFile(t, dir, "Demote_Admins.sh", "#!/bin/bash\n# ---\n# name: Demote Admins\n# ---\ndseditgroup -o edit -d \"$3\" -t user admin\n")
+	writeScriptFile(t, dir, "New.py", "#!/usr/bin/env python3\nprint('new')\n")
+
+	plan, err := j.SyncScripts(dir, &jamf.SyncOptions{Prune: true})
+	assert.Nil(t, err)
+	assert.Equal(t, 5, len(plan.Pending()))
+	assert.ElementsMatch(t, []string{"PUT 33", "PUT 52", "POST -1", "DELETE 1", "DELETE 60"}, mock.calls)
+}
+
+func TestExportScripts(t *testing.T) {
+	mock := newScriptSyncMock()
+	testServer := mock.server()
+	defer testServer.Close()
+	j, err := jamf.NewService(testServer.URL, "fake-username", "mock-password-cool", nil)
+	assert.Nil(t, err)
+
+	dir := t.TempDir()
+	written, err := j.ExportScripts(dir)
+	assert.Nil(t, err)
+	assert.Equal(t, 4, len(written))
+	assert.Equal(t, filepath.Join(dir, "Zoom_Script_2.sh"), written[1])
+
+	data, err := os.ReadFile(written[1])
+	assert.Nil(t, err)
+	assert.Equal(t, "#!/bin/bash\n# ---\n# name: Zoom Script 2\n# priority: After\n# parameters:\n#     4: Target Version\n# ---\nZoom_Target_Version=\"$4\"\necho $Zoom_Target_Version\n", string(data))
+
+	// an export read back in has no drift
+	plan, err := j.PlanScriptSync(dir, nil)
+	assert.Nil(t, err)
+	assert.False(t, plan.HasDrift())
+	assert.Equal(t, "no changes\n", plan.String())
+}
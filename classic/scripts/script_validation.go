@@ -0,0 +1,58 @@
+// Unless explicitly stated otherwise all files in this repository are licensed under the Apache-2.0
+// This product includes software developed at Datadog (https://www.datadoghq.com/). Copyright 2020 Datadog, Inc.
+
+package scripts
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// requirementPattern matches a single macOS version requirement i.e 10.15.x or 11.2.3
+var requirementPattern = regexp.MustCompile(`^\d+(\.\d+)*(\.x)?$`)
+
+// ValidateScript orchestrates script content validation
+func ValidateScript(s *ScriptContents) error {
+	if err := s.ValidatePriority(); err != nil {
+		return err
+	}
+
+	if err := s.ValidateRequirements(); err != nil {
+		return err
+	}
+
+	// the encoded form is only uploaded when there are no plain contents
+	if s.Contents == "" {
+		if _, err := s.Script(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// ValidatePriority will validate that a script's priority is valid
+func (s *ScriptContents) ValidatePriority() error {
+	switch strings.ToLower(s.Priority) {
+	case "", "before", "after", "at reboot":
+		return nil
+	default:
+		return fmt.Errorf("%s is not a valid script priority must be of type [ Before, After, At Reboot ]", s.Priority)
+	}
+}
+
+// ValidateRequirements will validate that a script's OS requirements are a comma separated
+// list of macOS versions i.e 10.15.x, 11.x
+func (s *ScriptContents) ValidateRequirements() error {
+	if strings.TrimSpace(s.Requirements) == "" {
+		return nil
+	}
+
+	for _, r := range strings.Split(s.Requirements, ",") {
+		if !requirementPattern.MatchString(strings.TrimSpace(r)) {
+			return fmt.Errorf("%s is not a valid script OS requirement must be a comma separated list of versions i.e 10.15.x, 11.x", strings.TrimSpace(r))
+		}
+	}
+	return nil
+}
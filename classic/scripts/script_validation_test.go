@@ -0,0 +1,58 @@
+// Unless explicitly stated otherwise all files in this repository are licensed under the Apache-2.0
+// This product includes software developed at Datadog (https://www.datadoghq.com/). Copyright 2020 Datadog, Inc.
+
+package scripts_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	jamf "github.com/trustero/jamf-api-client-go/classic/scripts"
+)
+
+func TestValidateScriptPriorityPass(t *testing.T) {
+	s := &jamf.ScriptContents{}
+	for _, p := range []string{"", "Before", "After", "At Reboot", "after"} {
+		s.Priority = p
+		assert.Nil(t, s.ValidatePriority())
+	}
+}
+
+func TestValidateScriptPriorityFail(t *testing.T) {
+	s := &jamf.ScriptContents{}
+	for _, p := range []string{"During", "Reboot"} {
+		s.Priority = p
+		err := s.ValidatePriority()
+		assert.NotNil(t, err)
+		assert.Equal(t, fmt.Sprintf("%s is not a valid script priority must be of type [ Before, After, At Reboot ]", p), err.Error())
+	}
+}
+
+func TestValidateScriptRequirements(t *testing.T) {
+	s := &jamf.ScriptContents{}
+	for _, r := range []string{"", "10.15.x", "10.14.x, 10.15.x,11.x", "11.2.3"} {
+		s.Requirements = r
+		assert.Nil(t, s.ValidateRequirements())
+	}
+
+	for _, r := range []string{"Catalina", "10.15.x, latest", "10..1"} {
+		s.Requirements = r
+		assert.NotNil(t, s.ValidateRequirements())
+	}
+}
+
+func TestCreateScriptValidation(t *testing.T) {
+	testServer := scriptsResponseMocks(t)
+	defer testServer.Close()
+	j, err := jamf.NewService(testServer.URL, "fake-username", "mock-password-cool", nil)
+	assert.Nil(t, err)
+
+	_, _, err = j.CreateScript(&jamf.ScriptContents{Name: "TestScript", Contents: "echo hi", Priority: "Whenever"})
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "script validation failed: TestScript")
+
+	_, _, err = j.UpdateScript(33, &jamf.ScriptContents{Requirements: "Big Sur"})
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "Big Sur is not a valid script OS requirement")
+}
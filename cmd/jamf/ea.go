@@ -0,0 +1,111 @@
+// Unless explicitly stated otherwise all files in this repository are licensed under the Apache-2.0
+// This product includes software developed at Datadog (https://www.datadoghq.com/). Copyright 2020 Datadog, Inc.
+
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/trustero/jamf-api-client-go/classic/computerextensionattributes"
+	"github.com/trustero/jamf-api-client-go/classic/computerextensionattributes/eascript"
+)
+
+var eaCommands = map[string]*command{
+	"check": {
+		summary: "statically check and optionally run extension attribute scripts",
+		run:     eaCheck,
+	},
+}
+
+// eaCheck checks every extension attribute script given. Directories are read using the
+// sidecar YAML layout used by SyncScripts while single scripts take their settings from flags
+func eaCheck(args []string, stdout, stderr io.Writer) int {
+	fs := flag.NewFlagSet("ea check", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	runScripts := fs.Bool("run", false, "run each script locally and parse its result")
+	timeout := fs.Duration("timeout", eascript.DefaultTimeout, "maximum time each script may run")
+	dataType := fs.String("data-type", "String", "data type of single scripts [ String, Integer, Date ]")
+	platform := fs.String("platform", "Mac", "platform of single scripts [ Mac, Windows ]")
+	fs.Usage = func() {
+		fmt.Fprintf(stderr, "usage: jamf ea check [flags] <script or directory>...\n")
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		return exitUsage
+	}
+	if fs.NArg() == 0 {
+		fs.Usage()
+		return exitUsage
+	}
+
+	var attrs []*computerextensionattributes.ComputerExtensionAttribute
+	for _, path := range fs.Args() {
+		loaded, err := loadEAScripts(path, *dataType, *platform)
+		if err != nil {
+			fmt.Fprintf(stderr, "FAIL %s: %s\n", path, err.Error())
+			return exitFailure
+		}
+		attrs = append(attrs, loaded...)
+	}
+
+	failed := 0
+	for _, attr := range attrs {
+		if err := eascript.Check(attr); err != nil {
+			failed++
+			fmt.Fprintf(stdout, "FAIL %s: %s\n", attr.Name, err.Error())
+			continue
+		}
+
+		if !*runScripts {
+			fmt.Fprintf(stdout, "ok   %s\n", attr.Name)
+			continue
+		}
+
+		res, err := eascript.Run(context.Background(), attr, &eascript.RunOptions{Timeout: *timeout})
+		if err != nil {
+			failed++
+			fmt.Fprintf(stdout, "FAIL %s: %s\n", attr.Name, err.Error())
+			continue
+		}
+		fmt.Fprintf(stdout, "ok   %s result=%q (%s)\n", attr.Name, res.Raw, res.Duration.Round(time.Millisecond))
+	}
+
+	if failed > 0 {
+		fmt.Fprintf(stdout, "%d of %d extension attribute script(s) failed\n", failed, len(attrs))
+		return exitFailure
+	}
+	return exitOK
+}
+
+func loadEAScripts(path string, dataType string, platform string) ([]*computerextensionattributes.ComputerExtensionAttribute, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+
+	if info.IsDir() {
+		return computerextensionattributes.LoadScriptDirectory(path)
+	}
+
+	script, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	return []*computerextensionattributes.ComputerExtensionAttribute{{
+		Name:     strings.TrimSuffix(filepath.Base(path), filepath.Ext(path)),
+		DataType: dataType,
+		InputType: &computerextensionattributes.ComputerExtensionAttrInputType{
+			Type:     computerextensionattributes.ScriptInputType,
+			Platform: platform,
+			Script:   string(script),
+		},
+	}}, nil
+}
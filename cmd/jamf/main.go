@@ -0,0 +1,93 @@
+// Unless explicitly stated otherwise all files in this repository are licensed under the Apache-2.0
+// This product includes software developed at Datadog (https://www.datadoghq.com/). Copyright 2020 Datadog, Inc.
+
+// Command jamf provides tooling built on the Jamf API client for use in CI
+//
+// Usage:
+//
+//	jamf <group> <command> [flags] [args]
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"sort"
+)
+
+// Exit codes returned by every command
+const (
+	exitOK      = 0
+	exitFailure = 1
+	exitUsage   = 2
+)
+
+// Environment variables holding the Jamf credentials used by commands which call the API
+const (
+	envDomain   = "JAMF_DOMAIN"
+	envUsername = "JAMF_USERNAME"
+	envPassword = "JAMF_PASSWORD"
+)
+
+// command is a single runnable subcommand
+type command struct {
+	summary string
+	run     func(args []string, stdout, stderr io.Writer) int
+}
+
+// groups holds every command keyed by group and then command name
+var groups = map[string]map[string]*command{
+	"ea":      eaCommands,
+	"scripts": scriptsCommands,
+}
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdout, os.Stderr))
+}
+
+func run(args []string, stdout, stderr io.Writer) int {
+	if len(args) < 1 {
+		usage(stderr)
+		return exitUsage
+	}
+
+	group, ok := groups[args[0]]
+	if !ok || len(args) < 2 {
+		usage(stderr)
+		return exitUsage
+	}
+
+	cmd, ok := group[args[1]]
+	if !ok {
+		usage(stderr)
+		return exitUsage
+	}
+	return cmd.run(args[2:], stdout, stderr)
+}
+
+func usage(w io.Writer) {
+	fmt.Fprintf(w, "usage: jamf <group> <command> [flags] [args]\n\ncommands:\n")
+	for _, g := range sortedKeys(groups) {
+		for _, c := range sortedKeys(groups[g]) {
+			fmt.Fprintf(w, "  %-20s %s\n", g+" "+c, groups[g][c].summary)
+		}
+	}
+}
+
+// credentials returns the Jamf domain, username and password set in the environment
+func credentials() (domain string, username string, password string, err error) {
+	domain, username, password = os.Getenv(envDomain), os.Getenv(envUsername), os.Getenv(envPassword)
+	if domain == "" || username == "" || password == "" {
+		err = fmt.Errorf("%s, %s and %s must be set", envDomain, envUsername, envPassword)
+	}
+	return
+}
+
+func sortedKeys[T any](m map[string]T) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,80 @@
+// Unless explicitly stated otherwise all files in this repository are licensed under the Apache-2.0
+// This product includes software developed at Datadog (https://www.datadoghq.com/). Copyright 2020 Datadog, Inc.
+
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRunUsage(t *testing.T) {
+	stdout, stderr := &bytes.Buffer{}, &bytes.Buffer{}
+	assert.Equal(t, exitUsage, run(nil, stdout, stderr))
+	assert.Contains(t, stderr.String(), "ea check")
+
+	assert.Equal(t, exitUsage, run([]string{"ea", "unknown"}, stdout, stderr))
+	assert.Equal(t, exitUsage, run([]string{"ea", "check"}, stdout, stderr))
+}
+
+func TestEACheck(t *testing.T) {
+	dir := t.TempDir()
+	good := filepath.Join(dir, "good.sh")
+	bad := filepath.Join(dir, "bad.sh")
+	assert.Nil(t, os.WriteFile(good, []byte("#!/bin/sh\necho \"<result>7</result>\"\n"), 0o644))
+	assert.Nil(t, os.WriteFile(bad, []byte("echo 7\n"), 0o644))
+
+	stdout, stderr := &bytes.Buffer{}, &bytes.Buffer{}
+	assert.Equal(t, exitOK, run([]string{"ea", "check", good}, stdout, stderr))
+	assert.Contains(t, stdout.String(), "ok   good")
+
+	stdout.Reset()
+	assert.Equal(t, exitFailure, run([]string{"ea", "check", good, bad}, stdout, stderr))
+	assert.Contains(t, stdout.String(), "FAIL bad: extension attribute script check failed with 2 error(s)")
+	assert.Contains(t, stdout.String(), "1 of 2 extension attribute script(s) failed")
+
+	if runtime.GOOS == "windows" {
+		return
+	}
+	stdout.Reset()
+	assert.Equal(t, exitOK, run([]string{"ea", "check", "-run", "-data-type", "Integer", good}, stdout, stderr))
+	assert.Contains(t, stdout.String(), "ok   good result=\"7\"")
+}
+
+func TestScriptsPlan(t *testing.T) {
+	testServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.RequestURI {
+		case "/JSSResource/scripts":
+			fmt.Fprint(w, `{"scripts": [{"id": 1, "name": "Cleanup"}]}`)
+		case "/JSSResource/scripts/id/1":
+			fmt.Fprint(w, `{"script": {"id": 1, "name": "Cleanup", "priority": "After", "script_contents": "#!/bin/sh\nrm -rf /tmp/old\n"}}`)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer testServer.Close()
+
+	dir := t.TempDir()
+	assert.Nil(t, os.WriteFile(filepath.Join(dir, "Cleanup.sh"), []byte("#!/bin/sh\nrm -rf /tmp/new\n"), 0o644))
+
+	stdout, stderr := &bytes.Buffer{}, &bytes.Buffer{}
+	t.Setenv("JAMF_DOMAIN", "")
+	assert.Equal(t, exitUsage, run([]string{"scripts", "plan", dir}, stdout, stderr))
+	assert.Contains(t, stderr.String(), "JAMF_DOMAIN, JAMF_USERNAME and JAMF_PASSWORD must be set")
+
+	t.Setenv("JAMF_DOMAIN", testServer.URL)
+	t.Setenv("JAMF_USERNAME", "fake-username")
+	t.Setenv("JAMF_PASSWORD", "mock-password-cool")
+	assert.Equal(t, exitOK, run([]string{"scripts", "plan", dir}, stdout, stderr))
+	assert.Contains(t, stdout.String(), "update script \"Cleanup\" (content)")
+
+	assert.Equal(t, exitFailure, run([]string{"scripts", "plan", "-fail-on-drift", dir}, stdout, stderr))
+}
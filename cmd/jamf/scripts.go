@@ -0,0 +1,133 @@
+// Unless explicitly stated otherwise all files in this repository are licensed under the Apache-2.0
+// This product includes software developed at Datadog (https://www.datadoghq.com/). Copyright 2020 Datadog, Inc.
+
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+
+	"github.com/trustero/jamf-api-client-go/classic/scripts"
+)
+
+var scriptsCommands = map[string]*command{
+	"plan": {
+		summary: "show how Jamf scripts drift from a directory of script files",
+		run:     scriptsPlan,
+	},
+	"apply": {
+		summary: "update Jamf scripts to match a directory of script files",
+		run:     scriptsApply,
+	},
+	"export": {
+		summary: "write every Jamf script to a directory of script files",
+		run:     scriptsExport,
+	},
+}
+
+// scriptsPlan prints the sync plan for a directory. With -fail-on-drift it exits with
+// exitFailure when Jamf has drifted from the directory so it can gate CI
+func scriptsPlan(args []string, stdout, stderr io.Writer) int {
+	fs := scriptsFlagSet("plan", stderr)
+	prune := fs.Bool("prune", false, "plan to delete scripts in Jamf which have no file")
+	failOnDrift := fs.Bool("fail-on-drift", false, "exit with status 1 when any drift is found")
+	dir, ok := parseScriptsArgs(fs, args)
+	if !ok {
+		return exitUsage
+	}
+
+	svc, err := scriptsService()
+	if err != nil {
+		fmt.Fprintf(stderr, "%s\n", err.Error())
+		return exitUsage
+	}
+
+	plan, err := svc.PlanScriptSync(dir, &scripts.SyncOptions{Prune: *prune})
+	if err != nil {
+		fmt.Fprintf(stderr, "%s\n", err.Error())
+		return exitFailure
+	}
+
+	fmt.Fprint(stdout, plan.String())
+	if *failOnDrift && plan.HasDrift() {
+		return exitFailure
+	}
+	return exitOK
+}
+
+func scriptsApply(args []string, stdout, stderr io.Writer) int {
+	fs := scriptsFlagSet("apply", stderr)
+	prune := fs.Bool("prune", false, "delete scripts in Jamf which have no file")
+	dir, ok := parseScriptsArgs(fs, args)
+	if !ok {
+		return exitUsage
+	}
+
+	svc, err := scriptsService()
+	if err != nil {
+		fmt.Fprintf(stderr, "%s\n", err.Error())
+		return exitUsage
+	}
+
+	if _, err := svc.SyncScripts(dir, &scripts.SyncOptions{Prune: *prune, Output: stdout}); err != nil {
+		fmt.Fprintf(stderr, "%s\n", err.Error())
+		return exitFailure
+	}
+	return exitOK
+}
+
+func scriptsExport(args []string, stdout, stderr io.Writer) int {
+	fs := scriptsFlagSet("export", stderr)
+	dir, ok := parseScriptsArgs(fs, args)
+	if !ok {
+		return exitUsage
+	}
+
+	svc, err := scriptsService()
+	if err != nil {
+		fmt.Fprintf(stderr, "%s\n", err.Error())
+		return exitUsage
+	}
+
+	written, err := svc.ExportScripts(dir)
+	for _, path := range written {
+		fmt.Fprintf(stdout, "wrote %s\n", path)
+	}
+	if err != nil {
+		fmt.Fprintf(stderr, "%s\n", err.Error())
+		return exitFailure
+	}
+	return exitOK
+}
+
+func scriptsFlagSet(name string, stderr io.Writer) *flag.FlagSet {
+	fs := flag.NewFlagSet("scripts "+name, flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	fs.Usage = func() {
+		fmt.Fprintf(stderr, "usage: jamf scripts %s [flags] <directory>\n\n", name)
+		fmt.Fprintf(stderr, "credentials are read from %s, %s and %s\n", envDomain, envUsername, envPassword)
+		fs.PrintDefaults()
+	}
+	return fs
+}
+
+func parseScriptsArgs(fs *flag.FlagSet, args []string) (string, bool) {
+	if err := fs.Parse(args); err != nil {
+		return "", false
+	}
+	if fs.NArg() != 1 {
+		fs.Usage()
+		return "", false
+	}
+	return fs.Arg(0), true
+}
+
+// scriptsService builds a scripts service from the credentials in the environment
+func scriptsService() (*scripts.Service, error) {
+	domain, username, password, err := credentials()
+	if err != nil {
+		return nil, err
+	}
+	return scripts.NewService(domain, username, password, nil)
+}
@@ -0,0 +1,202 @@
+// Unless explicitly stated otherwise all files in this repository are licensed under the Apache-2.0
+// This product includes software developed at Datadog (https://www.datadoghq.com/). Copyright 2020 Datadog, Inc.
+
+// Package filesync holds the plan, apply, diff and file naming code shared by the services
+// which keep objects in Jamf in sync with a directory of files
+package filesync
+
+import (
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/pmezard/go-difflib/difflib"
+)
+
+var unsafeFileChars = regexp.MustCompile(`[^A-Za-z0-9._-]+`)
+
+// Action describes what a sync will do to a single object
+type Action string
+
+// Actions a sync plan can take
+const (
+	Create    Action = "create"
+	Update    Action = "update"
+	Rename    Action = "rename"
+	Delete    Action = "delete"
+	Orphan    Action = "orphan"
+	Unchanged Action = "unchanged"
+)
+
+// Options holds the settings used when syncing a directory with Jamf
+type Options struct {
+	// Prune deletes objects in Jamf that have no file on disk, without it they are reported as orphans
+	Prune bool
+	// DryRun stops after the plan has been built and written to Output
+	DryRun bool
+	// Output receives the plan before anything is applied, nothing is written when nil
+	Output io.Writer
+}
+
+// Change holds the parts of a planned change shared by every kind of object synced
+type Change struct {
+	Action Action
+	// Name is the object name on disk or in Jamf for orphans and deletes
+	Name string
+	// Previous is the name in Jamf of a renamed object
+	Previous string
+	ID       int
+	// Drift lists what differs between disk and Jamf
+	Drift []string
+	// Diff is a unified diff of the managed settings and script body between Jamf and disk
+	Diff string
+	Err  error
+}
+
+// Base returns the shared parts of the change
+func (c *Change) Base() *Change {
+	return c
+}
+
+// Changer is implemented by the change types of each service by embedding Change
+type Changer interface {
+	Base() *Change
+}
+
+// Plan holds every change needed to bring Jamf in line with a directory
+type Plan[C Changer] struct {
+	Changes []C
+	kind    string
+}
+
+// NewPlan returns an empty plan, kind names the objects synced in messages i.e script
+func NewPlan[C Changer](kind string) *Plan[C] {
+	return &Plan[C]{kind: kind}
+}
+
+// Pending returns the changes that will modify Jamf when the plan is applied
+func (p *Plan[C]) Pending() []C {
+	var pending []C
+	for _, c := range p.Changes {
+		if a := c.Base().Action; a != Unchanged && a != Orphan {
+			pending = append(pending, c)
+		}
+	}
+	return pending
+}
+
+// HasDrift reports whether Jamf differs from the directory in any way, including orphans
+func (p *Plan[C]) HasDrift() bool {
+	for _, c := range p.Changes {
+		if c.Base().Action != Unchanged {
+			return true
+		}
+	}
+	return false
+}
+
+// String renders the plan as a list of actions each followed by its diff
+func (p *Plan[C]) String() string {
+	var b strings.Builder
+	for _, change := range p.Changes {
+		c := change.Base()
+		switch c.Action {
+		case Unchanged:
+			continue
+		case Rename:
+			fmt.Fprintf(&b, "rename %s %q to %q\n", p.kind, c.Previous, c.Name)
+		case Orphan:
+			fmt.Fprintf(&b, "orphan %s %q (Id %d) has no file on disk\n", p.kind, c.Name, c.ID)
+		default:
+			fmt.Fprintf(&b, "%s %s %q", c.Action, p.kind, c.Name)
+			if len(c.Drift) > 0 {
+				fmt.Fprintf(&b, " (%s)", strings.Join(c.Drift, ", "))
+			}
+			b.WriteString("\n")
+		}
+		b.WriteString(c.Diff)
+	}
+	if b.Len() == 0 {
+		return "no changes\n"
+	}
+	return b.String()
+}
+
+// Apply calls apply for every pending change. All changes are attempted and the outcome of
+// each is recorded on the change, an error is returned if any change failed
+func (p *Plan[C]) Apply(apply func(c C) error) error {
+	failed := 0
+	for _, c := range p.Pending() {
+		if c.Base().Err = apply(c); c.Base().Err != nil {
+			failed++
+		}
+	}
+
+	if failed > 0 {
+		var msgs []string
+		for _, change := range p.Changes {
+			if c := change.Base(); c.Err != nil {
+				msgs = append(msgs, fmt.Sprintf("%s %q: %s", c.Action, c.Name, c.Err.Error()))
+			}
+		}
+		return fmt.Errorf("%d %s change(s) failed: %s", failed, p.kind, strings.Join(msgs, "; "))
+	}
+	return nil
+}
+
+// Run writes the plan to the Output provided and applies it unless DryRun is set
+func (p *Plan[C]) Run(opts *Options, apply func(c C) error) error {
+	if opts == nil {
+		opts = &Options{}
+	}
+
+	if opts.Output != nil {
+		if _, err := io.WriteString(opts.Output, p.String()); err != nil {
+			return errors.Wrapf(err, "unable to write %s sync plan", p.kind)
+		}
+	}
+
+	if opts.DryRun {
+		return nil
+	}
+	return p.Apply(apply)
+}
+
+// FileName returns the file name without extension used to store an object on disk
+func FileName(name string) string {
+	return strings.Trim(unsafeFileChars.ReplaceAllString(name, "_"), "_")
+}
+
+// Normalize converts Windows line endings and ensures a single trailing newline so scripts
+// edited on disk compare equal to the copy stored in Jamf
+func Normalize(body string) string {
+	body = strings.ReplaceAll(body, "\r\n", "\n")
+	return strings.TrimRight(body, "\n") + "\n"
+}
+
+// SplitLines splits text into lines keeping the line endings as expected by Diff
+func SplitLines(text string) []string {
+	return difflib.SplitLines(text)
+}
+
+// Diff returns a unified diff between the lines in Jamf and on disk or an empty string when
+// they match. Either side may be nil for a create or delete
+func Diff(jamf, disk []string) string {
+	if strings.Join(jamf, "") == strings.Join(disk, "") {
+		return ""
+	}
+
+	diff, err := difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+		A:        jamf,
+		B:        disk,
+		FromFile: "jamf",
+		ToFile:   "disk",
+		Context:  3,
+	})
+	if err != nil {
+		return ""
+	}
+	return diff
+}
@@ -0,0 +1,76 @@
+// Unless explicitly stated otherwise all files in this repository are licensed under the Apache-2.0
+// This product includes software developed at Datadog (https://www.datadoghq.com/). Copyright 2020 Datadog, Inc.
+
+package filesync_test
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/trustero/jamf-api-client-go/internal/filesync"
+)
+
+type change struct {
+	filesync.Change
+	applied bool
+}
+
+func testPlan() *filesync.Plan[*change] {
+	plan := filesync.NewPlan[*change]("script")
+	plan.Changes = []*change{
+		{Change: filesync.Change{Action: filesync.Unchanged, Name: "Same"}},
+		{Change: filesync.Change{Action: filesync.Update, Name: "Zoom", Drift: []string{"content"}, Diff: filesync.Diff([]string{"a\n"}, []string{"b\n"})}},
+		{Change: filesync.Change{Action: filesync.Rename, Name: "New Name", Previous: "Old Name", ID: 3}},
+		{Change: filesync.Change{Action: filesync.Orphan, Name: "Orphan", ID: 4}},
+	}
+	return plan
+}
+
+func TestPlan(t *testing.T) {
+	plan := testPlan()
+	assert.True(t, plan.HasDrift())
+	assert.Len(t, plan.Pending(), 2)
+	assert.Equal(t, "update script \"Zoom\" (content)\n--- jamf\n+++ disk\n@@ -1 +1 @@\n-a\n+b\n"+
+		"rename script \"Old Name\" to \"New Name\"\n"+
+		"orphan script \"Orphan\" (Id 4) has no file on disk\n", plan.String())
+
+	empty := filesync.NewPlan[*change]("script")
+	assert.False(t, empty.HasDrift())
+	assert.Equal(t, "no changes\n", empty.String())
+}
+
+func TestRun(t *testing.T) {
+	plan := testPlan()
+	out := &bytes.Buffer{}
+	err := plan.Run(&filesync.Options{DryRun: true, Output: out}, func(c *change) error {
+		c.applied = true
+		return nil
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, plan.String(), out.String())
+	for _, c := range plan.Changes {
+		assert.False(t, c.applied)
+	}
+
+	// every pending change is attempted even when one fails
+	err = plan.Run(nil, func(c *change) error {
+		c.applied = true
+		if c.Action == filesync.Rename {
+			return fmt.Errorf("name taken")
+		}
+		return nil
+	})
+	assert.NotNil(t, err)
+	assert.Equal(t, "1 script change(s) failed: rename \"New Name\": name taken", err.Error())
+	assert.True(t, plan.Changes[1].applied)
+	assert.True(t, plan.Changes[2].applied)
+	assert.False(t, plan.Changes[3].applied)
+}
+
+func TestFiles(t *testing.T) {
+	assert.Equal(t, "Zoom_Update_v2", filesync.FileName("  Zoom Update (v2)!"))
+	assert.Equal(t, "echo hi\n", filesync.Normalize("echo hi\r\n\r\n"))
+	assert.Equal(t, "", filesync.Diff([]string{"a\n"}, []string{"a\n"}))
+}
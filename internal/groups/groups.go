@@ -0,0 +1,222 @@
+// Unless explicitly stated otherwise all files in this repository are licensed under the Apache-2.0
+// This product includes software developed at Datadog (https://www.datadoghq.com/). Copyright 2020 Datadog, Inc.
+
+// Package groups holds the requests and validation shared by the computer and mobile device
+// group services, which only differ by the kind of members they hold
+package groups
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/trustero/jamf-api-client-go/classic/client"
+)
+
+// Fields are the parts of a group checked by Kind.Validate and Kind.ValidateUpdate
+type Fields struct {
+	Name string
+	// IsSmart is whether the group is smart, nil leaves it unchanged on update and creates a
+	// static group
+	IsSmart *bool
+	// Criteria is the number of criteria of the group
+	Criteria int
+	// Members is the number of members listed by the group
+	Members int
+	// ValidateCriteria validates the criteria of a smart group
+	ValidateCriteria func() error
+}
+
+// Kind describes a type of group G and its members
+type Kind[G any] struct {
+	// Group names the type of group in messages i.e computer group
+	Group string
+	// Members names the members of the group in messages i.e computers
+	Members string
+	// Fields returns the parts of a group checked before it is sent to Jamf
+	Fields func(g *G) Fields
+	// ClearMembers removes the members listed by a group
+	ClearMembers func(g *G)
+}
+
+// Validate will validate that a group about to be created is named, that static groups have no
+// criteria and that smart groups have valid criteria and do not list their members
+func (k *Kind[G]) Validate(g *G) error {
+	f := k.Fields(g)
+	if strings.TrimSpace(f.Name) == "" {
+		return fmt.Errorf("%s name is required", k.Group)
+	}
+
+	if isSmart(f) && f.Members > 0 {
+		return fmt.Errorf("smart %s %s can not list %s, membership is found using its criteria", k.Group, f.Name, k.Members)
+	}
+	return k.validate(g, false)
+}
+
+// ValidateUpdate will validate a group about to be updated and returns the group to send. The
+// name may be left empty to keep the name in Jamf and the members Jamf lists for smart groups
+// are dropped, so a group fetched from Jamf can be edited and sent back. IsSmart may be left
+// unset to keep the type of the group in Jamf, any criteria sent are then validated
+func (k *Kind[G]) ValidateUpdate(g *G) (*G, error) {
+	if f := k.Fields(g); isSmart(f) && f.Members > 0 {
+		update := *g
+		k.ClearMembers(&update)
+		g = &update
+	}
+	return g, k.validate(g, true)
+}
+
+// validate checks the criteria of a group, keep is whether an unset IsSmart keeps the type of
+// the group in Jamf rather than creating a static group
+func (k *Kind[G]) validate(g *G, keep bool) error {
+	f := k.Fields(g)
+	if f.IsSmart == nil && keep {
+		if f.Criteria > 0 {
+			return f.ValidateCriteria()
+		}
+		return nil
+	}
+
+	if !isSmart(f) {
+		if f.Criteria > 0 {
+			return fmt.Errorf("static %s %s can not have criteria", k.Group, f.Name)
+		}
+		return nil
+	}
+	return f.ValidateCriteria()
+}
+
+func isSmart(f Fields) bool {
+	return f.IsSmart != nil && *f.IsSmart
+}
+
+// Service sends the group requests of a domain client
+type Service[G any] struct {
+	client *client.Client
+	kind   *Kind[G]
+}
+
+// NewService returns a service sending requests for groups of the kind given
+func NewService[G any](c *client.Client, kind *Kind[G]) *Service[G] {
+	return &Service[G]{client: c, kind: kind}
+}
+
+// List decodes the groups available in Jamf into v
+func (s *Service[G]) List(ctx context.Context, v interface{}) (response *http.Response, err error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", s.client.Endpoint, nil)
+	if err != nil {
+		err = errors.Wrapf(err, "error building JAMF %ss query request", s.kind.Group)
+		return
+	}
+
+	if response, err = client.MakeAPIrequest(s.client, req, v); err != nil {
+		err = errors.Wrapf(err, "unable to query available %ss from %s", s.kind.Group, s.client.Endpoint)
+	}
+	return
+}
+
+// Details decodes the details of a group given its Id or Name into v
+func (s *Service[G]) Details(identifier interface{}, v interface{}) (response *http.Response, err error) {
+	ep, err := s.client.IdentifierEndpoint(identifier)
+	if err != nil {
+		err = errors.Wrapf(err, "error building JAMF query request endpoint for %s: %v", s.kind.Group, identifier)
+		return
+	}
+
+	req, err := http.NewRequestWithContext(context.Background(), "GET", ep, nil)
+	if err != nil {
+		err = errors.Wrapf(err, "error building JAMF query request for %s: %v", s.kind.Group, identifier)
+		return
+	}
+
+	if response, err = client.MakeAPIrequest(s.client, req, v); err != nil {
+		err = errors.Wrapf(err, "unable to query %s with identifier: %v from %s", s.kind.Group, identifier, ep)
+	}
+	return
+}
+
+// Create will validate and create a group in Jamf
+func (s *Service[G]) Create(group *G) (result *G, response *http.Response, err error) {
+	// -1 denotes the next available Id
+	ep := s.client.IdEndpoint(-1)
+
+	if group == nil {
+		err = errors.Wrapf(fmt.Errorf("Empty payload"), "unable to process JAMF creation request for %s: (%s)", s.kind.Group, ep)
+		return
+	}
+
+	name := s.kind.Fields(group).Name
+	if err = s.kind.Validate(group); err != nil {
+		err = errors.Wrapf(err, "%s validation failed: %v", s.kind.Group, name)
+		return
+	}
+
+	result = new(G)
+	if response, err = client.SendXML(s.client, "POST", ep, group, result); err != nil {
+		err = errors.Wrapf(err, "unable to process JAMF creation request for %s: %v (%s)", s.kind.Group, name, ep)
+	}
+	return
+}
+
+// Update will validate and update a group in Jamf by either Id or Name, see Kind.ValidateUpdate
+func (s *Service[G]) Update(identifier interface{}, group *G) (result *G, response *http.Response, err error) {
+	ep, err := s.client.IdentifierEndpoint(identifier)
+	if err != nil {
+		err = errors.Wrapf(err, "error building JAMF query request for %s: %v", s.kind.Group, identifier)
+		return
+	}
+
+	if group == nil {
+		err = errors.Wrapf(fmt.Errorf("Empty payload"), "unable to process JAMF update request for %s: %v (%s)", s.kind.Group, identifier, ep)
+		return
+	}
+
+	if group, err = s.kind.ValidateUpdate(group); err != nil {
+		err = errors.Wrapf(err, "%s validation failed: %v", s.kind.Group, identifier)
+		return
+	}
+
+	result = new(G)
+	if response, err = client.SendXML(s.client, "PUT", ep, group, result); err != nil {
+		err = errors.Wrapf(err, "unable to process JAMF update request for %s: %v (%s)", s.kind.Group, identifier, ep)
+	}
+	return
+}
+
+// Delete will delete a group by either Id or Name
+func (s *Service[G]) Delete(identifier interface{}) (result *G, response *http.Response, err error) {
+	ep, err := s.client.IdentifierEndpoint(identifier)
+	if err != nil {
+		err = errors.Wrapf(err, "error building JAMF query request for %s: %v", s.kind.Group, identifier)
+		return
+	}
+
+	result = new(G)
+	if response, err = client.SendXML(s.client, "DELETE", ep, nil, result); err != nil {
+		err = errors.Wrapf(err, "unable to process JAMF deletion request for %s: %v (%s)", s.kind.Group, identifier, ep)
+	}
+	return
+}
+
+// EditMembership sends the additions and deletions of a static group, members is the number
+// of members added or removed by the update
+func (s *Service[G]) EditMembership(identifier interface{}, update interface{}, members int) (result *G, response *http.Response, err error) {
+	ep, err := s.client.IdentifierEndpoint(identifier)
+	if err != nil {
+		err = errors.Wrapf(err, "error building JAMF query request for %s: %v", s.kind.Group, identifier)
+		return
+	}
+
+	if members == 0 {
+		err = errors.Wrapf(fmt.Errorf("No %s provided", s.kind.Members), "unable to process JAMF membership request for %s: %v (%s)", s.kind.Group, identifier, ep)
+		return
+	}
+
+	result = new(G)
+	if response, err = client.SendXML(s.client, "PUT", ep, update, result); err != nil {
+		err = errors.Wrapf(err, "unable to process JAMF membership request for %s: %v (%s)", s.kind.Group, identifier, ep)
+	}
+	return
+}
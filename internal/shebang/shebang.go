@@ -0,0 +1,90 @@
+// Unless explicitly stated otherwise all files in this repository are licensed under the Apache-2.0
+// This product includes software developed at Datadog (https://www.datadoghq.com/). Copyright 2020 Datadog, Inc.
+
+// Package shebang reads the interpreter line at the top of scripts stored in Jamf
+package shebang
+
+import (
+	"path"
+	"sort"
+	"strings"
+)
+
+// extensions maps an interpreter to the file extension used when a script is written to disk
+var extensions = map[string]string{
+	"perl": ".pl",
+	"ruby": ".rb",
+	"zsh":  ".zsh",
+}
+
+// Extensions returns every file extension Extension can return so directories written using
+// it can be read back
+func Extensions() []string {
+	exts := []string{".sh", ".py"}
+	for _, ext := range extensions {
+		exts = append(exts, ext)
+	}
+	sort.Strings(exts[2:])
+	return exts
+}
+
+// Shebang is the interpreter line of a script i.e #!/usr/bin/env python3
+type Shebang struct {
+	// Path is the interpreter as written i.e /usr/bin/env
+	Path string
+	Args []string
+}
+
+// Parse reads the shebang on the first line of a script body
+func Parse(body string) (*Shebang, bool) {
+	firstLine := strings.SplitN(strings.ReplaceAll(body, "\r\n", "\n"), "\n", 2)[0]
+	if !strings.HasPrefix(firstLine, "#!") {
+		return nil, false
+	}
+
+	fields := strings.Fields(strings.TrimPrefix(firstLine, "#!"))
+	if len(fields) == 0 {
+		return nil, false
+	}
+	return &Shebang{Path: fields[0], Args: fields[1:]}, true
+}
+
+// String returns the interpreter and its arguments as written after #!
+func (s *Shebang) String() string {
+	return strings.Join(append([]string{s.Path}, s.Args...), " ")
+}
+
+// Interpreter returns the name of the program running the script i.e bash for #!/bin/bash
+// or python3 for #!/usr/bin/env python3
+func (s *Shebang) Interpreter() string {
+	interpreter := path.Base(s.Path)
+	if interpreter != "env" {
+		return interpreter
+	}
+
+	// skip env and any of its flags to find the program it runs
+	for _, arg := range s.Args {
+		if !strings.HasPrefix(arg, "-") {
+			return path.Base(arg)
+		}
+	}
+	return ""
+}
+
+// Extension returns the file extension for a script body based on its interpreter, scripts
+// without a known interpreter are stored as .sh
+func Extension(body string) string {
+	s, ok := Parse(body)
+	if !ok {
+		return ".sh"
+	}
+
+	interpreter := s.Interpreter()
+	if strings.HasPrefix(interpreter, "python") {
+		return ".py"
+	}
+	if ext, ok := extensions[interpreter]; ok {
+		return ext
+	}
+	return ".sh"
+}
@@ -0,0 +1,46 @@
+// Unless explicitly stated otherwise all files in this repository are licensed under the Apache-2.0
+// This product includes software developed at Datadog (https://www.datadoghq.com/). Copyright 2020 Datadog, Inc.
+
+package shebang_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/trustero/jamf-api-client-go/internal/shebang"
+)
+
+func TestParse(t *testing.T) {
+	s, ok := shebang.Parse("#! /usr/bin/env  -S python3 -u\r\nprint('hi')")
+	assert.True(t, ok)
+	assert.Equal(t, "/usr/bin/env", s.Path)
+	assert.Equal(t, "/usr/bin/env -S python3 -u", s.String())
+	assert.Equal(t, "python3", s.Interpreter())
+
+	for _, body := range []string{"echo hi", "#!\necho hi", ""} {
+		_, ok = shebang.Parse(body)
+		assert.False(t, ok)
+	}
+}
+
+func TestExtension(t *testing.T) {
+	for body, expected := range map[string]string{
+		"#!/bin/bash\necho hi":                    ".sh",
+		"#!/bin/zsh -f\necho hi":                  ".zsh",
+		"#!/usr/bin/env python3\nprint('hi')":     ".py",
+		"#!/Library/Python/3.11/bin/python3.11\n": ".py",
+		"#!/usr/bin/perl -w\nprint 'hi'":          ".pl",
+		"#!/usr/bin/env ruby\nputs 'hi'":          ".rb",
+		"echo no shebang":                         ".sh",
+	} {
+		assert.Equal(t, expected, shebang.Extension(body), body)
+	}
+}
+
+func TestExtensions(t *testing.T) {
+	exts := shebang.Extensions()
+	assert.Equal(t, []string{".sh", ".py", ".pl", ".rb", ".zsh"}, exts)
+	for _, body := range []string{"#!/bin/zsh\n", "#!/usr/bin/perl\n", "#!/usr/bin/env ruby\n", "#!/usr/bin/python3\n", "echo hi"} {
+		assert.Contains(t, exts, shebang.Extension(body), body)
+	}
+}
@@ -0,0 +1,148 @@
+// Unless explicitly stated otherwise all files in this repository are licensed under the Apache-2.0
+// This product includes software developed at Datadog (https://www.datadoghq.com/). Copyright 2020 Datadog, Inc.
+
+// Package pager provides a single iterator over Jamf list endpoints so callers do not need to
+// know whether the endpoint returns everything at once (Classic API) or pages (Jamf Pro API)
+package pager
+
+import "context"
+
+// UnknownTotal is returned by a PageFunc when the server does not report a total count
+const UnknownTotal = -1
+
+// Iterator is implemented by every list result that can be walked one item at a time
+type Iterator[T any] interface {
+	// Next advances to the next item returning false once all items have been read,
+	// the iterator was stopped or an error occurred
+	Next(ctx context.Context) bool
+	// Value returns the current item
+	Value() T
+	// Err returns the first error encountered while iterating
+	Err() error
+	// TotalCount returns the total number of items and whether the server reported it
+	TotalCount() (int, bool)
+	// All reads every remaining item
+	All(ctx context.Context) ([]T, error)
+	// Stop ends the iteration early, subsequent calls to Next return false
+	Stop()
+}
+
+// PageFunc fetches the page at the given index. It returns the items on the page, the
+// total number of items across all pages (or UnknownTotal) and whether more pages remain
+type PageFunc[T any] func(ctx context.Context, page int) (items []T, total int, more bool, err error)
+
+// Pager walks the pages returned by a PageFunc requesting each page only
+// when the previous one is exhausted
+type Pager[T any] struct {
+	fetch   PageFunc[T]
+	page    int
+	items   []T
+	index   int
+	total   int
+	more    bool
+	started bool
+	stopped bool
+	err     error
+}
+
+// New returns a pager that fetches pages from the PageFunc starting at page 0
+func New[T any](fetch PageFunc[T]) *Pager[T] {
+	return NewFrom(fetch, 0)
+}
+
+// NewFrom returns a pager that fetches pages from the PageFunc starting at the page provided
+func NewFrom[T any](fetch PageFunc[T], page int) *Pager[T] {
+	return &Pager[T]{
+		fetch: fetch,
+		page:  page,
+		index: -1,
+		total: UnknownTotal,
+		more:  true,
+	}
+}
+
+// Single returns a pager for endpoints that return every item in a single response
+func Single[T any](fetch func(ctx context.Context) ([]T, error)) *Pager[T] {
+	return New(func(ctx context.Context, page int) ([]T, int, bool, error) {
+		items, err := fetch(ctx)
+		if err != nil {
+			return nil, UnknownTotal, false, err
+		}
+		return items, len(items), false, nil
+	})
+}
+
+// Next advances to the next item fetching the next page when the current one is exhausted
+func (p *Pager[T]) Next(ctx context.Context) bool {
+	if p.stopped || p.err != nil {
+		return false
+	}
+
+	if p.index+1 < len(p.items) {
+		p.index++
+		return true
+	}
+
+	// keep requesting pages until one has items or the server reports there are no more
+	for p.more {
+		if err := ctx.Err(); err != nil {
+			p.err = err
+			return false
+		}
+
+		if p.started {
+			p.page++
+		}
+		p.started = true
+
+		items, total, more, err := p.fetch(ctx, p.page)
+		if err != nil {
+			p.err = err
+			return false
+		}
+
+		p.items = items
+		p.index = -1
+		p.total = total
+		p.more = more && len(items) > 0
+		if len(items) > 0 {
+			p.index = 0
+			return true
+		}
+	}
+	return false
+}
+
+// Value returns the current item or the zero value if there is none
+func (p *Pager[T]) Value() T {
+	var zero T
+	if p.index < 0 || p.index >= len(p.items) {
+		return zero
+	}
+	return p.items[p.index]
+}
+
+// Err returns the first error encountered while iterating
+func (p *Pager[T]) Err() error {
+	return p.err
+}
+
+// TotalCount returns the total number of items once the first page has been read
+// and whether the server reported it
+func (p *Pager[T]) TotalCount() (int, bool) {
+	return p.total, p.total != UnknownTotal
+}
+
+// All reads every remaining item
+func (p *Pager[T]) All(ctx context.Context) ([]T, error) {
+	var all []T
+	for p.Next(ctx) {
+		all = append(all, p.Value())
+	}
+	return all, p.Err()
+}
+
+// Stop ends the iteration early, subsequent calls to Next return false
+func (p *Pager[T]) Stop() {
+	p.stopped = true
+}
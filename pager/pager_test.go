@@ -0,0 +1,106 @@
+// Unless explicitly stated otherwise all files in this repository are licensed under the Apache-2.0
+// This product includes software developed at Datadog (https://www.datadoghq.com/). Copyright 2020 Datadog, Inc.
+
+package pager_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/trustero/jamf-api-client-go/pager"
+)
+
+func mockPages(requested *[]int) pager.PageFunc[int] {
+	pages := [][]int{{1, 2}, {3, 4}, {5}}
+	return func(ctx context.Context, page int) ([]int, int, bool, error) {
+		*requested = append(*requested, page)
+		return pages[page], 5, page < len(pages)-1, nil
+	}
+}
+
+func TestPagerAll(t *testing.T) {
+	requested := []int{}
+	p := pager.New(mockPages(&requested))
+
+	_, ok := p.TotalCount()
+	assert.False(t, ok)
+
+	all, err := p.All(context.Background())
+	assert.Nil(t, err)
+	assert.Equal(t, []int{1, 2, 3, 4, 5}, all)
+	assert.Equal(t, []int{0, 1, 2}, requested)
+
+	total, ok := p.TotalCount()
+	assert.True(t, ok)
+	assert.Equal(t, 5, total)
+	assert.False(t, p.Next(context.Background()))
+}
+
+func TestPagerStop(t *testing.T) {
+	requested := []int{}
+	var p pager.Iterator[int] = pager.New(mockPages(&requested))
+
+	values := []int{}
+	for p.Next(context.Background()) {
+		values = append(values, p.Value())
+		if p.Value() == 3 {
+			p.Stop()
+		}
+	}
+	assert.Nil(t, p.Err())
+	assert.Equal(t, []int{1, 2, 3}, values)
+	// pages are only requested as they are needed
+	assert.Equal(t, []int{0, 1}, requested)
+}
+
+func TestPagerNewFrom(t *testing.T) {
+	requested := []int{}
+	all, err := pager.NewFrom(mockPages(&requested), 1).All(context.Background())
+	assert.Nil(t, err)
+	assert.Equal(t, []int{3, 4, 5}, all)
+	assert.Equal(t, []int{1, 2}, requested)
+}
+
+func TestPagerError(t *testing.T) {
+	p := pager.New(func(ctx context.Context, page int) ([]string, int, bool, error) {
+		if page == 1 {
+			return nil, pager.UnknownTotal, false, errors.New("request error: boom")
+		}
+		return []string{"a"}, pager.UnknownTotal, true, nil
+	})
+
+	all, err := p.All(context.Background())
+	assert.NotNil(t, err)
+	assert.Equal(t, "request error: boom", err.Error())
+	assert.Equal(t, []string{"a"}, all)
+}
+
+func TestPagerCancelledContext(t *testing.T) {
+	requested := []int{}
+	p := pager.New(mockPages(&requested))
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	assert.False(t, p.Next(ctx))
+	assert.Equal(t, context.Canceled, p.Err())
+	assert.Empty(t, requested)
+}
+
+func TestSingle(t *testing.T) {
+	calls := 0
+	p := pager.Single(func(ctx context.Context) ([]string, error) {
+		calls++
+		return []string{"a", "b"}, nil
+	})
+
+	all, err := p.All(context.Background())
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"a", "b"}, all)
+	assert.Equal(t, 1, calls)
+
+	total, ok := p.TotalCount()
+	assert.True(t, ok)
+	assert.Equal(t, 2, total)
+}
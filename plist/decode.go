@@ -0,0 +1,180 @@
+// Unless explicitly stated otherwise all files in this repository are licensed under the Apache-2.0
+// This product includes software developed at Datadog (https://www.datadoghq.com/). Copyright 2020 Datadog, Inc.
+
+package plist
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DateLayout is the layout of property list dates
+const DateLayout = "2006-01-02T15:04:05Z"
+
+// Decode reads the value held by an XML property list
+func Decode(data []byte) (interface{}, error) {
+	d := xml.NewDecoder(bytes.NewReader(data))
+	for {
+		token, err := d.Token()
+		if err == io.EOF {
+			return nil, fmt.Errorf("property list holds no value")
+		}
+		if err != nil {
+			return nil, fmt.Errorf("unable to read property list: %s", err.Error())
+		}
+
+		if start, ok := token.(xml.StartElement); ok {
+			if start.Name.Local == "plist" {
+				continue
+			}
+			return decodeValue(d, start)
+		}
+	}
+}
+
+// DecodeDict reads an XML property list holding a dictionary such as a mobileconfig
+func DecodeDict(data []byte) (*Dict, error) {
+	v, err := Decode(data)
+	if err != nil {
+		return nil, err
+	}
+	dict, ok := v.(*Dict)
+	if !ok {
+		return nil, fmt.Errorf("property list holds a value of type (%T) instead of a dictionary", v)
+	}
+	return dict, nil
+}
+
+func decodeValue(d *xml.Decoder, start xml.StartElement) (interface{}, error) {
+	switch start.Name.Local {
+	case "dict":
+		return decodeDict(d)
+	case "array":
+		return decodeArray(d)
+	case "true", "false":
+		if err := d.Skip(); err != nil {
+			return nil, err
+		}
+		return start.Name.Local == "true", nil
+	}
+
+	text, err := readText(d, start)
+	if err != nil {
+		return nil, err
+	}
+
+	switch start.Name.Local {
+	case "string":
+		return text, nil
+	case "integer":
+		i, err := strconv.ParseInt(strings.TrimSpace(text), 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("%s is not a valid property list integer", text)
+		}
+		return i, nil
+	case "real":
+		f, err := strconv.ParseFloat(strings.TrimSpace(text), 64)
+		if err != nil {
+			return nil, fmt.Errorf("%s is not a valid property list real", text)
+		}
+		return f, nil
+	case "date":
+		t, err := time.Parse(time.RFC3339, strings.TrimSpace(text))
+		if err != nil {
+			return nil, fmt.Errorf("%s is not a valid property list date must be formatted as %s", text, DateLayout)
+		}
+		return t, nil
+	case "data":
+		data, err := base64.StdEncoding.DecodeString(strings.Join(strings.Fields(text), ""))
+		if err != nil {
+			return nil, fmt.Errorf("property list data is not valid base64: %s", err.Error())
+		}
+		return data, nil
+	default:
+		return nil, fmt.Errorf("%s is not a valid property list element", start.Name.Local)
+	}
+}
+
+func decodeDict(d *xml.Decoder) (*Dict, error) {
+	dict := NewDict()
+	key, hasKey := "", false
+	for {
+		token, err := d.Token()
+		if err != nil {
+			return nil, fmt.Errorf("unable to read property list dictionary: %s", err.Error())
+		}
+
+		switch t := token.(type) {
+		case xml.StartElement:
+			if !hasKey {
+				if t.Name.Local != "key" {
+					return nil, fmt.Errorf("property list dictionary has a %s without a key", t.Name.Local)
+				}
+				if key, err = readText(d, t); err != nil {
+					return nil, err
+				}
+				hasKey = true
+				continue
+			}
+
+			v, err := decodeValue(d, t)
+			if err != nil {
+				return nil, fmt.Errorf("%s: %s", key, err.Error())
+			}
+			dict.Set(key, v)
+			hasKey = false
+		case xml.EndElement:
+			if hasKey {
+				return nil, fmt.Errorf("property list dictionary key %s has no value", key)
+			}
+			return dict, nil
+		}
+	}
+}
+
+func decodeArray(d *xml.Decoder) ([]interface{}, error) {
+	array := []interface{}{}
+	for {
+		token, err := d.Token()
+		if err != nil {
+			return nil, fmt.Errorf("unable to read property list array: %s", err.Error())
+		}
+
+		switch t := token.(type) {
+		case xml.StartElement:
+			v, err := decodeValue(d, t)
+			if err != nil {
+				return nil, err
+			}
+			array = append(array, v)
+		case xml.EndElement:
+			return array, nil
+		}
+	}
+}
+
+// readText reads the text of an element which must not hold other elements
+func readText(d *xml.Decoder, start xml.StartElement) (string, error) {
+	var text strings.Builder
+	for {
+		token, err := d.Token()
+		if err != nil {
+			return "", fmt.Errorf("unable to read property list %s: %s", start.Name.Local, err.Error())
+		}
+
+		switch t := token.(type) {
+		case xml.CharData:
+			text.Write(t)
+		case xml.StartElement:
+			return "", fmt.Errorf("property list %s can not hold a %s", start.Name.Local, t.Name.Local)
+		case xml.EndElement:
+			return text.String(), nil
+		}
+	}
+}
@@ -0,0 +1,116 @@
+// Unless explicitly stated otherwise all files in this repository are licensed under the Apache-2.0
+// This product includes software developed at Datadog (https://www.datadoghq.com/). Copyright 2020 Datadog, Inc.
+
+package plist
+
+import (
+	"bytes"
+	"encoding/base64"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const header = `<?xml version="1.0" encoding="UTF-8"?>
+<!DOCTYPE plist PUBLIC "-//Apple//DTD PLIST 1.0//EN" "http://www.apple.com/DTDs/PropertyList-1.0.dtd">
+<plist version="1.0">
+`
+
+// textEscaper escapes the characters which can not appear in element text
+var textEscaper = strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;")
+
+// Encode writes a value as an XML property list indented with tabs the way macOS writes them.
+// Along with the types returned by Decode, Go integers, floats, []string, []*Dict and
+// map[string]interface{} are accepted, map keys are written in sorted order
+func Encode(v interface{}) ([]byte, error) {
+	var b bytes.Buffer
+	b.WriteString(header)
+	if err := encodeValue(&b, v, 0); err != nil {
+		return nil, err
+	}
+	b.WriteString("</plist>\n")
+	return b.Bytes(), nil
+}
+
+func encodeValue(b *bytes.Buffer, v interface{}, depth int) error {
+	indent := strings.Repeat("\t", depth)
+	switch value := v.(type) {
+	case *Dict:
+		if value.Len() == 0 {
+			b.WriteString(indent + "<dict/>\n")
+			return nil
+		}
+		b.WriteString(indent + "<dict>\n")
+		for _, k := range value.keys {
+			b.WriteString(indent + "\t<key>" + textEscaper.Replace(k) + "</key>\n")
+			if err := encodeValue(b, value.values[k], depth+1); err != nil {
+				return fmt.Errorf("%s: %s", k, err.Error())
+			}
+		}
+		b.WriteString(indent + "</dict>\n")
+	case map[string]interface{}:
+		keys := make([]string, 0, len(value))
+		for k := range value {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		dict := NewDict()
+		for _, k := range keys {
+			dict.Set(k, value[k])
+		}
+		return encodeValue(b, dict, depth)
+	case []interface{}:
+		if len(value) == 0 {
+			b.WriteString(indent + "<array/>\n")
+			return nil
+		}
+		b.WriteString(indent + "<array>\n")
+		for _, item := range value {
+			if err := encodeValue(b, item, depth+1); err != nil {
+				return err
+			}
+		}
+		b.WriteString(indent + "</array>\n")
+	case []string:
+		array := make([]interface{}, len(value))
+		for i, s := range value {
+			array[i] = s
+		}
+		return encodeValue(b, array, depth)
+	case []*Dict:
+		array := make([]interface{}, len(value))
+		for i, d := range value {
+			array[i] = d
+		}
+		return encodeValue(b, array, depth)
+	case string:
+		b.WriteString(indent + "<string>" + textEscaper.Replace(value) + "</string>\n")
+	case bool:
+		if value {
+			b.WriteString(indent + "<true/>\n")
+		} else {
+			b.WriteString(indent + "<false/>\n")
+		}
+	case int:
+		b.WriteString(indent + "<integer>" + strconv.Itoa(value) + "</integer>\n")
+	case int32:
+		b.WriteString(indent + "<integer>" + strconv.FormatInt(int64(value), 10) + "</integer>\n")
+	case int64:
+		b.WriteString(indent + "<integer>" + strconv.FormatInt(value, 10) + "</integer>\n")
+	case uint64:
+		b.WriteString(indent + "<integer>" + strconv.FormatUint(value, 10) + "</integer>\n")
+	case float32:
+		b.WriteString(indent + "<real>" + strconv.FormatFloat(float64(value), 'g', -1, 32) + "</real>\n")
+	case float64:
+		b.WriteString(indent + "<real>" + strconv.FormatFloat(value, 'g', -1, 64) + "</real>\n")
+	case time.Time:
+		b.WriteString(indent + "<date>" + value.UTC().Format(DateLayout) + "</date>\n")
+	case []byte:
+		b.WriteString(indent + "<data>" + base64.StdEncoding.EncodeToString(value) + "</data>\n")
+	default:
+		return fmt.Errorf("value of type (%T) can not be written to a property list", v)
+	}
+	return nil
+}
@@ -0,0 +1,369 @@
+// Unless explicitly stated otherwise all files in this repository are licensed under the Apache-2.0
+// This product includes software developed at Datadog (https://www.datadoghq.com/). Copyright 2020 Datadog, Inc.
+
+package plist
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+)
+
+var (
+	dictType = reflect.TypeOf((*Dict)(nil))
+	timeType = reflect.TypeOf(time.Time{})
+)
+
+// field is a struct field mapped to a dictionary key
+type field struct {
+	key       string
+	index     []int
+	omitEmpty bool
+}
+
+// structFields returns the fields of a struct mapped to dictionary keys and the index of its raw field
+func structFields(t reflect.Type) (fields []field, raw []int) {
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		tag := f.Tag.Get("plist")
+		if tag == "-" || (f.PkgPath != "" && !f.Anonymous) {
+			continue
+		}
+
+		name, options := tag, ""
+		if i := strings.Index(tag, ","); i >= 0 {
+			name, options = tag[:i], tag[i+1:]
+		}
+
+		if options == "raw" {
+			if f.Type == dictType {
+				raw = f.Index
+			}
+			continue
+		}
+
+		// the fields of untagged embedded structs are mapped as if they were fields of the outer struct
+		if f.Anonymous && name == "" && f.Type.Kind() == reflect.Struct {
+			embedded, embeddedRaw := structFields(f.Type)
+			for _, e := range embedded {
+				fields = append(fields, field{key: e.key, index: append([]int{i}, e.index...), omitEmpty: e.omitEmpty})
+			}
+			if raw == nil && embeddedRaw != nil {
+				raw = append([]int{i}, embeddedRaw...)
+			}
+			continue
+		}
+		if f.PkgPath != "" {
+			continue
+		}
+
+		if name == "" {
+			name = f.Name
+		}
+		fields = append(fields, field{key: name, index: f.Index, omitEmpty: options == "omitempty"})
+	}
+	return fields, raw
+}
+
+// Unmarshal stores the values of a dictionary in the struct v points to. Fields are mapped to keys
+// by the plist struct tag, ex. `plist:"PayloadType,omitempty"`, or by their name and fields tagged
+// `plist:"-"` are skipped. A *Dict field tagged `plist:",raw"` is set to the dictionary itself so
+// Marshal can write back the keys the struct does not map, in their original order
+func Unmarshal(d *Dict, v interface{}) error {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Ptr || rv.IsNil() {
+		return fmt.Errorf("value of type (%T) must be a non nil pointer", v)
+	}
+	return setValue(rv.Elem(), d)
+}
+
+func setValue(rv reflect.Value, value interface{}) error {
+	if rv.Type() == dictType {
+		dict, ok := value.(*Dict)
+		if !ok {
+			return mismatch(value, rv)
+		}
+		rv.Set(reflect.ValueOf(dict))
+		return nil
+	}
+
+	if rv.Type() == timeType {
+		t, ok := value.(time.Time)
+		if !ok {
+			return mismatch(value, rv)
+		}
+		rv.Set(reflect.ValueOf(t))
+		return nil
+	}
+
+	switch rv.Kind() {
+	case reflect.Interface:
+		v := reflect.ValueOf(value)
+		if !v.Type().AssignableTo(rv.Type()) {
+			return mismatch(value, rv)
+		}
+		rv.Set(v)
+	case reflect.Ptr:
+		elem := reflect.New(rv.Type().Elem())
+		if err := setValue(elem.Elem(), value); err != nil {
+			return err
+		}
+		rv.Set(elem)
+	case reflect.String:
+		s, ok := value.(string)
+		if !ok {
+			return mismatch(value, rv)
+		}
+		rv.SetString(s)
+	case reflect.Bool:
+		b, ok := value.(bool)
+		if !ok {
+			return mismatch(value, rv)
+		}
+		rv.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		i, ok := value.(int64)
+		if !ok {
+			return mismatch(value, rv)
+		}
+		if rv.OverflowInt(i) {
+			return fmt.Errorf("%d overflows a field of type (%s)", i, rv.Type())
+		}
+		rv.SetInt(i)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		i, ok := value.(int64)
+		if !ok {
+			return mismatch(value, rv)
+		}
+		if i < 0 || rv.OverflowUint(uint64(i)) {
+			return fmt.Errorf("%d overflows a field of type (%s)", i, rv.Type())
+		}
+		rv.SetUint(uint64(i))
+	case reflect.Float32, reflect.Float64:
+		switch f := value.(type) {
+		case float64:
+			rv.SetFloat(f)
+		case int64:
+			rv.SetFloat(float64(f))
+		default:
+			return mismatch(value, rv)
+		}
+	case reflect.Slice:
+		if data, ok := value.([]byte); ok && rv.Type().Elem().Kind() == reflect.Uint8 {
+			rv.SetBytes(append([]byte(nil), data...))
+			return nil
+		}
+		array, ok := value.([]interface{})
+		if !ok {
+			return mismatch(value, rv)
+		}
+		slice := reflect.MakeSlice(rv.Type(), len(array), len(array))
+		for i, item := range array {
+			if err := setValue(slice.Index(i), item); err != nil {
+				return fmt.Errorf("%d: %s", i, err.Error())
+			}
+		}
+		rv.Set(slice)
+	case reflect.Map:
+		dict, ok := value.(*Dict)
+		if !ok || rv.Type().Key().Kind() != reflect.String {
+			return mismatch(value, rv)
+		}
+		m := reflect.MakeMapWithSize(rv.Type(), dict.Len())
+		for _, k := range dict.keys {
+			elem := reflect.New(rv.Type().Elem()).Elem()
+			if err := setValue(elem, dict.values[k]); err != nil {
+				return fmt.Errorf("%s: %s", k, err.Error())
+			}
+			m.SetMapIndex(reflect.ValueOf(k).Convert(rv.Type().Key()), elem)
+		}
+		rv.Set(m)
+	case reflect.Struct:
+		dict, ok := value.(*Dict)
+		if !ok {
+			return mismatch(value, rv)
+		}
+		fields, raw := structFields(rv.Type())
+		if raw != nil {
+			rv.FieldByIndex(raw).Set(reflect.ValueOf(dict))
+		}
+		for _, f := range fields {
+			v, ok := dict.Get(f.key)
+			if !ok {
+				continue
+			}
+			if err := setValue(rv.FieldByIndex(f.index), v); err != nil {
+				return fmt.Errorf("%s: %s", f.key, err.Error())
+			}
+		}
+	default:
+		return fmt.Errorf("field of type (%s) can not be read from a property list", rv.Type())
+	}
+	return nil
+}
+
+func mismatch(value interface{}, rv reflect.Value) error {
+	return fmt.Errorf("value of type (%T) can not be stored in a field of type (%s)", value, rv.Type())
+}
+
+// Marshal writes a struct or a map with string keys to a dictionary using the same mapping as Unmarshal.
+// When the raw field of a struct is set the keys it holds are kept in order and only the mapped keys are
+// replaced, mapped keys which are new are added after them. Nil pointers and interfaces are left out as
+// are empty fields tagged omitempty, unless the raw field holds the key with the same empty value
+func Marshal(v interface{}) (*Dict, error) {
+	rv := reflect.ValueOf(v)
+	for rv.Kind() == reflect.Ptr && !rv.IsNil() && rv.Type() != dictType {
+		rv = rv.Elem()
+	}
+	if rv.Kind() != reflect.Struct && rv.Kind() != reflect.Map {
+		return nil, fmt.Errorf("value of type (%T) can not be written to a property list dictionary", v)
+	}
+
+	value, err := toValue(rv, nil)
+	if err != nil {
+		return nil, err
+	}
+	return value.(*Dict), nil
+}
+
+// toValue converts a Go value to a property list value, prev is the value it replaces if any
+func toValue(rv reflect.Value, prev interface{}) (interface{}, error) {
+	if rv.Type() == dictType || rv.Type() == timeType {
+		return rv.Interface(), nil
+	}
+
+	switch rv.Kind() {
+	case reflect.Interface, reflect.Ptr:
+		if rv.IsNil() {
+			return nil, fmt.Errorf("nil value of type (%s) can not be written to a property list", rv.Type())
+		}
+		return toValue(rv.Elem(), prev)
+	case reflect.String:
+		return rv.String(), nil
+	case reflect.Bool:
+		return rv.Bool(), nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return rv.Int(), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return int64(rv.Uint()), nil
+	case reflect.Float32, reflect.Float64:
+		return rv.Float(), nil
+	case reflect.Slice:
+		if rv.Type().Elem().Kind() == reflect.Uint8 {
+			return append([]byte(nil), rv.Bytes()...), nil
+		}
+		prevArray, _ := prev.([]interface{})
+		array := make([]interface{}, rv.Len())
+		for i := range array {
+			var prevItem interface{}
+			if i < len(prevArray) {
+				prevItem = prevArray[i]
+			}
+			item, err := toValue(rv.Index(i), prevItem)
+			if err != nil {
+				return nil, fmt.Errorf("%d: %s", i, err.Error())
+			}
+			array[i] = item
+		}
+		return array, nil
+	case reflect.Map:
+		if rv.Type().Key().Kind() != reflect.String {
+			return nil, fmt.Errorf("map of type (%s) can not be written to a property list", rv.Type())
+		}
+		return mapToDict(rv, prev)
+	case reflect.Struct:
+		return structToDict(rv)
+	default:
+		return nil, fmt.Errorf("value of type (%s) can not be written to a property list", rv.Type())
+	}
+}
+
+// mapToDict writes the keys of a map in the order of the dictionary it replaces, new keys are sorted
+func mapToDict(rv reflect.Value, prev interface{}) (*Dict, error) {
+	keys := make([]string, 0, rv.Len())
+	for _, k := range rv.MapKeys() {
+		keys = append(keys, k.String())
+	}
+	sort.Strings(keys)
+
+	prevDict, _ := prev.(*Dict)
+	if prevDict != nil {
+		ordered := make([]string, 0, len(keys))
+		for _, k := range prevDict.keys {
+			if rv.MapIndex(reflect.ValueOf(k).Convert(rv.Type().Key())).IsValid() {
+				ordered = append(ordered, k)
+			}
+		}
+		for _, k := range keys {
+			if _, ok := prevDict.values[k]; !ok {
+				ordered = append(ordered, k)
+			}
+		}
+		keys = ordered
+	}
+
+	dict := NewDict()
+	for _, k := range keys {
+		prevValue, _ := prevDict.Get(k)
+		value, err := toValue(rv.MapIndex(reflect.ValueOf(k).Convert(rv.Type().Key())), prevValue)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %s", k, err.Error())
+		}
+		dict.Set(k, value)
+	}
+	return dict, nil
+}
+
+// structToDict writes the mapped fields of a struct on top of a copy of its raw dictionary
+func structToDict(rv reflect.Value) (*Dict, error) {
+	fields, raw := structFields(rv.Type())
+	dict := NewDict()
+	if raw != nil {
+		if rawDict := rv.FieldByIndex(raw).Interface().(*Dict); rawDict != nil {
+			dict = rawDict.Clone()
+		}
+	}
+
+	for _, f := range fields {
+		fv := rv.FieldByIndex(f.index)
+		prev, found := dict.Get(f.key)
+		if isNil(fv) || (f.omitEmpty && isEmpty(fv)) {
+			if !found || isNil(fv) || !sameValue(fv, prev) {
+				dict.Delete(f.key)
+			}
+			continue
+		}
+		value, err := toValue(fv, prev)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %s", f.key, err.Error())
+		}
+		dict.Set(f.key, value)
+	}
+	return dict, nil
+}
+
+// sameValue returns whether a field holds the property list value it was read from
+func sameValue(rv reflect.Value, prev interface{}) bool {
+	value, err := toValue(rv, prev)
+	return err == nil && reflect.DeepEqual(value, prev)
+}
+
+func isNil(rv reflect.Value) bool {
+	switch rv.Kind() {
+	case reflect.Interface, reflect.Ptr:
+		return rv.IsNil()
+	}
+	return false
+}
+
+func isEmpty(rv reflect.Value) bool {
+	switch rv.Kind() {
+	case reflect.Slice, reflect.Map, reflect.String:
+		return rv.Len() == 0
+	case reflect.Struct:
+		return rv.Type() == timeType && rv.Interface().(time.Time).IsZero()
+	}
+	return rv.IsZero()
+}
@@ -0,0 +1,107 @@
+// Unless explicitly stated otherwise all files in this repository are licensed under the Apache-2.0
+// This product includes software developed at Datadog (https://www.datadoghq.com/). Copyright 2020 Datadog, Inc.
+
+package plist_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/trustero/jamf-api-client-go/plist"
+)
+
+type header struct {
+	Type    string `plist:"PayloadType"`
+	Version int    `plist:"PayloadVersion,omitempty"`
+}
+
+type settings struct {
+	Raw *plist.Dict `plist:",raw"`
+	header
+	Enabled  *bool
+	Level    float64 `plist:",omitempty"`
+	Created  time.Time
+	Icon     []byte            `plist:",omitempty"`
+	Tags     []string          `plist:",omitempty"`
+	Limits   map[string]int64  `plist:",omitempty"`
+	Children []*settings       `plist:",omitempty"`
+	Extra    interface{}       `plist:",omitempty"`
+	Ignored  string            `plist:"-"`
+	Labels   map[string]string `plist:",omitempty"`
+}
+
+func TestUnmarshalAndMarshal(t *testing.T) {
+	data := []byte(`<plist><dict>
+		<key>Unknown</key><string>kept</string>
+		<key>PayloadType</key><string>com.example</string>
+		<key>Enabled</key><false/>
+		<key>Level</key><integer>2</integer>
+		<key>Created</key><date>2024-03-01T10:00:00Z</date>
+		<key>Tags</key><array><string>a</string><string>b</string></array>
+		<key>Limits</key><dict><key>z</key><integer>1</integer><key>a</key><integer>2</integer></dict>
+		<key>Children</key><array><dict><key>PayloadType</key><string>child</string><key>Note</key><string>kept too</string></dict></array>
+		<key>Extra</key><array><true/></array>
+		<key>Ignored</key><string>not read</string>
+	</dict></plist>`)
+	dict, err := plist.DecodeDict(data)
+	assert.Nil(t, err)
+
+	s := &settings{}
+	assert.Nil(t, plist.Unmarshal(dict, s))
+	assert.Equal(t, dict, s.Raw)
+	assert.Equal(t, "com.example", s.Type)
+	assert.False(t, *s.Enabled)
+	assert.Equal(t, 2.0, s.Level)
+	assert.Equal(t, time.Date(2024, 3, 1, 10, 0, 0, 0, time.UTC), s.Created)
+	assert.Equal(t, []string{"a", "b"}, s.Tags)
+	assert.Equal(t, map[string]int64{"z": 1, "a": 2}, s.Limits)
+	assert.Equal(t, "child", s.Children[0].Type)
+	assert.Equal(t, []interface{}{true}, s.Extra)
+	assert.Empty(t, s.Ignored)
+
+	// mapped keys are replaced in place, unknown keys and the order of map keys are kept
+	s.Version = 2
+	s.Limits["b"] = 3
+	s.Children[0].Type = "renamed"
+	s.Extra = nil
+	out, err := plist.Marshal(s)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"Unknown", "PayloadType", "Enabled", "Level", "Created", "Tags", "Limits", "Children", "Ignored", "PayloadVersion"}, out.Keys())
+	assert.Equal(t, "kept", out.String("Unknown"))
+	assert.Equal(t, "not read", out.String("Ignored"))
+	assert.Equal(t, []string{"z", "a", "b"}, out.Dict("Limits").Keys())
+	assert.Equal(t, "kept too", out.Dicts("Children")[0].String("Note"))
+	assert.Equal(t, "renamed", out.Dicts("Children")[0].String("PayloadType"))
+	// the dictionary which was read is left untouched
+	assert.Equal(t, "child", s.Children[0].Raw.String("PayloadType"))
+
+	// without a raw dictionary keys are written in field order
+	out, err = plist.Marshal(&settings{header: header{Type: "new"}, Labels: map[string]string{"b": "2", "a": "1"}})
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"PayloadType", "Created", "Labels"}, out.Keys())
+	assert.Equal(t, []string{"a", "b"}, out.Dict("Labels").Keys())
+}
+
+func TestUnmarshalErrors(t *testing.T) {
+	dict := plist.NewDict().Set("PayloadType", int64(1))
+	err := plist.Unmarshal(dict, &settings{})
+	assert.NotNil(t, err)
+	assert.Equal(t, "PayloadType: value of type (int64) can not be stored in a field of type (string)", err.Error())
+
+	dict = plist.NewDict().Set("Tags", []interface{}{"a", true})
+	err = plist.Unmarshal(dict, &settings{})
+	assert.NotNil(t, err)
+	assert.Equal(t, "Tags: 1: value of type (bool) can not be stored in a field of type (string)", err.Error())
+
+	dict = plist.NewDict().Set("Small", int64(300))
+	err = plist.Unmarshal(dict, &struct{ Small int8 }{})
+	assert.NotNil(t, err)
+	assert.Equal(t, "Small: 300 overflows a field of type (int8)", err.Error())
+
+	assert.NotNil(t, plist.Unmarshal(dict, settings{}))
+	_, err = plist.Marshal("string")
+	assert.NotNil(t, err)
+	_, err = plist.Marshal(struct{ C chan int }{})
+	assert.NotNil(t, err)
+}
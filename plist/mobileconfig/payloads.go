@@ -0,0 +1,213 @@
+// Unless explicitly stated otherwise all files in this repository are licensed under the Apache-2.0
+// This product includes software developed at Datadog (https://www.datadoghq.com/). Copyright 2020 Datadog, Inc.
+
+package mobileconfig
+
+import "github.com/trustero/jamf-api-client-go/plist"
+
+// Payload types with a typed struct
+const (
+	PPPCType             = "com.apple.TCC.configuration-profile-policy"
+	SystemExtensionsType = "com.apple.system-extension-policy"
+	NotificationsType    = "com.apple.notificationsettings"
+	LoginItemsType       = "com.apple.servicemanagement"
+)
+
+// TCCService is a privacy service managed by a PPPC payload
+type TCCService string
+
+const (
+	Accessibility                TCCService = "Accessibility"
+	AddressBook                  TCCService = "AddressBook"
+	AppleEvents                  TCCService = "AppleEvents"
+	BluetoothAlways              TCCService = "BluetoothAlways"
+	Calendar                     TCCService = "Calendar"
+	Camera                       TCCService = "Camera"
+	FileProviderPresence         TCCService = "FileProviderPresence"
+	ListenEvent                  TCCService = "ListenEvent"
+	MediaLibrary                 TCCService = "MediaLibrary"
+	Microphone                   TCCService = "Microphone"
+	Photos                       TCCService = "Photos"
+	PostEvent                    TCCService = "PostEvent"
+	Reminders                    TCCService = "Reminders"
+	ScreenCapture                TCCService = "ScreenCapture"
+	SpeechRecognition            TCCService = "SpeechRecognition"
+	SystemPolicyAllFiles         TCCService = "SystemPolicyAllFiles"
+	SystemPolicyAppBundles       TCCService = "SystemPolicyAppBundles"
+	SystemPolicyAppData          TCCService = "SystemPolicyAppData"
+	SystemPolicyDesktopFolder    TCCService = "SystemPolicyDesktopFolder"
+	SystemPolicyDocumentsFolder  TCCService = "SystemPolicyDocumentsFolder"
+	SystemPolicyDownloadsFolder  TCCService = "SystemPolicyDownloadsFolder"
+	SystemPolicyNetworkVolumes   TCCService = "SystemPolicyNetworkVolumes"
+	SystemPolicyRemovableVolumes TCCService = "SystemPolicyRemovableVolumes"
+	SystemPolicySysAdminFiles    TCCService = "SystemPolicySysAdminFiles"
+)
+
+// IdentifierType is how a PPPC rule identifies an application
+type IdentifierType string
+
+const (
+	BundleID IdentifierType = "bundleID"
+	Path     IdentifierType = "path"
+)
+
+// Authorization is the access a PPPC rule grants
+type Authorization string
+
+const (
+	Allow Authorization = "Allow"
+	Deny  Authorization = "Deny"
+	// AllowStandardUserToSetSystemService lets standard users grant access, only for ListenEvent and ScreenCapture
+	AllowStandardUserToSetSystemService Authorization = "AllowStandardUserToSetSystemService"
+)
+
+// PPPC is a Privacy Preferences Policy Control payload which grants or denies applications access to
+// privacy services such as full disk access (SystemPolicyAllFiles)
+type PPPC struct {
+	Raw *plist.Dict `plist:",raw"`
+	PayloadHeader
+	Services map[TCCService][]*PPPCRule
+}
+
+// NewPPPC returns an empty PPPC payload
+func NewPPPC() *PPPC {
+	return &PPPC{PayloadHeader: PayloadHeader{PayloadType: PPPCType}, Services: map[TCCService][]*PPPCRule{}}
+}
+
+// Grant adds a rule for a service
+func (p *PPPC) Grant(service TCCService, rule *PPPCRule) *PPPC {
+	if p.Services == nil {
+		p.Services = map[TCCService][]*PPPCRule{}
+	}
+	p.Services[service] = append(p.Services[service], rule)
+	return p
+}
+
+// PPPCRule grants or denies an application access to a privacy service. Either Allowed or the newer
+// Authorization must be set, Authorization takes precedence on macOS 11 and later
+type PPPCRule struct {
+	Raw                       *plist.Dict `plist:",raw"`
+	Identifier                string
+	IdentifierType            IdentifierType
+	CodeRequirement           string
+	StaticCode                *bool          `plist:",omitempty"`
+	Allowed                   *bool          `plist:",omitempty"`
+	Authorization             Authorization  `plist:",omitempty"`
+	Comment                   string         `plist:",omitempty"`
+	AEReceiverIdentifier      string         `plist:",omitempty"`
+	AEReceiverIdentifierType  IdentifierType `plist:",omitempty"`
+	AEReceiverCodeRequirement string         `plist:",omitempty"`
+}
+
+// SystemExtensionType is a type of system extension
+type SystemExtensionType string
+
+const (
+	DriverExtension           SystemExtensionType = "DriverExtension"
+	NetworkExtension          SystemExtensionType = "NetworkExtension"
+	EndpointSecurityExtension SystemExtensionType = "EndpointSecurityExtension"
+)
+
+// SystemExtensions is a system extension policy payload. The maps are keyed by team identifier
+type SystemExtensions struct {
+	Raw *plist.Dict `plist:",raw"`
+	PayloadHeader
+	AllowUserOverrides          *bool                            `plist:",omitempty"`
+	AllowedTeamIdentifiers      []string                         `plist:",omitempty"`
+	AllowedSystemExtensions     map[string][]string              `plist:",omitempty"`
+	AllowedSystemExtensionTypes map[string][]SystemExtensionType `plist:",omitempty"`
+	RemovableSystemExtensions   map[string][]string              `plist:",omitempty"`
+}
+
+// NewSystemExtensions returns an empty system extension policy payload
+func NewSystemExtensions() *SystemExtensions {
+	return &SystemExtensions{PayloadHeader: PayloadHeader{PayloadType: SystemExtensionsType}}
+}
+
+// Allow allows system extensions of a team by bundle identifier
+func (s *SystemExtensions) Allow(teamIdentifier string, bundleIdentifiers ...string) *SystemExtensions {
+	if s.AllowedSystemExtensions == nil {
+		s.AllowedSystemExtensions = map[string][]string{}
+	}
+	s.AllowedSystemExtensions[teamIdentifier] = append(s.AllowedSystemExtensions[teamIdentifier], bundleIdentifiers...)
+	return s
+}
+
+// AlertType is how notifications of an application are shown
+type AlertType int
+
+const (
+	NoAlert         AlertType = 0
+	TemporaryAlert  AlertType = 1
+	PersistentAlert AlertType = 2
+)
+
+// PreviewType is when notification previews of an application are shown
+type PreviewType int
+
+const (
+	PreviewAlways       PreviewType = 0
+	PreviewWhenUnlocked PreviewType = 1
+	PreviewNever        PreviewType = 2
+)
+
+// Notifications is a notifications payload which manages the notification settings of applications
+type Notifications struct {
+	Raw *plist.Dict `plist:",raw"`
+	PayloadHeader
+	NotificationSettings []*NotificationSetting
+}
+
+// NewNotifications returns a notifications payload for the given settings
+func NewNotifications(settings ...*NotificationSetting) *Notifications {
+	return &Notifications{PayloadHeader: PayloadHeader{PayloadType: NotificationsType}, NotificationSettings: settings}
+}
+
+// NotificationSetting holds the notification settings of an application. Unset keys keep the macOS
+// default, use Ptr to set them
+type NotificationSetting struct {
+	Raw                      *plist.Dict `plist:",raw"`
+	BundleIdentifier         string
+	NotificationsEnabled     *bool        `plist:",omitempty"`
+	AlertType                *AlertType   `plist:",omitempty"`
+	BadgesEnabled            *bool        `plist:",omitempty"`
+	CriticalAlertEnabled     *bool        `plist:",omitempty"`
+	ShowInCarPlay            *bool        `plist:",omitempty"`
+	ShowInLockScreen         *bool        `plist:",omitempty"`
+	ShowInNotificationCenter *bool        `plist:",omitempty"`
+	SoundsEnabled            *bool        `plist:",omitempty"`
+	PreviewType              *PreviewType `plist:",omitempty"`
+}
+
+// RuleType is what a managed login item rule matches
+type RuleType string
+
+const (
+	BundleIdentifierRule       RuleType = "BundleIdentifier"
+	BundleIdentifierPrefixRule RuleType = "BundleIdentifierPrefix"
+	LabelRule                  RuleType = "Label"
+	LabelPrefixRule            RuleType = "LabelPrefix"
+	TeamIdentifierRule         RuleType = "TeamIdentifier"
+)
+
+// LoginItems is a service management payload which manages login items and background tasks so
+// users can not disable them
+type LoginItems struct {
+	Raw *plist.Dict `plist:",raw"`
+	PayloadHeader
+	Rules []*LoginItemRule
+}
+
+// NewLoginItems returns a managed login items payload for the given rules
+func NewLoginItems(rules ...*LoginItemRule) *LoginItems {
+	return &LoginItems{PayloadHeader: PayloadHeader{PayloadType: LoginItemsType}, Rules: rules}
+}
+
+// LoginItemRule matches the login items and background tasks to manage
+type LoginItemRule struct {
+	Raw            *plist.Dict `plist:",raw"`
+	RuleType       RuleType
+	RuleValue      string
+	TeamIdentifier string `plist:",omitempty"`
+	Comment        string `plist:",omitempty"`
+}
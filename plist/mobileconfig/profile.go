@@ -0,0 +1,225 @@
+// Unless explicitly stated otherwise all files in this repository are licensed under the Apache-2.0
+// This product includes software developed at Datadog (https://www.datadoghq.com/). Copyright 2020 Datadog, Inc.
+
+// Package mobileconfig reads and writes configuration profiles (.mobileconfig) with typed structs for
+// common payloads. Profiles are not signed. Every struct keeps the dictionary it was read from so keys
+// it does not know about are written back unchanged and in their original order
+package mobileconfig
+
+import (
+	"crypto/rand"
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/trustero/jamf-api-client-go/plist"
+)
+
+// ConfigurationType is the payload type of a configuration profile
+const ConfigurationType = "Configuration"
+
+// PayloadHeader holds the keys shared by profiles and every payload they hold. Keys which are
+// empty are left out so payloads missing them are written back as they were read
+type PayloadHeader struct {
+	PayloadType         string `plist:",omitempty"`
+	PayloadVersion      int    `plist:",omitempty"`
+	PayloadIdentifier   string `plist:",omitempty"`
+	PayloadUUID         string `plist:",omitempty"`
+	PayloadDisplayName  string `plist:",omitempty"`
+	PayloadDescription  string `plist:",omitempty"`
+	PayloadOrganization string `plist:",omitempty"`
+}
+
+// Header returns the payload header
+func (h *PayloadHeader) Header() *PayloadHeader {
+	return h
+}
+
+// Payload is a payload of a configuration profile such as *PPPC
+type Payload interface {
+	Header() *PayloadHeader
+}
+
+// GenericPayload holds a payload of a type which has no typed struct registered, see RegisterPayload
+type GenericPayload struct {
+	Raw *plist.Dict `plist:",raw"`
+	PayloadHeader
+}
+
+var (
+	payloadsMu sync.RWMutex
+	payloads   = map[string]func() Payload{
+		PPPCType:             func() Payload { return &PPPC{} },
+		SystemExtensionsType: func() Payload { return &SystemExtensions{} },
+		NotificationsType:    func() Payload { return &Notifications{} },
+		LoginItemsType:       func() Payload { return &LoginItems{} },
+	}
+)
+
+// RegisterPayload registers the typed struct used to read payloads of a payload type. newPayload must
+// return a pointer to a struct mapped with plist struct tags, see plist.Unmarshal
+func RegisterPayload(payloadType string, newPayload func() Payload) {
+	payloadsMu.Lock()
+	defer payloadsMu.Unlock()
+	payloads[payloadType] = newPayload
+}
+
+// PayloadTypes returns the payload types which are read into typed structs
+func PayloadTypes() []string {
+	payloadsMu.RLock()
+	defer payloadsMu.RUnlock()
+	types := make([]string, 0, len(payloads))
+	for t := range payloads {
+		types = append(types, t)
+	}
+	sort.Strings(types)
+	return types
+}
+
+func newPayload(payloadType string) Payload {
+	payloadsMu.RLock()
+	defer payloadsMu.RUnlock()
+	if newPayload, ok := payloads[payloadType]; ok {
+		return newPayload()
+	}
+	return &GenericPayload{}
+}
+
+// Profile is a configuration profile. PayloadContent is read into a typed struct for each registered
+// payload type and into a GenericPayload for the others
+type Profile struct {
+	Raw *plist.Dict `plist:",raw"`
+	PayloadHeader
+	PayloadScope             string    `plist:",omitempty"`
+	PayloadRemovalDisallowed *bool     `plist:",omitempty"`
+	PayloadContent           []Payload `plist:"-"`
+}
+
+// NewProfile returns an empty profile with a new UUID
+func NewProfile(identifier string, displayName string) *Profile {
+	return &Profile{PayloadHeader: PayloadHeader{
+		PayloadType:        ConfigurationType,
+		PayloadVersion:     1,
+		PayloadIdentifier:  identifier,
+		PayloadUUID:        NewUUID(),
+		PayloadDisplayName: displayName,
+	}}
+}
+
+// Parse reads a configuration profile from an XML property list
+func Parse(data []byte) (*Profile, error) {
+	d, err := plist.DecodeDict(data)
+	if err != nil {
+		return nil, err
+	}
+	return FromDict(d)
+}
+
+// FromDict reads a configuration profile from a property list dictionary
+func FromDict(d *plist.Dict) (*Profile, error) {
+	if d == nil {
+		return nil, fmt.Errorf("configuration profile is empty")
+	}
+
+	p := &Profile{}
+	if err := plist.Unmarshal(d, p); err != nil {
+		return nil, err
+	}
+
+	content, _ := d.Get("PayloadContent")
+	items, ok := content.([]interface{})
+	if dicts, isDicts := content.([]*plist.Dict); isDicts {
+		items, ok = make([]interface{}, len(dicts)), true
+		for i, dict := range dicts {
+			items[i] = dict
+		}
+	}
+	if content != nil && !ok {
+		return nil, fmt.Errorf("PayloadContent must be an array of payloads")
+	}
+	for i, item := range items {
+		dict, ok := item.(*plist.Dict)
+		if !ok {
+			return nil, fmt.Errorf("payload %d must be a dictionary", i)
+		}
+		payload := newPayload(dict.String("PayloadType"))
+		if err := plist.Unmarshal(dict, payload); err != nil {
+			return nil, fmt.Errorf("payload %d (%s): %s", i, dict.String("PayloadType"), err.Error())
+		}
+		p.PayloadContent = append(p.PayloadContent, payload)
+	}
+	return p, nil
+}
+
+// Add adds payloads to the profile filling in the version, UUID and identifier when they are not set
+func (p *Profile) Add(payloads ...Payload) *Profile {
+	for _, payload := range payloads {
+		h := payload.Header()
+		if h.PayloadVersion == 0 {
+			h.PayloadVersion = 1
+		}
+		if h.PayloadUUID == "" {
+			h.PayloadUUID = NewUUID()
+		}
+		if h.PayloadIdentifier == "" {
+			h.PayloadIdentifier = p.PayloadIdentifier + "." + h.PayloadUUID
+		}
+		p.PayloadContent = append(p.PayloadContent, payload)
+	}
+	return p
+}
+
+// Dict writes the profile to a property list dictionary
+func (p *Profile) Dict() (*plist.Dict, error) {
+	d, err := plist.Marshal(p)
+	if err != nil {
+		return nil, err
+	}
+
+	content := make([]interface{}, len(p.PayloadContent))
+	for i, payload := range p.PayloadContent {
+		dict, err := plist.Marshal(payload)
+		if err != nil {
+			return nil, fmt.Errorf("payload %d (%s): %s", i, payload.Header().PayloadType, err.Error())
+		}
+		content[i] = dict
+	}
+	d.Set("PayloadContent", content)
+	return d, nil
+}
+
+// Encode writes the profile as an XML property list which can be saved as a .mobileconfig
+func (p *Profile) Encode() ([]byte, error) {
+	d, err := p.Dict()
+	if err != nil {
+		return nil, err
+	}
+	return plist.Encode(d)
+}
+
+// PayloadsOf returns the payloads of a profile read into the typed struct T, ex. PayloadsOf[*PPPC](profile)
+func PayloadsOf[T Payload](p *Profile) []T {
+	var found []T
+	for _, payload := range p.PayloadContent {
+		if t, ok := payload.(T); ok {
+			found = append(found, t)
+		}
+	}
+	return found
+}
+
+// NewUUID returns a random version 4 UUID as used by PayloadUUID
+func NewUUID() string {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		panic(err)
+	}
+	b[6] = (b[6] & 0x0f) | 0x40
+	b[8] = (b[8] & 0x3f) | 0x80
+	return fmt.Sprintf("%X-%X-%X-%X-%X", b[0:4], b[4:6], b[6:8], b[8:10], b[10:])
+}
+
+// Ptr returns a pointer to a value, ex. Ptr(true) for optional keys such as NotificationSetting.BadgesEnabled
+func Ptr[T any](v T) *T {
+	return &v
+}
@@ -0,0 +1,327 @@
+// Unless explicitly stated otherwise all files in this repository are licensed under the Apache-2.0
+// This product includes software developed at Datadog (https://www.datadoghq.com/). Copyright 2020 Datadog, Inc.
+
+package mobileconfig_test
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/trustero/jamf-api-client-go/plist"
+	"github.com/trustero/jamf-api-client-go/plist/mobileconfig"
+)
+
+const agentProfile = `<?xml version="1.0" encoding="UTF-8"?>
+<!DOCTYPE plist PUBLIC "-//Apple//DTD PLIST 1.0//EN" "http://www.apple.com/DTDs/PropertyList-1.0.dtd">
+<plist version="1.0">
+<dict>
+	<key>PayloadContent</key>
+	<array>
+		<dict>
+			<key>PayloadDisplayName</key>
+			<string>Privacy Preferences Policy Control</string>
+			<key>PayloadIdentifier</key>
+			<string>com.example.agent.pppc</string>
+			<key>PayloadType</key>
+			<string>com.apple.TCC.configuration-profile-policy</string>
+			<key>PayloadUUID</key>
+			<string>6B1A5C3E-2F0B-4C4B-9C5E-1E2D3F4A5B6C</string>
+			<key>PayloadVersion</key>
+			<integer>1</integer>
+			<key>Services</key>
+			<dict>
+				<key>SystemPolicyAllFiles</key>
+				<array>
+					<dict>
+						<key>Authorization</key>
+						<string>Allow</string>
+						<key>CodeRequirement</key>
+						<string>identifier "com.example.agent" and anchor apple generic</string>
+						<key>Comment</key>
+						<string>Full disk access for the agent</string>
+						<key>Identifier</key>
+						<string>com.example.agent</string>
+						<key>IdentifierType</key>
+						<string>bundleID</string>
+						<key>StaticCode</key>
+						<false/>
+					</dict>
+				</array>
+				<key>Accessibility</key>
+				<array>
+					<dict>
+						<key>Allowed</key>
+						<true/>
+						<key>CodeRequirement</key>
+						<string>identifier "com.example.agent" and anchor apple generic</string>
+						<key>Identifier</key>
+						<string>com.example.agent</string>
+						<key>IdentifierType</key>
+						<string>bundleID</string>
+					</dict>
+				</array>
+			</dict>
+		</dict>
+		<dict>
+			<key>AllowedSystemExtensions</key>
+			<dict>
+				<key>ABCDE12345</key>
+				<array>
+					<string>com.example.agent.extension</string>
+				</array>
+			</dict>
+			<key>AllowedSystemExtensionTypes</key>
+			<dict>
+				<key>ABCDE12345</key>
+				<array>
+					<string>EndpointSecurityExtension</string>
+				</array>
+			</dict>
+			<key>PayloadIdentifier</key>
+			<string>com.example.agent.sysext</string>
+			<key>PayloadType</key>
+			<string>com.apple.system-extension-policy</string>
+			<key>PayloadUUID</key>
+			<string>1C2D3E4F-5A6B-4C7D-8E9F-0A1B2C3D4E5F</string>
+			<key>PayloadVersion</key>
+			<integer>1</integer>
+		</dict>
+		<dict>
+			<key>NotificationSettings</key>
+			<array>
+				<dict>
+					<key>AlertType</key>
+					<integer>2</integer>
+					<key>BundleIdentifier</key>
+					<string>com.example.agent</string>
+					<key>CriticalAlertEnabled</key>
+					<true/>
+					<key>GroupingType</key>
+					<integer>0</integer>
+				</dict>
+			</array>
+			<key>PayloadIdentifier</key>
+			<string>com.example.agent.notifications</string>
+			<key>PayloadType</key>
+			<string>com.apple.notificationsettings</string>
+			<key>PayloadUUID</key>
+			<string>2D3E4F5A-6B7C-4D8E-9F0A-1B2C3D4E5F6A</string>
+			<key>PayloadVersion</key>
+			<integer>1</integer>
+		</dict>
+		<dict>
+			<key>PayloadIdentifier</key>
+			<string>com.example.agent.loginitems</string>
+			<key>PayloadType</key>
+			<string>com.apple.servicemanagement</string>
+			<key>PayloadUUID</key>
+			<string>3E4F5A6B-7C8D-4E9F-0A1B-2C3D4E5F6A7B</string>
+			<key>PayloadVersion</key>
+			<integer>1</integer>
+			<key>Rules</key>
+			<array>
+				<dict>
+					<key>Comment</key>
+					<string>Agent daemons</string>
+					<key>RuleType</key>
+					<string>TeamIdentifier</string>
+					<key>RuleValue</key>
+					<string>ABCDE12345</string>
+				</dict>
+			</array>
+		</dict>
+		<dict>
+			<key>PayloadIdentifier</key>
+			<string>com.example.agent.dock</string>
+			<key>PayloadType</key>
+			<string>com.apple.dock</string>
+			<key>PayloadUUID</key>
+			<string>4F5A6B7C-8D9E-4F0A-1B2C-3D4E5F6A7B8C</string>
+			<key>PayloadVersion</key>
+			<integer>1</integer>
+			<key>autohide</key>
+			<true/>
+		</dict>
+	</array>
+	<key>PayloadDisplayName</key>
+	<string>Example Agent</string>
+	<key>PayloadIdentifier</key>
+	<string>com.example.agent</string>
+	<key>PayloadRemovalDisallowed</key>
+	<true/>
+	<key>PayloadScope</key>
+	<string>System</string>
+	<key>PayloadType</key>
+	<string>Configuration</string>
+	<key>PayloadUUID</key>
+	<string>0A6C2F4E-8B1D-4E3F-A5C7-9D0E1F2A3B4C</string>
+	<key>PayloadVersion</key>
+	<integer>1</integer>
+</dict>
+</plist>
+`
+
+func TestParseProfile(t *testing.T) {
+	profile, err := mobileconfig.Parse([]byte(agentProfile))
+	assert.Nil(t, err)
+	assert.Nil(t, profile.Validate())
+	assert.Equal(t, "com.example.agent", profile.PayloadIdentifier)
+	assert.Equal(t, "System", profile.PayloadScope)
+	assert.True(t, *profile.PayloadRemovalDisallowed)
+	assert.Len(t, profile.PayloadContent, 5)
+
+	pppc := mobileconfig.PayloadsOf[*mobileconfig.PPPC](profile)
+	assert.Len(t, pppc, 1)
+	rule := pppc[0].Services[mobileconfig.SystemPolicyAllFiles][0]
+	assert.Equal(t, mobileconfig.Allow, rule.Authorization)
+	assert.Equal(t, mobileconfig.BundleID, rule.IdentifierType)
+	assert.False(t, *rule.StaticCode)
+	assert.Nil(t, rule.Allowed)
+	assert.True(t, *pppc[0].Services[mobileconfig.Accessibility][0].Allowed)
+
+	extensions := mobileconfig.PayloadsOf[*mobileconfig.SystemExtensions](profile)[0]
+	assert.Equal(t, []string{"com.example.agent.extension"}, extensions.AllowedSystemExtensions["ABCDE12345"])
+	assert.Equal(t, []mobileconfig.SystemExtensionType{mobileconfig.EndpointSecurityExtension}, extensions.AllowedSystemExtensionTypes["ABCDE12345"])
+	assert.Nil(t, extensions.AllowUserOverrides)
+
+	notifications := mobileconfig.PayloadsOf[*mobileconfig.Notifications](profile)[0]
+	assert.Equal(t, mobileconfig.PersistentAlert, *notifications.NotificationSettings[0].AlertType)
+	assert.True(t, *notifications.NotificationSettings[0].CriticalAlertEnabled)
+	assert.Nil(t, notifications.NotificationSettings[0].BadgesEnabled)
+
+	loginItems := mobileconfig.PayloadsOf[*mobileconfig.LoginItems](profile)[0]
+	assert.Equal(t, mobileconfig.TeamIdentifierRule, loginItems.Rules[0].RuleType)
+
+	dock := mobileconfig.PayloadsOf[*mobileconfig.GenericPayload](profile)[0]
+	assert.Equal(t, "com.apple.dock", dock.PayloadType)
+	assert.True(t, dock.Raw.Bool("autohide"))
+
+	// a profile which is not changed is written back exactly as it was read
+	data, err := profile.Encode()
+	assert.Nil(t, err)
+	assert.Equal(t, agentProfile, string(data))
+}
+
+func TestParseProfileMissingHeader(t *testing.T) {
+	// payloads without a version, identifier or UUID and keys holding empty values are written
+	// back as they were read
+	d := plist.NewDict().
+		Set("PayloadContent", []interface{}{
+			plist.NewDict().Set("PayloadType", "com.apple.dock").Set("autohide", true),
+			plist.NewDict().Set("PayloadType", "com.apple.screensaver").Set("PayloadDisplayName", "").Set("PayloadVersion", int64(0)),
+		}).
+		Set("PayloadType", mobileconfig.ConfigurationType)
+	data, err := plist.Encode(d)
+	assert.Nil(t, err)
+
+	profile, err := mobileconfig.Parse(data)
+	assert.Nil(t, err)
+	encoded, err := profile.Encode()
+	assert.Nil(t, err)
+	assert.Equal(t, string(data), string(encoded))
+}
+
+func TestEditProfile(t *testing.T) {
+	profile, err := mobileconfig.Parse([]byte(agentProfile))
+	assert.Nil(t, err)
+
+	pppc := mobileconfig.PayloadsOf[*mobileconfig.PPPC](profile)[0]
+	pppc.Grant(mobileconfig.ScreenCapture, &mobileconfig.PPPCRule{
+		Identifier:      "com.example.agent",
+		IdentifierType:  mobileconfig.BundleID,
+		CodeRequirement: `identifier "com.example.agent" and anchor apple generic`,
+		Authorization:   mobileconfig.AllowStandardUserToSetSystemService,
+	})
+	notifications := mobileconfig.PayloadsOf[*mobileconfig.Notifications](profile)[0]
+	notifications.NotificationSettings[0].BadgesEnabled = mobileconfig.Ptr(false)
+	assert.Nil(t, profile.Validate())
+
+	d, err := profile.Dict()
+	assert.Nil(t, err)
+	content := d.Dicts("PayloadContent")
+	// existing services keep their order and new services are added after them
+	assert.Equal(t, []string{"SystemPolicyAllFiles", "Accessibility", "ScreenCapture"}, content[0].Dict("Services").Keys())
+	assert.Equal(t, []string{"Identifier", "IdentifierType", "CodeRequirement", "Authorization"}, content[0].Dict("Services").Dicts("ScreenCapture")[0].Keys())
+	// unknown keys such as GroupingType are kept
+	assert.Equal(t, []string{"AlertType", "BundleIdentifier", "CriticalAlertEnabled", "GroupingType", "BadgesEnabled"}, content[2].Dicts("NotificationSettings")[0].Keys())
+	assert.True(t, content[4].Bool("autohide"))
+
+	reparsed, err := mobileconfig.FromDict(d)
+	assert.Nil(t, err)
+	assert.False(t, *mobileconfig.PayloadsOf[*mobileconfig.Notifications](reparsed)[0].NotificationSettings[0].BadgesEnabled)
+}
+
+func TestNewProfile(t *testing.T) {
+	profile := mobileconfig.NewProfile("com.example.security", "Security").Add(
+		mobileconfig.NewPPPC().Grant(mobileconfig.SystemPolicyAllFiles, &mobileconfig.PPPCRule{
+			Identifier:      "/usr/local/bin/agent",
+			IdentifierType:  mobileconfig.Path,
+			CodeRequirement: `identifier "agent" and anchor apple generic`,
+			Allowed:         mobileconfig.Ptr(true),
+		}),
+		mobileconfig.NewSystemExtensions().Allow("ABCDE12345", "com.example.agent.extension"),
+		mobileconfig.NewNotifications(&mobileconfig.NotificationSetting{BundleIdentifier: "com.example.agent", AlertType: mobileconfig.Ptr(mobileconfig.TemporaryAlert)}),
+		mobileconfig.NewLoginItems(&mobileconfig.LoginItemRule{RuleType: mobileconfig.BundleIdentifierPrefixRule, RuleValue: "com.example."}),
+	)
+	assert.Nil(t, profile.Validate())
+	assert.Regexp(t, regexp.MustCompile(`^[0-9A-F]{8}-[0-9A-F]{4}-4[0-9A-F]{3}-[89AB][0-9A-F]{3}-[0-9A-F]{12}$`), profile.PayloadUUID)
+
+	pppc := profile.PayloadContent[0].Header()
+	assert.Equal(t, 1, pppc.PayloadVersion)
+	assert.Equal(t, "com.example.security."+pppc.PayloadUUID, pppc.PayloadIdentifier)
+
+	data, err := profile.Encode()
+	assert.Nil(t, err)
+	parsed, err := mobileconfig.Parse(data)
+	assert.Nil(t, err)
+	assert.Equal(t, "/usr/local/bin/agent", mobileconfig.PayloadsOf[*mobileconfig.PPPC](parsed)[0].Services[mobileconfig.SystemPolicyAllFiles][0].Identifier)
+	assert.Equal(t, []string{"PayloadType", "PayloadVersion", "PayloadIdentifier", "PayloadUUID", "PayloadDisplayName", "PayloadContent"}, parsed.Raw.Keys())
+}
+
+func TestValidateProfile(t *testing.T) {
+	testCases := map[string]mobileconfig.Payload{
+		"payload 0 (com.apple.TCC.configuration-profile-policy): Camera rule 0: code requirement is required for com.example.agent": mobileconfig.NewPPPC().
+			Grant(mobileconfig.Camera, &mobileconfig.PPPCRule{Identifier: "com.example.agent", IdentifierType: mobileconfig.BundleID, Allowed: mobileconfig.Ptr(true)}),
+		"Camera rule 0: com.example.agent requires either Allowed or Authorization": mobileconfig.NewPPPC().
+			Grant(mobileconfig.Camera, &mobileconfig.PPPCRule{Identifier: "com.example.agent", IdentifierType: mobileconfig.BundleID, CodeRequirement: "anchor apple"}),
+		"AllowStandardUserToSetSystemService authorization is only valid for ListenEvent and ScreenCapture": mobileconfig.NewPPPC().
+			Grant(mobileconfig.Camera, &mobileconfig.PPPCRule{Identifier: "a", IdentifierType: mobileconfig.BundleID, CodeRequirement: "anchor apple", Authorization: mobileconfig.AllowStandardUserToSetSystemService}),
+		"AppleEvents rules require the receiver identifier and code requirement": mobileconfig.NewPPPC().
+			Grant(mobileconfig.AppleEvents, &mobileconfig.PPPCRule{Identifier: "a", IdentifierType: mobileconfig.BundleID, CodeRequirement: "anchor apple", Authorization: mobileconfig.Allow}),
+		"bundle is not a valid identifier type must be of type [ bundleID, path ]": mobileconfig.NewPPPC().
+			Grant(mobileconfig.Camera, &mobileconfig.PPPCRule{Identifier: "a", IdentifierType: "bundle", CodeRequirement: "anchor apple", Authorization: mobileconfig.Allow}),
+		"example is not a valid team identifier":              mobileconfig.NewSystemExtensions().Allow("example", "com.example.agent.extension"),
+		"notification setting 0 requires a bundle identifier": mobileconfig.NewNotifications(&mobileconfig.NotificationSetting{}),
+		"Launchd is not a valid rule type must be of type [ BundleIdentifier, BundleIdentifierPrefix, Label, LabelPrefix, TeamIdentifier ]": mobileconfig.NewLoginItems(&mobileconfig.LoginItemRule{RuleType: "Launchd", RuleValue: "com.example.agent"}),
+		"payload 0 requires a PayloadType": &mobileconfig.GenericPayload{},
+	}
+	for expected, payload := range testCases {
+		err := mobileconfig.NewProfile("com.example", "Example").Add(payload).Validate()
+		assert.NotNil(t, err, expected)
+		if err != nil {
+			assert.Contains(t, err.Error(), expected)
+		}
+	}
+}
+
+type dockPayload struct {
+	Raw *plist.Dict `plist:",raw"`
+	mobileconfig.PayloadHeader
+	Autohide bool `plist:"autohide"`
+}
+
+func TestRegisterPayload(t *testing.T) {
+	mobileconfig.RegisterPayload("com.apple.dock", func() mobileconfig.Payload { return &dockPayload{} })
+	assert.Contains(t, mobileconfig.PayloadTypes(), "com.apple.dock")
+
+	profile, err := mobileconfig.Parse([]byte(agentProfile))
+	assert.Nil(t, err)
+	dock := mobileconfig.PayloadsOf[*dockPayload](profile)
+	assert.Len(t, dock, 1)
+	assert.True(t, dock[0].Autohide)
+
+	_, err = mobileconfig.Parse([]byte(`<plist><dict><key>PayloadContent</key><array><dict><key>PayloadType</key><string>com.apple.dock</string><key>autohide</key><string>yes</string></dict></array></dict></plist>`))
+	assert.NotNil(t, err)
+	assert.Equal(t, "payload 0 (com.apple.dock): autohide: value of type (string) can not be stored in a field of type (bool)", err.Error())
+}
@@ -0,0 +1,178 @@
+// Unless explicitly stated otherwise all files in this repository are licensed under the Apache-2.0
+// This product includes software developed at Datadog (https://www.datadoghq.com/). Copyright 2020 Datadog, Inc.
+
+package mobileconfig
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+var teamIdentifierPattern = regexp.MustCompile(`^[A-Z0-9]{10}$`)
+
+// Validate orchestrates configuration profile validation, every payload requires a payload type and
+// typed payloads are validated using their Validate method
+func (p *Profile) Validate() error {
+	for i, payload := range p.PayloadContent {
+		if payload == nil {
+			return fmt.Errorf("payload %d is empty", i)
+		}
+		payloadType := payload.Header().PayloadType
+		if strings.TrimSpace(payloadType) == "" {
+			return fmt.Errorf("payload %d requires a PayloadType", i)
+		}
+		if v, ok := payload.(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return fmt.Errorf("payload %d (%s): %s", i, payloadType, err.Error())
+			}
+		}
+	}
+	return nil
+}
+
+// Validate will validate every rule of a PPPC payload
+func (p *PPPC) Validate() error {
+	for service, rules := range p.Services {
+		for i, rule := range rules {
+			if err := rule.validate(service); err != nil {
+				return fmt.Errorf("%s rule %d: %s", service, i, err.Error())
+			}
+		}
+	}
+	return nil
+}
+
+func (r *PPPCRule) validate(service TCCService) error {
+	if r == nil {
+		return fmt.Errorf("rule is empty")
+	}
+	if strings.TrimSpace(r.Identifier) == "" {
+		return fmt.Errorf("identifier is required")
+	}
+	if err := r.IdentifierType.Validate(); err != nil {
+		return err
+	}
+	if strings.TrimSpace(r.CodeRequirement) == "" {
+		return fmt.Errorf("code requirement is required for %s", r.Identifier)
+	}
+
+	switch r.Authorization {
+	case "":
+		if r.Allowed == nil {
+			return fmt.Errorf("%s requires either Allowed or Authorization", r.Identifier)
+		}
+	case Allow, Deny:
+	case AllowStandardUserToSetSystemService:
+		if service != ListenEvent && service != ScreenCapture {
+			return fmt.Errorf("%s authorization is only valid for %s and %s", r.Authorization, ListenEvent, ScreenCapture)
+		}
+	default:
+		return fmt.Errorf("%s is not a valid authorization must be of type [ %s, %s, %s ]", r.Authorization, Allow, Deny, AllowStandardUserToSetSystemService)
+	}
+
+	if service == AppleEvents {
+		if strings.TrimSpace(r.AEReceiverIdentifier) == "" || strings.TrimSpace(r.AEReceiverCodeRequirement) == "" {
+			return fmt.Errorf("%s rules require the receiver identifier and code requirement", AppleEvents)
+		}
+		if err := r.AEReceiverIdentifierType.Validate(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Validate will validate that an identifier type is supported by PPPC payloads
+func (t IdentifierType) Validate() error {
+	switch t {
+	case BundleID, Path:
+		return nil
+	default:
+		return fmt.Errorf("%s is not a valid identifier type must be of type [ %s, %s ]", t, BundleID, Path)
+	}
+}
+
+// Validate will validate the team identifiers and extension types of a system extension policy
+func (s *SystemExtensions) Validate() error {
+	for _, team := range s.AllowedTeamIdentifiers {
+		if err := validateTeamIdentifier(team); err != nil {
+			return err
+		}
+	}
+	for _, m := range []map[string][]string{s.AllowedSystemExtensions, s.RemovableSystemExtensions} {
+		for team := range m {
+			if err := validateTeamIdentifier(team); err != nil {
+				return err
+			}
+		}
+	}
+	for team, types := range s.AllowedSystemExtensionTypes {
+		if err := validateTeamIdentifier(team); err != nil {
+			return err
+		}
+		for _, t := range types {
+			if err := t.Validate(); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// Validate will validate that a system extension type is supported by macOS
+func (t SystemExtensionType) Validate() error {
+	switch t {
+	case DriverExtension, NetworkExtension, EndpointSecurityExtension:
+		return nil
+	default:
+		return fmt.Errorf("%s is not a valid system extension type must be of type [ %s, %s, %s ]", t, DriverExtension, NetworkExtension, EndpointSecurityExtension)
+	}
+}
+
+// validateTeamIdentifier allows the 10 character Apple developer team identifiers
+func validateTeamIdentifier(team string) error {
+	if !teamIdentifierPattern.MatchString(team) {
+		return fmt.Errorf("%s is not a valid team identifier", team)
+	}
+	return nil
+}
+
+// Validate will validate that every notification setting names its application
+func (n *Notifications) Validate() error {
+	for i, setting := range n.NotificationSettings {
+		if setting == nil || strings.TrimSpace(setting.BundleIdentifier) == "" {
+			return fmt.Errorf("notification setting %d requires a bundle identifier", i)
+		}
+		if setting.AlertType != nil && (*setting.AlertType < NoAlert || *setting.AlertType > PersistentAlert) {
+			return fmt.Errorf("%d is not a valid alert type must be of type [ 0 (none), 1 (temporary), 2 (persistent) ]", *setting.AlertType)
+		}
+		if setting.PreviewType != nil && (*setting.PreviewType < PreviewAlways || *setting.PreviewType > PreviewNever) {
+			return fmt.Errorf("%d is not a valid preview type must be of type [ 0 (always), 1 (when unlocked), 2 (never) ]", *setting.PreviewType)
+		}
+	}
+	return nil
+}
+
+// Validate will validate every rule of a managed login items payload
+func (l *LoginItems) Validate() error {
+	for i, rule := range l.Rules {
+		if rule == nil || strings.TrimSpace(rule.RuleValue) == "" {
+			return fmt.Errorf("login item rule %d requires a rule value", i)
+		}
+		if err := rule.RuleType.Validate(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Validate will validate that a login item rule type is supported by macOS
+func (t RuleType) Validate() error {
+	switch t {
+	case BundleIdentifierRule, BundleIdentifierPrefixRule, LabelRule, LabelPrefixRule, TeamIdentifierRule:
+		return nil
+	default:
+		return fmt.Errorf("%s is not a valid rule type must be of type [ %s, %s, %s, %s, %s ]", t,
+			BundleIdentifierRule, BundleIdentifierPrefixRule, LabelRule, LabelPrefixRule, TeamIdentifierRule)
+	}
+}
@@ -0,0 +1,141 @@
+// Unless explicitly stated otherwise all files in this repository are licensed under the Apache-2.0
+// This product includes software developed at Datadog (https://www.datadoghq.com/). Copyright 2020 Datadog, Inc.
+
+// Package plist reads and writes XML property lists such as the payloads of configuration profiles.
+// Values are decoded as string, int64, float64, bool, time.Time, []byte, []interface{} and *Dict.
+// Dictionaries keep their keys in order so a property list can be edited and written back without
+// losing or reordering keys the caller does not know about
+package plist
+
+// Dict is a property list dictionary which keeps its keys in the order they were read or set
+type Dict struct {
+	keys   []string
+	values map[string]interface{}
+}
+
+// NewDict returns an empty dictionary
+func NewDict() *Dict {
+	return &Dict{values: map[string]interface{}{}}
+}
+
+// Clone returns a copy of the dictionary. Nested dictionaries and arrays are shared with the copy
+func (d *Dict) Clone() *Dict {
+	clone := NewDict()
+	if d == nil {
+		return clone
+	}
+	clone.keys = append(clone.keys, d.keys...)
+	for k, v := range d.values {
+		clone.values[k] = v
+	}
+	return clone
+}
+
+// Len returns the number of keys in the dictionary
+func (d *Dict) Len() int {
+	if d == nil {
+		return 0
+	}
+	return len(d.keys)
+}
+
+// Keys returns the keys of the dictionary in order
+func (d *Dict) Keys() []string {
+	if d == nil {
+		return nil
+	}
+	return append([]string(nil), d.keys...)
+}
+
+// Get returns the value of a key
+func (d *Dict) Get(key string) (interface{}, bool) {
+	if d == nil {
+		return nil, false
+	}
+	v, ok := d.values[key]
+	return v, ok
+}
+
+// Set adds or replaces the value of a key. New keys are added after the existing keys. A new
+// dictionary holding the key is returned when d is nil
+func (d *Dict) Set(key string, value interface{}) *Dict {
+	if d == nil {
+		d = NewDict()
+	}
+	if d.values == nil {
+		d.values = map[string]interface{}{}
+	}
+	if _, ok := d.values[key]; !ok {
+		d.keys = append(d.keys, key)
+	}
+	d.values[key] = value
+	return d
+}
+
+// Delete removes a key
+func (d *Dict) Delete(key string) {
+	if d == nil {
+		return
+	}
+	if _, ok := d.values[key]; !ok {
+		return
+	}
+	delete(d.values, key)
+	for i, k := range d.keys {
+		if k == key {
+			d.keys = append(d.keys[:i], d.keys[i+1:]...)
+			break
+		}
+	}
+}
+
+// String returns the value of a key when it is a string
+func (d *Dict) String(key string) string {
+	v, _ := d.Get(key)
+	s, _ := v.(string)
+	return s
+}
+
+// Bool returns the value of a key when it is a boolean
+func (d *Dict) Bool(key string) bool {
+	v, _ := d.Get(key)
+	b, _ := v.(bool)
+	return b
+}
+
+// Int returns the value of a key when it is an integer
+func (d *Dict) Int(key string) int64 {
+	v, _ := d.Get(key)
+	i, _ := v.(int64)
+	return i
+}
+
+// Dict returns the value of a key when it is a dictionary
+func (d *Dict) Dict(key string) *Dict {
+	v, _ := d.Get(key)
+	dict, _ := v.(*Dict)
+	return dict
+}
+
+// Array returns the value of a key when it is an array
+func (d *Dict) Array(key string) []interface{} {
+	v, _ := d.Get(key)
+	a, _ := v.([]interface{})
+	return a
+}
+
+// Dicts returns the dictionaries held in the array value of a key, ex. PayloadContent
+func (d *Dict) Dicts(key string) []*Dict {
+	v, _ := d.Get(key)
+	if dicts, ok := v.([]*Dict); ok {
+		return dicts
+	}
+
+	var dicts []*Dict
+	for _, v := range d.Array(key) {
+		if dict, ok := v.(*Dict); ok {
+			dicts = append(dicts, dict)
+		}
+	}
+	return dicts
+}
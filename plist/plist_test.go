@@ -0,0 +1,139 @@
+// Unless explicitly stated otherwise all files in this repository are licensed under the Apache-2.0
+// This product includes software developed at Datadog (https://www.datadoghq.com/). Copyright 2020 Datadog, Inc.
+
+package plist_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/trustero/jamf-api-client-go/plist"
+)
+
+const mobileconfig = `<?xml version="1.0" encoding="UTF-8"?>
+<!DOCTYPE plist PUBLIC "-//Apple//DTD PLIST 1.0//EN" "http://www.apple.com/DTDs/PropertyList-1.0.dtd">
+<plist version="1.0">
+<dict>
+	<key>PayloadContent</key>
+	<array>
+		<dict>
+			<key>PayloadType</key>
+			<string>com.apple.notificationsettings</string>
+			<key>NotificationSettings</key>
+			<array>
+				<dict>
+					<key>BundleIdentifier</key>
+					<string>com.tinyspeck.slackmacgap</string>
+					<key>AlertType</key>
+					<integer>1</integer>
+					<key>BadgesEnabled</key>
+					<true/>
+				</dict>
+			</array>
+			<key>VendorExtra</key>
+			<real>1.5</real>
+		</dict>
+	</array>
+	<key>PayloadDisplayName</key>
+	<string>Notifications &amp; Alerts</string>
+	<key>PayloadRemovalDisallowed</key>
+	<false/>
+	<key>ConsentText</key>
+	<dict/>
+	<key>Created</key>
+	<date>2024-03-01T10:00:00Z</date>
+	<key>Icon</key>
+	<data>
+	aWNvbg==
+	</data>
+</dict>
+</plist>
+`
+
+func TestDecodePropertyList(t *testing.T) {
+	dict, err := plist.DecodeDict([]byte(mobileconfig))
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"PayloadContent", "PayloadDisplayName", "PayloadRemovalDisallowed", "ConsentText", "Created", "Icon"}, dict.Keys())
+	assert.Equal(t, "Notifications & Alerts", dict.String("PayloadDisplayName"))
+	assert.False(t, dict.Bool("PayloadRemovalDisallowed"))
+	assert.Equal(t, 0, dict.Dict("ConsentText").Len())
+	created, _ := dict.Get("Created")
+	assert.Equal(t, time.Date(2024, 3, 1, 10, 0, 0, 0, time.UTC), created)
+	icon, _ := dict.Get("Icon")
+	assert.Equal(t, []byte("icon"), icon)
+
+	payloads := dict.Dicts("PayloadContent")
+	assert.Len(t, payloads, 1)
+	assert.Equal(t, "com.apple.notificationsettings", payloads[0].String("PayloadType"))
+	extra, _ := payloads[0].Get("VendorExtra")
+	assert.Equal(t, 1.5, extra)
+	setting := payloads[0].Dicts("NotificationSettings")[0]
+	assert.Equal(t, int64(1), setting.Int("AlertType"))
+	assert.True(t, setting.Bool("BadgesEnabled"))
+}
+
+func TestEncodePropertyListRoundTrip(t *testing.T) {
+	dict, err := plist.DecodeDict([]byte(mobileconfig))
+	assert.Nil(t, err)
+
+	data, err := plist.Encode(dict)
+	assert.Nil(t, err)
+	assert.Equal(t, mobileconfig[:len(mobileconfig)-len("\t<data>\n\taWNvbg==\n\t</data>\n</dict>\n</plist>\n")]+
+		"\t<data>aWNvbg==</data>\n</dict>\n</plist>\n", string(data))
+
+	decoded, err := plist.DecodeDict(data)
+	assert.Nil(t, err)
+	assert.Equal(t, dict, decoded)
+}
+
+func TestEditPropertyList(t *testing.T) {
+	dict := plist.NewDict().
+		Set("PayloadIdentifier", "com.example.profile").
+		Set("PayloadVersion", 1).
+		Set("Services", map[string]interface{}{"Camera": []string{"zoom"}, "Accessibility": []*plist.Dict{}})
+	dict.Set("PayloadIdentifier", "com.example.renamed")
+	dict.Delete("PayloadVersion")
+	dict.Delete("Missing")
+
+	data, err := plist.Encode(dict)
+	assert.Nil(t, err)
+	assert.Contains(t, string(data), "<dict>\n"+
+		"\t<key>PayloadIdentifier</key>\n\t<string>com.example.renamed</string>\n"+
+		"\t<key>Services</key>\n\t<dict>\n"+
+		"\t\t<key>Accessibility</key>\n\t\t<array/>\n"+
+		"\t\t<key>Camera</key>\n\t\t<array>\n\t\t\t<string>zoom</string>\n\t\t</array>\n"+
+		"\t</dict>\n</dict>\n")
+
+	// setting a key on a nil dictionary returns a new dictionary
+	var empty *plist.Dict
+	assert.Equal(t, "com.example.profile", empty.Set("PayloadIdentifier", "com.example.profile").String("PayloadIdentifier"))
+
+	// integers are always decimal, leading zeros do not make them octal
+	dict, err = plist.DecodeDict([]byte("<plist><dict><key>a</key><integer>010</integer></dict></plist>"))
+	assert.Nil(t, err)
+	assert.Equal(t, int64(10), dict.Int("a"))
+
+	_, err = plist.Encode(plist.NewDict().Set("Bad", struct{}{}))
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "Bad: value of type (struct {}) can not be written to a property list")
+}
+
+func TestDecodeInvalidPropertyList(t *testing.T) {
+	testCases := map[string]string{
+		"<plist><dict><string>x</string></dict></plist>":                  "property list dictionary has a string without a key",
+		"<plist><dict><key>a</key></dict></plist>":                        "property list dictionary key a has no value",
+		"<plist><dict><key>a</key><integer>x</integer></dict></plist>":    "a: x is not a valid property list integer",
+		"<plist><dict><key>a</key><integer>0x10</integer></dict></plist>": "a: 0x10 is not a valid property list integer",
+		"<plist><dict><key>a</key><color>red</color></dict></plist>":      "a: color is not a valid property list element",
+		"<plist></plist>": "property list holds no value",
+		"<plist><array><string>a</string></array></plist>": "",
+	}
+	for doc, expected := range testCases {
+		_, err := plist.DecodeDict([]byte(doc))
+		assert.NotNil(t, err, doc)
+		if expected != "" {
+			assert.Contains(t, err.Error(), expected, doc)
+		}
+	}
+}
@@ -0,0 +1,197 @@
+// Unless explicitly stated otherwise all files in this repository are licensed under the Apache-2.0
+// This product includes software developed at Datadog (https://www.datadoghq.com/). Copyright 2020 Datadog, Inc.
+
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	classic "github.com/trustero/jamf-api-client-go/classic/client"
+)
+
+// tokenPath is the Jamf Pro API endpoint used to exchange basic auth credentials for a bearer token
+const tokenPath = "api/v1/auth/token"
+
+// tokenExpiryBuffer is subtracted from a token's expiration so it is refreshed before Jamf rejects it
+const tokenExpiryBuffer = time.Minute
+
+// Client represents the interface used to communicate with
+// the Jamf Pro API via an HTTP client
+type Client struct {
+	Domain   string
+	Username string
+	Password string
+	Endpoint string
+	Api      *http.Client
+
+	mu    sync.Mutex
+	token *Token
+}
+
+// Token represents a Jamf Pro API bearer token
+type Token struct {
+	Token   string    `json:"token"`
+	Expires time.Time `json:"expires"`
+}
+
+// Valid returns true when the token exists and has not expired
+func (t *Token) Valid() bool {
+	return t != nil && t.Token != "" && time.Now().Add(tokenExpiryBuffer).Before(t.Expires)
+}
+
+// NewDomainClient returns a new Jamf Pro HTTP client to be used for API requests against the
+// versioned domain provided i.e v1/computers-inventory. If no HTTP client is passed the retrying
+// client of the classic API is configured, see classic.RetryingHTTPClient
+func NewDomainClient(baseUrl string, domain string, username string, password string, client *http.Client) (*Client, error) {
+	if baseUrl == "" || username == "" || password == "" {
+		return nil, errors.New("you must provide a valid Jamf base url, username, and password")
+	}
+
+	if client == nil {
+		client = classic.RetryingHTTPClient()
+	}
+
+	return &Client{
+		Domain:   baseUrl,
+		Username: username,
+		Password: password,
+		Endpoint: fmt.Sprintf("%s/api/%s", baseUrl, domain),
+		Api:      client,
+	}, nil
+}
+
+// IdEndpoint can be utilized to query a specific API context via Id
+func (j *Client) IdEndpoint(identifier string) string {
+	return fmt.Sprintf("%s/%s", j.Endpoint, identifier)
+}
+
+// BearerToken returns a valid bearer token requesting a new one from Jamf if needed
+func (j *Client) BearerToken(ctx context.Context) (string, error) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	if j.token.Valid() {
+		return j.token.Token, nil
+	}
+
+	ep := fmt.Sprintf("%s/%s", j.Domain, tokenPath)
+	req, err := http.NewRequestWithContext(ctx, "POST", ep, nil)
+	if err != nil {
+		return "", errors.Wrap(err, "error building Jamf Pro token request")
+	}
+	req.Header.Set("Accept", "application/json")
+	req.SetBasicAuth(j.Username, j.Password)
+
+	token := &Token{}
+	if _, err := do(j, req, token); err != nil {
+		return "", errors.Wrapf(err, "unable to retrieve Jamf Pro API token from %s", ep)
+	}
+	j.token = token
+	return token.Token, nil
+}
+
+// NewRequest builds a request with a JSON encoded body when one is provided
+func NewRequest(ctx context.Context, method string, ep string, body interface{}) (*http.Request, error) {
+	if body == nil {
+		return http.NewRequestWithContext(ctx, method, ep, nil)
+	}
+
+	bodyContent, err := json.Marshal(body)
+	if err != nil {
+		return nil, errors.Wrapf(err, "error building Jamf Pro %s payload for %s", method, ep)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, ep, bytes.NewReader(bodyContent))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	return req, nil
+}
+
+// MakeAPIrequest authenticates and sends the request decoding the JSON response into v
+func MakeAPIrequest(j *Client, r *http.Request, v interface{}) (*http.Response, error) {
+	token, err := j.BearerToken(r.Context())
+	if err != nil {
+		return nil, err
+	}
+
+	// Jamf Pro API only supports JSON bodies
+	r.Header.Set("Accept", "application/json")
+	r.Header.Set("Cache-Control", "no-store, no-cache, must-revalidate, max-age=0, post-check=0, pre-check=0")
+	r.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+
+	res, err := do(j, r, v)
+	if res != nil && res.StatusCode == http.StatusUnauthorized {
+		// the token may have been invalidated server side so force a refresh on the next request
+		j.mu.Lock()
+		j.token = nil
+		j.mu.Unlock()
+	}
+	return res, err
+}
+
+// APIError represents the error body returned by the Jamf Pro API
+type APIError struct {
+	HTTPStatus int              `json:"httpStatus"`
+	Errors     []APIErrorDetail `json:"errors"`
+}
+
+// APIErrorDetail holds a single error cause returned by the Jamf Pro API
+type APIErrorDetail struct {
+	Code        string `json:"code"`
+	Field       string `json:"field"`
+	Description string `json:"description"`
+	ID          string `json:"id"`
+}
+
+func (e *APIError) Error() string {
+	details := make([]string, 0, len(e.Errors))
+	for _, d := range e.Errors {
+		if d.Field != "" {
+			details = append(details, fmt.Sprintf("%s (%s): %s", d.Code, d.Field, d.Description))
+			continue
+		}
+		details = append(details, fmt.Sprintf("%s: %s", d.Code, d.Description))
+	}
+	return fmt.Sprintf("request error: %d %s", e.HTTPStatus, strings.Join(details, "; "))
+}
+
+func do(j *Client, r *http.Request, v interface{}) (*http.Response, error) {
+	res, err := j.Api.Do(r)
+	if err != nil {
+		return res, errors.Wrapf(err, "error making %s request to %s", r.Method, r.URL)
+	}
+	defer res.Body.Close()
+
+	responseData, err := ioutil.ReadAll(res.Body)
+	if err != nil {
+		return res, errors.Wrapf(err, "request error: %s. unable to read response body", res.Status)
+	}
+
+	if res.StatusCode < 200 || res.StatusCode > 299 {
+		apiErr := &APIError{}
+		if err := json.Unmarshal(responseData, apiErr); err == nil && len(apiErr.Errors) > 0 {
+			return res, apiErr
+		}
+		return res, fmt.Errorf("request error: %s %s", res.Status, string(responseData))
+	}
+
+	if v == nil || len(responseData) == 0 {
+		return res, nil
+	}
+
+	if err = json.Unmarshal(responseData, v); err != nil {
+		return res, errors.Wrapf(err, "response was successful but error occured decoding response body of type %s", res.Header.Get("Content-Type"))
+	}
+	return res, nil
+}
@@ -0,0 +1,152 @@
+// Unless explicitly stated otherwise all files in this repository are licensed under the Apache-2.0
+// This product includes software developed at Datadog (https://www.datadoghq.com/). Copyright 2020 Datadog, Inc.
+package client_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	jamf "github.com/trustero/jamf-api-client-go/pro/client"
+	"github.com/trustero/jamf-api-client-go/pro/rsql"
+)
+
+type mockItem struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+func proResponseMocks(t *testing.T, tokenRequests *int) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/api/v1/auth/token" {
+			*tokenRequests++
+			user, pwd, ok := r.BasicAuth()
+			assert.True(t, ok)
+			assert.Equal(t, "fake-username", user)
+			assert.Equal(t, "mock-password-cool", pwd)
+			fmt.Fprintf(w, `{"token": "mock-token", "expires": "%s"}`, time.Now().Add(time.Hour).UTC().Format(time.RFC3339))
+			return
+		}
+
+		assert.Equal(t, "Bearer mock-token", r.Header.Get("Authorization"))
+		switch r.URL.Path {
+		case "/api/v1/mock":
+			assert.Equal(t, "general.name==\"Test Mac\"", r.URL.Query().Get("filter"))
+			assert.Equal(t, "2", r.URL.Query().Get("page-size"))
+			switch r.URL.Query().Get("page") {
+			case "0":
+				fmt.Fprint(w, `{"totalCount": 3, "results": [{"id": "1", "name": "one"}, {"id": "2", "name": "two"}]}`)
+			case "1":
+				fmt.Fprint(w, `{"totalCount": 3, "results": [{"id": "3", "name": "three"}]}`)
+			default:
+				t.Errorf("unexpected page requested %s", r.URL.Query().Get("page"))
+			}
+		case "/api/v1/mock/missing":
+			w.WriteHeader(http.StatusNotFound)
+			fmt.Fprint(w, `{"httpStatus": 404, "errors": [{"code": "INVALID_ID", "description": "Object with id 99 does not exist", "id": "99", "field": "id"}]}`)
+		default:
+			http.Error(w, fmt.Sprintf("bad Jamf Pro API call to %s", r.URL), http.StatusInternalServerError)
+		}
+	}))
+}
+
+func TestNewDomainClient(t *testing.T) {
+	j, err := jamf.NewDomainClient("https://mock.test.com", "v1/mock", "fake-username", "mock-password-cool", nil)
+	assert.Nil(t, err)
+	assert.Equal(t, "https://mock.test.com/api/v1/mock", j.Endpoint)
+	assert.Equal(t, "https://mock.test.com/api/v1/mock/12", j.IdEndpoint("12"))
+
+	_, err = jamf.NewDomainClient("https://mock.test.com", "v1/mock", "", "mock-password-cool", nil)
+	assert.NotNil(t, err)
+	assert.Equal(t, "you must provide a valid Jamf base url, username, and password", err.Error())
+}
+
+func TestNewPager(t *testing.T) {
+	tokenRequests := 0
+	testServer := proResponseMocks(t, &tokenRequests)
+	defer testServer.Close()
+
+	j, err := jamf.NewDomainClient(testServer.URL, "v1/mock", "fake-username", "mock-password-cool", nil)
+	assert.Nil(t, err)
+
+	opts := &jamf.ListOptions{PageSize: 2, Filter: rsql.Eq("general.name", "Test Mac")}
+	items, err := jamf.NewPager[mockItem](j, j.Endpoint, opts).All(context.Background())
+	assert.Nil(t, err)
+	assert.Len(t, items, 3)
+	assert.Equal(t, "three", items[2].Name)
+	// the bearer token is reused until it expires
+	assert.Equal(t, 1, tokenRequests)
+
+	// stopping after the first item does not request the second page
+	it := jamf.NewPager[mockItem](j, j.Endpoint, opts)
+	assert.True(t, it.Next(context.Background()))
+	it.Stop()
+	assert.False(t, it.Next(context.Background()))
+	total, ok := it.TotalCount()
+	assert.True(t, ok)
+	assert.Equal(t, 3, total)
+}
+
+func TestRetry(t *testing.T) {
+	tokenRequests, attempts := 0, 0
+	testServer := proResponseMocks(t, &tokenRequests)
+	defer testServer.Close()
+	busyServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if attempts++; attempts < 3 {
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		testServer.Config.Handler.ServeHTTP(w, r)
+	}))
+	defer busyServer.Close()
+
+	// the token request is retried by the default client
+	j, err := jamf.NewDomainClient(busyServer.URL, "v1/mock", "fake-username", "mock-password-cool", nil)
+	assert.Nil(t, err)
+	_, err = j.BearerToken(context.Background())
+	assert.Nil(t, err)
+	assert.Equal(t, 3, attempts)
+	assert.Equal(t, 1, tokenRequests)
+}
+
+func TestAPIError(t *testing.T) {
+	tokenRequests := 0
+	testServer := proResponseMocks(t, &tokenRequests)
+	defer testServer.Close()
+
+	j, err := jamf.NewDomainClient(testServer.URL, "v1/mock", "fake-username", "mock-password-cool", nil)
+	assert.Nil(t, err)
+
+	req, err := jamf.NewRequest(context.Background(), "GET", j.IdEndpoint("missing"), nil)
+	assert.Nil(t, err)
+	res, err := jamf.MakeAPIrequest(j, req, &mockItem{})
+	assert.NotNil(t, err)
+	assert.Equal(t, http.StatusNotFound, res.StatusCode)
+
+	apiErr, ok := err.(*jamf.APIError)
+	assert.True(t, ok)
+	assert.Equal(t, "INVALID_ID", apiErr.Errors[0].Code)
+	assert.True(t, strings.HasPrefix(err.Error(), "request error: 404 INVALID_ID (id)"))
+}
+
+func TestListOptionsValues(t *testing.T) {
+	var opts *jamf.ListOptions
+	assert.Equal(t, "page=0&page-size=100", opts.Values().Encode())
+
+	opts = &jamf.ListOptions{
+		Page:  3,
+		Sort:  rsql.SortBy("general.name", rsql.Asc).Then("id", rsql.Desc),
+		Extra: map[string][]string{"section": {"GENERAL", "HARDWARE"}},
+	}
+	q := opts.Values()
+	assert.Equal(t, "3", q.Get("page"))
+	assert.Equal(t, "100", q.Get("page-size"))
+	assert.Equal(t, "general.name:asc,id:desc", q.Get("sort"))
+	assert.Equal(t, []string{"GENERAL", "HARDWARE"}, q["section"])
+}
@@ -0,0 +1,126 @@
+// Unless explicitly stated otherwise all files in this repository are licensed under the Apache-2.0
+// This product includes software developed at Datadog (https://www.datadoghq.com/). Copyright 2020 Datadog, Inc.
+
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/pkg/errors"
+	"github.com/trustero/jamf-api-client-go/pager"
+	"github.com/trustero/jamf-api-client-go/pro/rsql"
+)
+
+// DefaultPageSize is the page size used when one is not provided. Jamf Pro defaults to 100
+const DefaultPageSize = 100
+
+// ListOptions holds the query parameters shared by the paginated Jamf Pro list endpoints
+type ListOptions struct {
+	Page     int
+	PageSize int
+	Sort     *rsql.Sort
+	Filter   rsql.Filter
+	// Extra holds any endpoint specific query parameters i.e section
+	Extra url.Values
+}
+
+// Values returns the list options encoded as query parameters
+func (o *ListOptions) Values() url.Values {
+	q := url.Values{}
+	if o == nil {
+		q.Set("page", "0")
+		q.Set("page-size", strconv.Itoa(DefaultPageSize))
+		return q
+	}
+
+	for k, v := range o.Extra {
+		q[k] = append([]string{}, v...)
+	}
+
+	pageSize := o.PageSize
+	if pageSize <= 0 {
+		pageSize = DefaultPageSize
+	}
+	q.Set("page", strconv.Itoa(o.Page))
+	q.Set("page-size", strconv.Itoa(pageSize))
+
+	if o.Sort != nil && o.Sort.String() != "" {
+		q.Set("sort", o.Sort.String())
+	}
+
+	if o.Filter != nil && o.Filter.String() != "" {
+		q.Set("filter", o.Filter.String())
+	}
+	return q
+}
+
+// Page represents a single page of results returned by a Jamf Pro list endpoint
+type Page struct {
+	TotalCount int             `json:"totalCount"`
+	Results    json.RawMessage `json:"results"`
+}
+
+// Decode unmarshals the page results into v which should be a pointer to a slice
+func (p *Page) Decode(v interface{}) error {
+	if len(p.Results) == 0 {
+		return nil
+	}
+	return json.Unmarshal(p.Results, v)
+}
+
+// GetPage requests a single page from a Jamf Pro list endpoint
+func GetPage(ctx context.Context, j *Client, ep string, opts *ListOptions) (page *Page, response *http.Response, err error) {
+	u := fmt.Sprintf("%s?%s", ep, opts.Values().Encode())
+	req, err := NewRequest(ctx, "GET", u, nil)
+	if err != nil {
+		err = errors.Wrapf(err, "error building Jamf Pro list request for %s", ep)
+		return
+	}
+
+	res := &Page{}
+	if response, err = MakeAPIrequest(j, req, res); err != nil {
+		err = errors.Wrapf(err, "unable to query page %d from %s", opts.pageNumber(), ep)
+		return
+	}
+	page = res
+	return
+}
+
+func (o *ListOptions) pageNumber() int {
+	if o == nil {
+		return 0
+	}
+	return o.Page
+}
+
+// NewPager returns a pager over a Jamf Pro list endpoint decoding each result into T.
+// Pages are requested lazily starting at the page set in the options
+func NewPager[T any](j *Client, ep string, opts *ListOptions) *pager.Pager[T] {
+	current := ListOptions{}
+	if opts != nil {
+		current = *opts
+	}
+	if current.PageSize <= 0 {
+		current.PageSize = DefaultPageSize
+	}
+
+	return pager.NewFrom(func(ctx context.Context, page int) ([]T, int, bool, error) {
+		pageOpts := current
+		pageOpts.Page = page
+		p, _, err := GetPage(ctx, j, ep, &pageOpts)
+		if err != nil {
+			return nil, pager.UnknownTotal, false, err
+		}
+
+		var items []T
+		if err := p.Decode(&items); err != nil {
+			return nil, pager.UnknownTotal, false, errors.Wrapf(err, "unable to decode page %d from %s", page, ep)
+		}
+		return items, p.TotalCount, (page+1)*pageOpts.PageSize < p.TotalCount, nil
+	}, current.Page)
+}
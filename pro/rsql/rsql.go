@@ -0,0 +1,162 @@
+// Unless explicitly stated otherwise all files in this repository are licensed under the Apache-2.0
+// This product includes software developed at Datadog (https://www.datadoghq.com/). Copyright 2020 Datadog, Inc.
+
+// Package rsql builds the RSQL filter and sort expressions accepted by the Jamf Pro API
+// https://developer.jamf.com/jamf-pro/docs/filtering-with-rsql
+package rsql
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Operator represents an RSQL comparison operator
+type Operator string
+
+// Comparison operators supported by the Jamf Pro API
+const (
+	OpEqual          Operator = "=="
+	OpNotEqual       Operator = "!="
+	OpLessThan       Operator = "<"
+	OpLessOrEqual    Operator = "<="
+	OpGreaterThan    Operator = ">"
+	OpGreaterOrEqual Operator = ">="
+	OpIn             Operator = "=in="
+	OpNotIn          Operator = "=out="
+)
+
+// Filter represents an RSQL expression that can be passed as the filter query parameter
+type Filter interface {
+	String() string
+}
+
+// Comparison represents a single field comparison i.e general.name=="Test Mac"
+type Comparison struct {
+	Field    string
+	Operator Operator
+	Values   []string
+}
+
+func (c *Comparison) String() string {
+	values := make([]string, 0, len(c.Values))
+	for _, v := range c.Values {
+		values = append(values, Quote(v))
+	}
+
+	switch c.Operator {
+	case OpIn, OpNotIn:
+		return fmt.Sprintf("%s%s(%s)", c.Field, c.Operator, strings.Join(values, ","))
+	default:
+		return fmt.Sprintf("%s%s%s", c.Field, c.Operator, strings.Join(values, ","))
+	}
+}
+
+// Group represents a set of filters joined by a logical operator
+type Group struct {
+	Separator string
+	Filters   []Filter
+}
+
+func (g *Group) String() string {
+	parts := make([]string, 0, len(g.Filters))
+	for _, f := range g.Filters {
+		if f == nil || f.String() == "" {
+			continue
+		}
+		// nested groups are wrapped so precedence is preserved
+		if nested, ok := f.(*Group); ok && len(nested.Filters) > 1 {
+			parts = append(parts, fmt.Sprintf("(%s)", nested.String()))
+			continue
+		}
+		parts = append(parts, f.String())
+	}
+	return strings.Join(parts, g.Separator)
+}
+
+// Eq returns a filter matching field equal to value. Values may include * as a wildcard
+func Eq(field string, value string) Filter {
+	return &Comparison{Field: field, Operator: OpEqual, Values: []string{value}}
+}
+
+// Ne returns a filter matching field not equal to value
+func Ne(field string, value string) Filter {
+	return &Comparison{Field: field, Operator: OpNotEqual, Values: []string{value}}
+}
+
+// Lt returns a filter matching field less than value
+func Lt(field string, value string) Filter {
+	return &Comparison{Field: field, Operator: OpLessThan, Values: []string{value}}
+}
+
+// Le returns a filter matching field less than or equal to value
+func Le(field string, value string) Filter {
+	return &Comparison{Field: field, Operator: OpLessOrEqual, Values: []string{value}}
+}
+
+// Gt returns a filter matching field greater than value
+func Gt(field string, value string) Filter {
+	return &Comparison{Field: field, Operator: OpGreaterThan, Values: []string{value}}
+}
+
+// Ge returns a filter matching field greater than or equal to value
+func Ge(field string, value string) Filter {
+	return &Comparison{Field: field, Operator: OpGreaterOrEqual, Values: []string{value}}
+}
+
+// In returns a filter matching field equal to any of the values
+func In(field string, values ...string) Filter {
+	return &Comparison{Field: field, Operator: OpIn, Values: values}
+}
+
+// Out returns a filter matching field not equal to any of the values
+func Out(field string, values ...string) Filter {
+	return &Comparison{Field: field, Operator: OpNotIn, Values: values}
+}
+
+// And joins the filters so all of them must match
+func And(filters ...Filter) Filter {
+	return &Group{Separator: ";", Filters: filters}
+}
+
+// Or joins the filters so any of them may match
+func Or(filters ...Filter) Filter {
+	return &Group{Separator: ",", Filters: filters}
+}
+
+// Quote wraps a value in double quotes when it contains characters reserved by RSQL
+func Quote(value string) string {
+	if value != "" && !strings.ContainsAny(value, " \"'();,=!<>~\\") {
+		return value
+	}
+	escaped := strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(value)
+	return fmt.Sprintf("\"%s\"", escaped)
+}
+
+// Direction represents the order results are sorted in
+type Direction string
+
+// Sort directions supported by the Jamf Pro API
+const (
+	Asc  Direction = "asc"
+	Desc Direction = "desc"
+)
+
+// Sort represents an ordered set of sort criteria i.e general.name:asc,id:desc
+type Sort struct {
+	criteria []string
+}
+
+// SortBy returns a sort on the field in the direction provided
+func SortBy(field string, direction Direction) *Sort {
+	return (&Sort{}).Then(field, direction)
+}
+
+// Then adds a secondary sort criteria
+func (s *Sort) Then(field string, direction Direction) *Sort {
+	s.criteria = append(s.criteria, fmt.Sprintf("%s:%s", field, direction))
+	return s
+}
+
+func (s *Sort) String() string {
+	return strings.Join(s.criteria, ",")
+}
@@ -0,0 +1,43 @@
+// Unless explicitly stated otherwise all files in this repository are licensed under the Apache-2.0
+// This product includes software developed at Datadog (https://www.datadoghq.com/). Copyright 2020 Datadog, Inc.
+
+package rsql_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/trustero/jamf-api-client-go/pro/rsql"
+)
+
+func TestComparisons(t *testing.T) {
+	assert.Equal(t, "hardware.serialNumber==C02ABC123", rsql.Eq("hardware.serialNumber", "C02ABC123").String())
+	assert.Equal(t, "general.name==Mac*", rsql.Eq("general.name", "Mac*").String())
+	assert.Equal(t, "general.name!=\"Test Mac\"", rsql.Ne("general.name", "Test Mac").String())
+	assert.Equal(t, "id>=10", rsql.Ge("id", "10").String())
+	assert.Equal(t, "id<10", rsql.Lt("id", "10").String())
+	assert.Equal(t, "general.platform=in=(Mac,Windows)", rsql.In("general.platform", "Mac", "Windows").String())
+	assert.Equal(t, "id=out=(1,2)", rsql.Out("id", "1", "2").String())
+}
+
+func TestGroups(t *testing.T) {
+	f := rsql.And(
+		rsql.Eq("general.platform", "Mac"),
+		rsql.Or(rsql.Eq("userAndLocation.department", "IT"), rsql.Eq("userAndLocation.department", "Security")),
+	)
+	assert.Equal(t, "general.platform==Mac;(userAndLocation.department==IT,userAndLocation.department==Security)", f.String())
+
+	// empty and single filter groups are not wrapped
+	assert.Equal(t, "id==1", rsql.And(rsql.Or(rsql.Eq("id", "1")), rsql.And()).String())
+}
+
+func TestQuote(t *testing.T) {
+	assert.Equal(t, "\"\"", rsql.Quote(""))
+	assert.Equal(t, "\"say \\\"hi\\\"\"", rsql.Quote("say \"hi\""))
+	assert.Equal(t, "plain", rsql.Quote("plain"))
+}
+
+func TestSort(t *testing.T) {
+	assert.Equal(t, "general.name:asc", rsql.SortBy("general.name", rsql.Asc).String())
+	assert.Equal(t, "general.name:asc,id:desc", rsql.SortBy("general.name", rsql.Asc).Then("id", rsql.Desc).String())
+}
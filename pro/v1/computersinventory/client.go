@@ -0,0 +1,21 @@
+package computersinventory
+
+import (
+	"github.com/trustero/jamf-api-client-go/pro/client"
+	"net/http"
+)
+
+const domain = "v1/computers-inventory"
+
+type Service struct {
+	client *client.Client
+}
+
+func NewService(baseUrl string, username string, password string, httpClient *http.Client) (*Service, error) {
+	j, err := client.NewDomainClient(baseUrl, domain, username, password, httpClient)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Service{client: j}, nil
+}
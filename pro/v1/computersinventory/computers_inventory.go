@@ -0,0 +1,76 @@
+// Unless explicitly stated otherwise all files in this repository are licensed under the Apache-2.0
+// This product includes software developed at Datadog (https://www.datadoghq.com/). Copyright 2020 Datadog, Inc.
+
+package computersinventory
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/pkg/errors"
+	"github.com/trustero/jamf-api-client-go/pager"
+	"github.com/trustero/jamf-api-client-go/pro/client"
+)
+
+// ListResponse represents a single page of computer inventory records
+type ListResponse struct {
+	TotalCount int                 `json:"totalCount"`
+	Results    []ComputerInventory `json:"results"`
+}
+
+// List returns a single page of computer inventory records
+func (j *Service) List(opts *ListOptions) (result *ListResponse, response *http.Response, err error) {
+	page, response, err := client.GetPage(context.Background(), j.client, j.client.Endpoint, opts.listOptions())
+	if err != nil {
+		err = errors.Wrap(err, "unable to query computer inventory")
+		return
+	}
+
+	res := &ListResponse{TotalCount: page.TotalCount}
+	if err = page.Decode(&res.Results); err != nil {
+		err = errors.Wrapf(err, "unable to decode computer inventory from %s", j.client.Endpoint)
+		return
+	}
+	result = res
+	return
+}
+
+// ListAll returns every computer inventory record walking all pages
+func (j *Service) ListAll(opts *ListOptions) ([]ComputerInventory, error) {
+	return j.Iterate(opts).All(context.Background())
+}
+
+// GetById returns the inventory record for a specific computer given its Id. When no
+// sections are provided Jamf only returns the GENERAL section
+func (j *Service) GetById(identifier string, sections ...Section) (result *ComputerInventory, response *http.Response, err error) {
+	ep := j.client.IdEndpoint(identifier)
+	if len(sections) > 0 {
+		q := url.Values{}
+		for _, s := range sections {
+			q.Add("section", string(s))
+		}
+		ep = fmt.Sprintf("%s?%s", ep, q.Encode())
+	}
+
+	req, err := client.NewRequest(context.Background(), "GET", ep, nil)
+	if err != nil {
+		err = errors.Wrapf(err, "error building Jamf Pro computer inventory request for computer: %v (%s)", identifier, ep)
+		return
+	}
+
+	res := &ComputerInventory{}
+	if response, err = client.MakeAPIrequest(j.client, req, res); err != nil {
+		err = errors.Wrapf(err, "unable to query computer inventory for computer: %v (%s)", identifier, ep)
+		return
+	}
+	result = res
+	return
+}
+
+// Iterate returns a pager over every computer inventory record matching the options
+// requesting each page from Jamf only when the previous one is exhausted
+func (j *Service) Iterate(opts *ListOptions) *pager.Pager[ComputerInventory] {
+	return client.NewPager[ComputerInventory](j.client, j.client.Endpoint, opts.listOptions())
+}
@@ -0,0 +1,306 @@
+// Unless explicitly stated otherwise all files in this repository are licensed under the Apache-2.0
+// This product includes software developed at Datadog (https://www.datadoghq.com/). Copyright 2020 Datadog, Inc.
+
+package computersinventory
+
+// ComputerInventory represents a computer inventory record returned by the Jamf Pro API.
+// Only the sections requested are populated, all others are left nil
+type ComputerInventory struct {
+	ID                    string                 `json:"id"`
+	UDID                  string                 `json:"udid"`
+	General               *General               `json:"general,omitempty"`
+	DiskEncryption        *DiskEncryption        `json:"diskEncryption,omitempty"`
+	Purchasing            *Purchasing            `json:"purchasing,omitempty"`
+	Applications          []Application          `json:"applications,omitempty"`
+	Storage               *Storage               `json:"storage,omitempty"`
+	UserAndLocation       *UserAndLocation       `json:"userAndLocation,omitempty"`
+	ConfigurationProfiles []ConfigurationProfile `json:"configurationProfiles,omitempty"`
+	Printers              []Printer              `json:"printers,omitempty"`
+	Services              []RunningService       `json:"services,omitempty"`
+	Hardware              *Hardware              `json:"hardware,omitempty"`
+	LocalUserAccounts     []LocalUserAccount     `json:"localUserAccounts,omitempty"`
+	Certificates          []Certificate          `json:"certificates,omitempty"`
+	Security              *Security              `json:"security,omitempty"`
+	OperatingSystem       *OperatingSystem       `json:"operatingSystem,omitempty"`
+	PackageReceipts       *PackageReceipts       `json:"packageReceipts,omitempty"`
+	SoftwareUpdates       []SoftwareUpdate       `json:"softwareUpdates,omitempty"`
+	ExtensionAttributes   []ExtensionAttribute   `json:"extensionAttributes,omitempty"`
+	GroupMemberships      []GroupMembership      `json:"groupMemberships,omitempty"`
+}
+
+// General holds the GENERAL section of a computer inventory record
+type General struct {
+	Name                                 string               `json:"name"`
+	LastIPAddress                        string               `json:"lastIpAddress"`
+	LastReportedIP                       string               `json:"lastReportedIp"`
+	JamfBinaryVersion                    string               `json:"jamfBinaryVersion"`
+	Platform                             string               `json:"platform"`
+	Barcode1                             string               `json:"barcode1"`
+	Barcode2                             string               `json:"barcode2"`
+	AssetTag                             string               `json:"assetTag"`
+	RemoteManagement                     *RemoteManagement    `json:"remoteManagement,omitempty"`
+	Supervised                           bool                 `json:"supervised"`
+	MDMCapable                           *MDMCapability       `json:"mdmCapable,omitempty"`
+	ReportDate                           string               `json:"reportDate"`
+	LastContactTime                      string               `json:"lastContactTime"`
+	LastCloudBackupDate                  string               `json:"lastCloudBackupDate"`
+	LastEnrolledDate                     string               `json:"lastEnrolledDate"`
+	MDMProfileExpiration                 string               `json:"mdmProfileExpiration"`
+	InitialEntryDate                     string               `json:"initialEntryDate"`
+	DistributionPoint                    string               `json:"distributionPoint"`
+	Site                                 *Site                `json:"site,omitempty"`
+	ITunesStoreAccountActive             bool                 `json:"itunesStoreAccountActive"`
+	EnrolledViaAutomatedDeviceEnrollment bool                 `json:"enrolledViaAutomatedDeviceEnrollment"`
+	UserApprovedMDM                      bool                 `json:"userApprovedMdm"`
+	ExtensionAttributes                  []ExtensionAttribute `json:"extensionAttributes,omitempty"`
+}
+
+// RemoteManagement holds the management account details of a computer
+type RemoteManagement struct {
+	Managed            bool   `json:"managed"`
+	ManagementUsername string `json:"managementUsername"`
+}
+
+// MDMCapability holds whether a computer and its users are MDM capable
+type MDMCapability struct {
+	Capable      bool     `json:"capable"`
+	CapableUsers []string `json:"capableUsers"`
+}
+
+// Site represents the Jamf site a computer belongs to
+type Site struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+// DiskEncryption holds the DISK_ENCRYPTION section of a computer inventory record
+type DiskEncryption struct {
+	BootPartitionEncryptionDetails      *PartitionEncryption `json:"bootPartitionEncryptionDetails,omitempty"`
+	IndividualRecoveryKeyValidityStatus string               `json:"individualRecoveryKeyValidityStatus"`
+	InstitutionalRecoveryKeyPresent     bool                 `json:"institutionalRecoveryKeyPresent"`
+	DiskEncryptionConfigurationName     string               `json:"diskEncryptionConfigurationName"`
+	FileVault2EnabledUserNames          []string             `json:"fileVault2EnabledUserNames"`
+	FileVault2EligibilityMessage        string               `json:"fileVault2EligibilityMessage"`
+}
+
+// PartitionEncryption holds the FileVault state of a partition
+type PartitionEncryption struct {
+	PartitionName              string `json:"partitionName"`
+	PartitionFileVault2State   string `json:"partitionFileVault2State"`
+	PartitionFileVault2Percent int    `json:"partitionFileVault2Percent"`
+}
+
+// Purchasing holds the PURCHASING section of a computer inventory record
+type Purchasing struct {
+	Leased              bool                 `json:"leased"`
+	Purchased           bool                 `json:"purchased"`
+	PONumber            string               `json:"poNumber"`
+	PODate              string               `json:"poDate"`
+	Vendor              string               `json:"vendor"`
+	WarrantyDate        string               `json:"warrantyDate"`
+	AppleCareID         string               `json:"appleCareId"`
+	LeaseDate           string               `json:"leaseDate"`
+	PurchasePrice       string               `json:"purchasePrice"`
+	LifeExpectancy      int                  `json:"lifeExpectancy"`
+	PurchasingAccount   string               `json:"purchasingAccount"`
+	PurchasingContact   string               `json:"purchasingContact"`
+	ExtensionAttributes []ExtensionAttribute `json:"extensionAttributes,omitempty"`
+}
+
+// Application holds information about an application installed on a computer
+type Application struct {
+	Name              string `json:"name"`
+	Path              string `json:"path"`
+	Version           string `json:"version"`
+	MacAppStore       bool   `json:"macAppStore"`
+	SizeMegabytes     int    `json:"sizeMegabytes"`
+	BundleID          string `json:"bundleId"`
+	UpdateAvailable   bool   `json:"updateAvailable"`
+	ExternalVersionID string `json:"externalVersionId"`
+}
+
+// Storage holds the STORAGE section of a computer inventory record
+type Storage struct {
+	BootDriveAvailableSpaceMegabytes int64  `json:"bootDriveAvailableSpaceMegabytes"`
+	Disks                            []Disk `json:"disks"`
+}
+
+// Disk holds information about a disk attached to a computer
+type Disk struct {
+	ID            string      `json:"id"`
+	Device        string      `json:"device"`
+	Model         string      `json:"model"`
+	Revision      string      `json:"revision"`
+	SerialNumber  string      `json:"serialNumber"`
+	SizeMegabytes int64       `json:"sizeMegabytes"`
+	SmartStatus   string      `json:"smartStatus"`
+	Type          string      `json:"type"`
+	Partitions    []Partition `json:"partitions"`
+}
+
+// Partition holds information about a partition on a disk
+type Partition struct {
+	Name                      string `json:"name"`
+	SizeMegabytes             int64  `json:"sizeMegabytes"`
+	AvailableMegabytes        int64  `json:"availableMegabytes"`
+	PartitionType             string `json:"partitionType"`
+	PercentUsed               int    `json:"percentUsed"`
+	FileVault2State           string `json:"fileVault2State"`
+	FileVault2ProgressPercent int    `json:"fileVault2ProgressPercent"`
+	LVMManaged                bool   `json:"lvmManaged"`
+}
+
+// UserAndLocation holds the USER_AND_LOCATION section of a computer inventory record
+type UserAndLocation struct {
+	Username            string               `json:"username"`
+	Realname            string               `json:"realname"`
+	Email               string               `json:"email"`
+	Position            string               `json:"position"`
+	Phone               string               `json:"phone"`
+	DepartmentID        string               `json:"departmentId"`
+	BuildingID          string               `json:"buildingId"`
+	Room                string               `json:"room"`
+	ExtensionAttributes []ExtensionAttribute `json:"extensionAttributes,omitempty"`
+}
+
+// ConfigurationProfile represents a configuration profile installed on a computer
+type ConfigurationProfile struct {
+	ID                string `json:"id"`
+	Username          string `json:"username"`
+	LastInstalled     string `json:"lastInstalled"`
+	Removable         bool   `json:"removable"`
+	DisplayName       string `json:"displayName"`
+	ProfileIdentifier string `json:"profileIdentifier"`
+}
+
+// Printer represents a printer mapped on a computer
+type Printer struct {
+	Name     string `json:"name"`
+	Type     string `json:"type"`
+	URI      string `json:"uri"`
+	Location string `json:"location"`
+}
+
+// RunningService represents a service running on a computer
+type RunningService struct {
+	Name string `json:"name"`
+}
+
+// Hardware holds the HARDWARE section of a computer inventory record
+type Hardware struct {
+	Make                   string               `json:"make"`
+	Model                  string               `json:"model"`
+	ModelIdentifier        string               `json:"modelIdentifier"`
+	SerialNumber           string               `json:"serialNumber"`
+	ProcessorSpeedMhz      int                  `json:"processorSpeedMhz"`
+	ProcessorCount         int                  `json:"processorCount"`
+	CoreCount              int                  `json:"coreCount"`
+	ProcessorType          string               `json:"processorType"`
+	ProcessorArchitecture  string               `json:"processorArchitecture"`
+	BusSpeedMhz            int                  `json:"busSpeedMhz"`
+	CacheSizeKilobytes     int                  `json:"cacheSizeKilobytes"`
+	NetworkAdapterType     string               `json:"networkAdapterType"`
+	MACAddress             string               `json:"macAddress"`
+	AltNetworkAdapterType  string               `json:"altNetworkAdapterType"`
+	AltMACAddress          string               `json:"altMacAddress"`
+	TotalRAMMegabytes      int64                `json:"totalRamMegabytes"`
+	OpenRAMSlots           int                  `json:"openRamSlots"`
+	BatteryCapacityPercent int                  `json:"batteryCapacityPercent"`
+	SMCVersion             string               `json:"smcVersion"`
+	NicSpeed               string               `json:"nicSpeed"`
+	OpticalDrive           string               `json:"opticalDrive"`
+	BootRom                string               `json:"bootRom"`
+	BleCapable             bool                 `json:"bleCapable"`
+	SupportsIosAppInstalls bool                 `json:"supportsIosAppInstalls"`
+	AppleSilicon           bool                 `json:"appleSilicon"`
+	ExtensionAttributes    []ExtensionAttribute `json:"extensionAttributes,omitempty"`
+}
+
+// LocalUserAccount represents a local user account on a computer
+type LocalUserAccount struct {
+	UID                 string `json:"uid"`
+	UserGUID            string `json:"userGuid"`
+	Username            string `json:"username"`
+	FullName            string `json:"fullName"`
+	Admin               bool   `json:"admin"`
+	HomeDirectory       string `json:"homeDirectory"`
+	HomeDirectorySizeMb int64  `json:"homeDirectorySizeMb"`
+	FileVault2Enabled   bool   `json:"fileVault2Enabled"`
+	UserAccountType     string `json:"userAccountType"`
+}
+
+// Certificate represents a certificate installed on a computer
+type Certificate struct {
+	CommonName        string `json:"commonName"`
+	Identity          bool   `json:"identity"`
+	ExpirationDate    string `json:"expirationDate"`
+	Username          string `json:"username"`
+	LifecycleStatus   string `json:"lifecycleStatus"`
+	CertificateStatus string `json:"certificateStatus"`
+	SubjectName       string `json:"subjectName"`
+	SerialNumber      string `json:"serialNumber"`
+	SHA1Fingerprint   string `json:"sha1Fingerprint"`
+	IssuedDate        string `json:"issuedDate"`
+}
+
+// Security holds the SECURITY section of a computer inventory record
+type Security struct {
+	SIPStatus             string `json:"sipStatus"`
+	GatekeeperStatus      string `json:"gatekeeperStatus"`
+	XProtectVersion       string `json:"xprotectVersion"`
+	AutoLoginDisabled     bool   `json:"autoLoginDisabled"`
+	RemoteDesktopEnabled  bool   `json:"remoteDesktopEnabled"`
+	ActivationLockEnabled bool   `json:"activationLockEnabled"`
+	RecoveryLockEnabled   bool   `json:"recoveryLockEnabled"`
+	FirewallEnabled       bool   `json:"firewallEnabled"`
+	SecureBootLevel       string `json:"secureBootLevel"`
+	ExternalBootLevel     string `json:"externalBootLevel"`
+	BootstrapTokenAllowed bool   `json:"bootstrapTokenAllowed"`
+}
+
+// OperatingSystem holds the OPERATING_SYSTEM section of a computer inventory record
+type OperatingSystem struct {
+	Name                     string               `json:"name"`
+	Version                  string               `json:"version"`
+	Build                    string               `json:"build"`
+	SupplementalBuildVersion string               `json:"supplementalBuildVersion"`
+	RapidSecurityResponse    string               `json:"rapidSecurityResponse"`
+	ActiveDirectoryStatus    string               `json:"activeDirectoryStatus"`
+	FileVault2Status         string               `json:"fileVault2Status"`
+	SoftwareUpdateDeviceID   string               `json:"softwareUpdateDeviceId"`
+	ExtensionAttributes      []ExtensionAttribute `json:"extensionAttributes,omitempty"`
+}
+
+// PackageReceipts holds the PACKAGE_RECEIPTS section of a computer inventory record
+type PackageReceipts struct {
+	InstalledByJamfPro      []string `json:"installedByJamfPro"`
+	InstalledByInstallerSwu []string `json:"installedByInstallerSwu"`
+	Cached                  []string `json:"cached"`
+}
+
+// SoftwareUpdate represents a software update available to a computer
+type SoftwareUpdate struct {
+	Name        string `json:"name"`
+	Version     string `json:"version"`
+	PackageName string `json:"packageName"`
+}
+
+// ExtensionAttribute holds an extension attribute value reported for a computer
+type ExtensionAttribute struct {
+	DefinitionID string   `json:"definitionId"`
+	Name         string   `json:"name"`
+	Description  string   `json:"description"`
+	Enabled      bool     `json:"enabled"`
+	MultiValue   bool     `json:"multiValue"`
+	Values       []string `json:"values"`
+	DataType     string   `json:"dataType"`
+	Options      []string `json:"options"`
+	InputType    string   `json:"inputType"`
+}
+
+// GroupMembership represents a computer group the computer is a member of
+type GroupMembership struct {
+	GroupID    string `json:"groupId"`
+	GroupName  string `json:"groupName"`
+	SmartGroup bool   `json:"smartGroup"`
+}
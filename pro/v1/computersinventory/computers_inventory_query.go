@@ -0,0 +1,131 @@
+// Unless explicitly stated otherwise all files in this repository are licensed under the Apache-2.0
+// This product includes software developed at Datadog (https://www.datadoghq.com/). Copyright 2020 Datadog, Inc.
+
+package computersinventory
+
+import (
+	"net/url"
+
+	"github.com/trustero/jamf-api-client-go/pro/client"
+	"github.com/trustero/jamf-api-client-go/pro/rsql"
+)
+
+// Section represents a section of the computer inventory record that can be requested
+type Section string
+
+// Sections supported by the computers inventory endpoint. Jamf only returns GENERAL by default
+const (
+	SectionGeneral               Section = "GENERAL"
+	SectionDiskEncryption        Section = "DISK_ENCRYPTION"
+	SectionPurchasing            Section = "PURCHASING"
+	SectionApplications          Section = "APPLICATIONS"
+	SectionStorage               Section = "STORAGE"
+	SectionUserAndLocation       Section = "USER_AND_LOCATION"
+	SectionConfigurationProfiles Section = "CONFIGURATION_PROFILES"
+	SectionPrinters              Section = "PRINTERS"
+	SectionServices              Section = "SERVICES"
+	SectionHardware              Section = "HARDWARE"
+	SectionLocalUserAccounts     Section = "LOCAL_USER_ACCOUNTS"
+	SectionCertificates          Section = "CERTIFICATES"
+	SectionSecurity              Section = "SECURITY"
+	SectionOperatingSystem       Section = "OPERATING_SYSTEM"
+	SectionPackageReceipts       Section = "PACKAGE_RECEIPTS"
+	SectionSoftwareUpdates       Section = "SOFTWARE_UPDATES"
+	SectionExtensionAttributes   Section = "EXTENSION_ATTRIBUTES"
+	SectionGroupMemberships      Section = "GROUP_MEMBERSHIPS"
+)
+
+// Field represents a computer inventory field that can be used to filter or sort results
+type Field string
+
+// Commonly filtered computer inventory fields
+const (
+	FieldID                    Field = "id"
+	FieldUDID                  Field = "udid"
+	FieldName                  Field = "general.name"
+	FieldPlatform              Field = "general.platform"
+	FieldAssetTag              Field = "general.assetTag"
+	FieldLastIPAddress         Field = "general.lastIpAddress"
+	FieldReportDate            Field = "general.reportDate"
+	FieldLastContactTime       Field = "general.lastContactTime"
+	FieldManaged               Field = "general.remoteManagement.managed"
+	FieldSerialNumber          Field = "hardware.serialNumber"
+	FieldModel                 Field = "hardware.model"
+	FieldModelIdentifier       Field = "hardware.modelIdentifier"
+	FieldMACAddress            Field = "hardware.macAddress"
+	FieldOSName                Field = "operatingSystem.name"
+	FieldOSVersion             Field = "operatingSystem.version"
+	FieldOSBuild               Field = "operatingSystem.build"
+	FieldFileVault2Status      Field = "operatingSystem.fileVault2Status"
+	FieldUsername              Field = "userAndLocation.username"
+	FieldEmail                 Field = "userAndLocation.email"
+	FieldDepartmentID          Field = "userAndLocation.departmentId"
+	FieldBuildingID            Field = "userAndLocation.buildingId"
+	FieldGatekeeperStatus      Field = "security.gatekeeperStatus"
+	FieldSIPStatus             Field = "security.sipStatus"
+	FieldFirewallEnabled       Field = "security.firewallEnabled"
+	FieldActivationLockEnabled Field = "security.activationLockEnabled"
+	FieldLeased                Field = "purchasing.leased"
+	FieldWarrantyDate          Field = "purchasing.warrantyDate"
+	FieldDiskEncryptionConfig  Field = "diskEncryption.diskEncryptionConfigurationName"
+)
+
+// Eq returns a filter matching the field equal to value i.e hardware.serialNumber=="C02..."
+func (f Field) Eq(value string) rsql.Filter { return rsql.Eq(string(f), value) }
+
+// Ne returns a filter matching the field not equal to value
+func (f Field) Ne(value string) rsql.Filter { return rsql.Ne(string(f), value) }
+
+// Lt returns a filter matching the field less than value
+func (f Field) Lt(value string) rsql.Filter { return rsql.Lt(string(f), value) }
+
+// Le returns a filter matching the field less than or equal to value
+func (f Field) Le(value string) rsql.Filter { return rsql.Le(string(f), value) }
+
+// Gt returns a filter matching the field greater than value
+func (f Field) Gt(value string) rsql.Filter { return rsql.Gt(string(f), value) }
+
+// Ge returns a filter matching the field greater than or equal to value
+func (f Field) Ge(value string) rsql.Filter { return rsql.Ge(string(f), value) }
+
+// In returns a filter matching the field equal to any of the values
+func (f Field) In(values ...string) rsql.Filter { return rsql.In(string(f), values...) }
+
+// Out returns a filter matching the field not equal to any of the values
+func (f Field) Out(values ...string) rsql.Filter { return rsql.Out(string(f), values...) }
+
+// Asc returns a sort on the field in ascending order
+func (f Field) Asc() *rsql.Sort { return rsql.SortBy(string(f), rsql.Asc) }
+
+// Desc returns a sort on the field in descending order
+func (f Field) Desc() *rsql.Sort { return rsql.SortBy(string(f), rsql.Desc) }
+
+// ListOptions holds the query parameters for the computers inventory endpoint
+type ListOptions struct {
+	Sections []Section
+	Filter   rsql.Filter
+	Sort     *rsql.Sort
+	Page     int
+	PageSize int
+}
+
+func (o *ListOptions) listOptions() *client.ListOptions {
+	if o == nil {
+		return nil
+	}
+
+	opts := &client.ListOptions{
+		Page:     o.Page,
+		PageSize: o.PageSize,
+		Sort:     o.Sort,
+		Filter:   o.Filter,
+	}
+
+	if len(o.Sections) > 0 {
+		opts.Extra = url.Values{}
+		for _, s := range o.Sections {
+			opts.Extra.Add("section", string(s))
+		}
+	}
+	return opts
+}
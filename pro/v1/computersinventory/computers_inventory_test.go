@@ -0,0 +1,126 @@
+// Unless explicitly stated otherwise all files in this repository are licensed under the Apache-2.0
+// This product includes software developed at Datadog (https://www.datadoghq.com/). Copyright 2020 Datadog, Inc.
+
+package computersinventory_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/trustero/jamf-api-client-go/pro/rsql"
+	jamf "github.com/trustero/jamf-api-client-go/pro/v1/computersinventory"
+)
+
+var COMPUTERS_INVENTORY_API_BASE_ENDPOINT = "/api/v1/computers-inventory"
+
+func computersInventoryResponseMocks(t *testing.T) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v1/auth/token":
+			fmt.Fprint(w, `{"token": "mock-token", "expires": "2999-01-01T00:00:00.000Z"}`)
+		case COMPUTERS_INVENTORY_API_BASE_ENDPOINT:
+			assert.Equal(t, []string{"GENERAL", "HARDWARE"}, r.URL.Query()["section"])
+			assert.Equal(t, "hardware.model==\"MacBook Pro\";general.platform==Mac", r.URL.Query().Get("filter"))
+			switch r.URL.Query().Get("page") {
+			case "0":
+				fmt.Fprint(w, `{
+					"totalCount": 3,
+					"results": [
+						{"id": "1", "udid": "123", "general": {"name": "Test MacBook #1", "platform": "Mac"}, "hardware": {"model": "MacBook Pro", "serialNumber": "C02ABC123", "appleSilicon": true}},
+						{"id": "2", "udid": "456", "general": {"name": "Test MacBook #2", "platform": "Mac"}}
+					]
+				}`)
+			default:
+				fmt.Fprint(w, `{
+					"totalCount": 3,
+					"results": [
+						{"id": "3", "udid": "789", "general": {"name": "Test MacBook #3", "platform": "Mac"}}
+					]
+				}`)
+			}
+		case fmt.Sprintf("%s/2", COMPUTERS_INVENTORY_API_BASE_ENDPOINT):
+			assert.Equal(t, []string{"GENERAL", "SECURITY"}, r.URL.Query()["section"])
+			fmt.Fprint(w, `{
+				"id": "2",
+				"udid": "456",
+				"general": {"name": "Test MacBook #2", "lastIpAddress": "10.0.0.2", "platform": "Mac", "remoteManagement": {"managed": true}},
+				"security": {"sipStatus": "ENABLED", "firewallEnabled": true}
+			}`)
+		default:
+			http.Error(w, fmt.Sprintf("bad Jamf Pro API call to %s", r.URL), http.StatusInternalServerError)
+		}
+	}))
+}
+
+func TestListComputersInventory(t *testing.T) {
+	testServer := computersInventoryResponseMocks(t)
+	defer testServer.Close()
+	j, err := jamf.NewService(testServer.URL, "fake-username", "mock-password-cool", nil)
+	assert.Nil(t, err)
+
+	opts := &jamf.ListOptions{
+		Sections: []jamf.Section{jamf.SectionGeneral, jamf.SectionHardware},
+		Filter:   rsql.And(jamf.FieldModel.Eq("MacBook Pro"), jamf.FieldPlatform.Eq("Mac")),
+		PageSize: 2,
+	}
+
+	page, _, err := j.List(opts)
+	assert.Nil(t, err)
+	assert.Equal(t, 3, page.TotalCount)
+	assert.Len(t, page.Results, 2)
+	assert.Equal(t, "Test MacBook #1", page.Results[0].General.Name)
+	assert.Equal(t, "C02ABC123", page.Results[0].Hardware.SerialNumber)
+	assert.True(t, page.Results[0].Hardware.AppleSilicon)
+	assert.Nil(t, page.Results[0].Security)
+
+	all, err := j.ListAll(opts)
+	assert.Nil(t, err)
+	assert.Len(t, all, 3)
+	assert.Equal(t, "789", all[2].UDID)
+}
+
+func TestIterateComputersInventory(t *testing.T) {
+	testServer := computersInventoryResponseMocks(t)
+	defer testServer.Close()
+	j, err := jamf.NewService(testServer.URL, "fake-username", "mock-password-cool", nil)
+	assert.Nil(t, err)
+
+	opts := &jamf.ListOptions{
+		Sections: []jamf.Section{jamf.SectionGeneral, jamf.SectionHardware},
+		Filter:   rsql.And(jamf.FieldModel.Eq("MacBook Pro"), jamf.FieldPlatform.Eq("Mac")),
+		PageSize: 2,
+	}
+
+	it := j.Iterate(opts)
+	ids := []string{}
+	for it.Next(context.Background()) {
+		ids = append(ids, it.Value().ID)
+		if it.Value().ID == "2" {
+			it.Stop()
+		}
+	}
+	assert.Nil(t, it.Err())
+	assert.Equal(t, []string{"1", "2"}, ids)
+	total, ok := it.TotalCount()
+	assert.True(t, ok)
+	assert.Equal(t, 3, total)
+}
+
+func TestGetComputerInventoryById(t *testing.T) {
+	testServer := computersInventoryResponseMocks(t)
+	defer testServer.Close()
+	j, err := jamf.NewService(testServer.URL, "fake-username", "mock-password-cool", nil)
+	assert.Nil(t, err)
+
+	computer, _, err := j.GetById("2", jamf.SectionGeneral, jamf.SectionSecurity)
+	assert.Nil(t, err)
+	assert.Equal(t, "2", computer.ID)
+	assert.Equal(t, "10.0.0.2", computer.General.LastIPAddress)
+	assert.True(t, computer.General.RemoteManagement.Managed)
+	assert.Equal(t, "ENABLED", computer.Security.SIPStatus)
+	assert.True(t, computer.Security.FirewallEnabled)
+}
@@ -0,0 +1,34 @@
+// Unless explicitly stated otherwise all files in this repository are licensed under the Apache-2.0
+// This product includes software developed at Datadog (https://www.datadoghq.com/). Copyright 2020 Datadog, Inc.
+
+package jamfproversion
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/pkg/errors"
+	"github.com/trustero/jamf-api-client-go/pro/client"
+)
+
+// JamfProVersion holds the version of the Jamf Pro server
+type JamfProVersion struct {
+	Version string `json:"version"`
+}
+
+// Get returns the version of the Jamf Pro server
+func (j *Service) Get() (result *JamfProVersion, response *http.Response, err error) {
+	req, err := client.NewRequest(context.Background(), "GET", j.client.Endpoint, nil)
+	if err != nil {
+		err = errors.Wrap(err, "error building Jamf Pro version request")
+		return
+	}
+
+	res := &JamfProVersion{}
+	if response, err = client.MakeAPIrequest(j.client, req, res); err != nil {
+		err = errors.Wrapf(err, "unable to query Jamf Pro version from %s", j.client.Endpoint)
+		return
+	}
+	result = res
+	return
+}
@@ -0,0 +1,38 @@
+// Unless explicitly stated otherwise all files in this repository are licensed under the Apache-2.0
+// This product includes software developed at Datadog (https://www.datadoghq.com/). Copyright 2020 Datadog, Inc.
+
+package jamfproversion_test
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	jamf "github.com/trustero/jamf-api-client-go/pro/v1/jamfproversion"
+)
+
+func versionResponseMocks(t *testing.T) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.RequestURI {
+		case "/api/v1/auth/token":
+			fmt.Fprint(w, `{"token": "mock-token", "expires": "2999-01-01T00:00:00.000Z"}`)
+		case "/api/v1/jamf-pro-version":
+			assert.Equal(t, "Bearer mock-token", r.Header.Get("Authorization"))
+			fmt.Fprint(w, `{"version": "10.27.0-t1612301116"}`)
+		default:
+			http.Error(w, fmt.Sprintf("bad Jamf Pro API call to %s", r.URL), http.StatusInternalServerError)
+		}
+	}))
+}
+
+func TestGetJamfProVersion(t *testing.T) {
+	testServer := versionResponseMocks(t)
+	defer testServer.Close()
+	j, err := jamf.NewService(testServer.URL, "fake-username", "mock-password-cool", nil)
+	assert.Nil(t, err)
+	version, _, err := j.Get()
+	assert.Nil(t, err)
+	assert.Equal(t, "10.27.0-t1612301116", version.Version)
+}